@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// config holds the CLI settings. They are read from the JSON file at ACAI_CONFIG (defaults to
+// acai/config.json in the user's config directory), and overridden by the API_URL and API_TOKEN environment
+// variables.
+type config struct {
+	URL   string `json:"url"`
+	Token string `json:"token"`
+}
+
+func loadConfig() (config, error) {
+	cfg := config{URL: "http://localhost:8080"}
+
+	path := os.Getenv("ACAI_CONFIG")
+	if path == "" {
+		if dir, err := os.UserConfigDir(); err == nil {
+			path = filepath.Join(dir, "acai", "config.json")
+		}
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return cfg, fmt.Errorf("failed to read config: %w", err)
+		}
+
+		if err == nil {
+			if err := json.Unmarshal(data, &cfg); err != nil {
+				return cfg, fmt.Errorf("failed to parse config %s: %w", path, err)
+			}
+		}
+	}
+
+	if v := os.Getenv("API_URL"); v != "" {
+		cfg.URL = v
+	}
+
+	if v := os.Getenv("API_TOKEN"); v != "" {
+		cfg.Token = v
+	}
+
+	return cfg, nil
+}
+
+// httpClient returns a client sending the configured token with every request.
+func (c config) httpClient() *http.Client {
+	if c.Token == "" {
+		return http.DefaultClient
+	}
+
+	return &http.Client{Transport: bearerTransport{token: c.Token, next: http.DefaultTransport}}
+}
+
+type bearerTransport struct {
+	token string
+	next  http.RoundTripper
+}
+
+func (t bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.next.RoundTrip(req)
+}
@@ -5,8 +5,10 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"net/http"
+	"mime"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/acai-travel/tech-challenge/internal/pb"
@@ -16,9 +18,12 @@ func main() {
 	flag.Usage = func() {
 		fmt.Printf("Usage: acai-cli [command] [options]\n")
 		fmt.Println("Commands:")
-		fmt.Println("  ask        Create a new conversation with assistant or continue an existing one")
-		fmt.Println("  list       List existing conversations")
-		fmt.Println("  show       Show conversation by ID")
+		fmt.Println("  ask        Create a new conversation with assistant or continue an existing one (--persona ID, --tools, --disable-tools)")
+		fmt.Println("  list       List existing conversations (--limit N, --all)")
+		fmt.Println("  show       Show conversation by ID (--verbose)")
+		fmt.Println("  personas   List personas conversations can be started with")
+		fmt.Println("  memory     List what the assistant remembers about you (list), or make it forget a fact (delete ID)")
+		fmt.Println("  upload     Upload a text document the assistant can answer from (--conversation ID, --type)")
 	}
 
 	if len(os.Args) < 2 {
@@ -28,22 +33,36 @@ func main() {
 		os.Exit(-1)
 	}
 
-	url := "http://localhost:8080"
-	if v := os.Getenv("API_URL"); v != "" {
-		url = v
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
 	}
 
-	cli := pb.NewChatServiceJSONClient(url, http.DefaultClient)
+	client := cfg.httpClient()
+	cli := pb.NewChatServiceJSONClient(cfg.URL, client)
 	ctx := context.Background()
 
 	switch os.Args[1] {
 	case "ask":
+		flags := flag.NewFlagSet("ask", flag.ExitOnError)
+		persona := flags.String("persona", "", "persona to start the conversation with")
+		tools := flags.String("tools", "", "comma-separated tools the assistant may use, all of them by default")
+		disabled := flags.String("disable-tools", "", "comma-separated tools the assistant may not use")
+		_ = flags.Parse(os.Args[2:])
+
+		cid := flags.Arg(0)
+		if cid != "" && (*persona != "" || *tools != "" || *disabled != "") {
+			fmt.Println("Error: --persona, --tools and --disable-tools can only be used to start a new conversation")
+			os.Exit(1)
+		}
+
+		start := streamRequest{PersonaID: *persona, Tools: splitList(*tools), DisabledTools: splitList(*disabled)}
+
 		fmt.Println("Press CMD+C to exit.")
 		fmt.Println()
 
-		cid := ""
-		if len(os.Args) >= 3 {
-			cid = os.Args[2]
+		if cid != "" {
 			resp, err := cli.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: cid})
 
 			if err != nil {
@@ -55,9 +74,7 @@ func main() {
 			fmt.Println("Title:", resp.GetConversation().GetTitle())
 			fmt.Println("Timestamp:", resp.GetConversation().GetTimestamp().AsTime().Format(time.RFC1123))
 			fmt.Println("")
-			for _, msg := range resp.GetConversation().GetMessages() {
-				fmt.Printf("%s, %s:\n%s\n\n", msg.GetRole(), msg.GetTimestamp().AsTime().Format(time.TimeOnly), msg.GetContent())
-			}
+			printMessages(resp.GetConversation().GetMessages(), false)
 		} else {
 			fmt.Println("Starting a new conversation, type your message below.")
 			fmt.Println()
@@ -75,63 +92,189 @@ func main() {
 
 			fmt.Println()
 
-			if cid == "" {
-				out, err := cli.StartConversation(ctx, &pb.StartConversationRequest{
-					Message: string(line),
-				})
+			req := start
+			if cid != "" {
+				req = streamRequest{ConversationID: cid}
+			}
+			req.Message = string(line)
 
-				if err != nil {
-					fmt.Printf("Error starting conversation: %v\n", err)
-					os.Exit(1)
+			saved, err := streamReply(ctx, client, cfg.URL, req, func(event streamEvent) {
+				switch event.Type {
+				case "started":
+					if cid == "" {
+						fmt.Println("New conversation started:")
+						fmt.Println("ID:", event.ConversationID)
+						fmt.Println()
+					}
+					fmt.Printf("ASSISTANT:\n")
+				case "delta":
+					fmt.Print(event.Delta)
+				case "tool_call_started":
+					fmt.Printf("[calling %s(%s)]\n", event.ToolCall.Name, event.ToolCall.Arguments)
+				case "tool_call_finished":
+					if event.ToolCall.Error != "" {
+						fmt.Printf("[%s failed: %s]\n", event.ToolCall.Name, event.ToolCall.Error)
+					}
 				}
+			})
 
-				fmt.Println("New conversation started:")
-				fmt.Println("ID:", out.GetConversationId())
-				fmt.Println("Title:", out.GetTitle())
-				fmt.Println()
+			if err != nil {
+				fmt.Printf("\nError getting reply: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("\n\n")
+			printCitations(saved.Citations)
 
-				cid = out.GetConversationId()
-				fmt.Printf("ASSISTANT:\n%s\n\n", out.GetReply())
-				continue
+			if cid == "" {
+				fmt.Println("Title:", saved.Title)
+				fmt.Println()
+				cid = saved.ConversationID
 			}
+		}
 
-			out, err := cli.ContinueConversation(ctx, &pb.ContinueConversationRequest{
-				ConversationId: cid,
-				Message:        string(line),
-			})
+	case "upload":
+		flags := flag.NewFlagSet("upload", flag.ExitOnError)
+		conversation := flags.String("conversation", "", "conversation the document is only searched in, every conversation by default")
+		contentType := flags.String("type", "", "content type of the document, guessed from its extension by default")
+		_ = flags.Parse(os.Args[2:])
+
+		// Flags are accepted both before and after the file.
+		file := flags.Arg(0)
+		if flags.NArg() > 0 {
+			_ = flags.Parse(flags.Args()[1:])
+		}
+
+		if file == "" {
+			fmt.Println("Error: File is required")
+			os.Exit(1)
+		}
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Printf("Error reading document: %v\n", err)
+			os.Exit(1)
+		}
+
+		if *contentType == "" {
+			*contentType = mime.TypeByExtension(filepath.Ext(file))
+		}
+
+		resp, err := cli.UploadDocument(ctx, &pb.UploadDocumentRequest{
+			Name:           filepath.Base(file),
+			ContentType:    *contentType,
+			Content:        content,
+			ConversationId: *conversation,
+		})
+		if err != nil {
+			fmt.Printf("Error uploading document: %v\n", err)
+			os.Exit(1)
+		}
+
+		doc := resp.GetDocument()
+		fmt.Println("ID:", doc.GetId())
+		fmt.Println("Name:", doc.GetName())
+		fmt.Printf("Size: %d bytes, %d parts\n", doc.GetSize(), doc.GetChunks())
+		if doc.GetConversationId() != "" {
+			fmt.Println("Conversation:", doc.GetConversationId())
+		}
+	case "personas":
+		resp, err := cli.ListPersonas(ctx, &pb.ListPersonasRequest{})
+		if err != nil {
+			fmt.Printf("Error listing personas: %v\n", err)
+			os.Exit(1)
+		}
 
+		if len(resp.GetPersonas()) == 0 {
+			fmt.Println("No personas found.")
+			return
+		}
+
+		fmt.Println("ID                   NAME")
+		for _, p := range resp.GetPersonas() {
+			fmt.Printf("%-20s %s\n", p.GetId(), p.GetName())
+		}
+	case "memory":
+		var action, id string
+		if len(os.Args) > 2 {
+			action = os.Args[2]
+		}
+		if len(os.Args) > 3 {
+			id = os.Args[3]
+		}
+
+		switch action {
+		case "", "list":
+			resp, err := cli.ListMemories(ctx, &pb.ListMemoriesRequest{})
 			if err != nil {
-				fmt.Printf("Error continuing conversation: %v\n", err)
+				fmt.Printf("Error listing memories: %v\n", err)
 				os.Exit(1)
 			}
 
-			fmt.Printf("ASSISTANT:\n%s\n\n", out.GetReply())
-		}
+			if len(resp.GetMemories()) == 0 {
+				fmt.Println("No memories found.")
+				return
+			}
+
+			fmt.Println("ID                         DATE         CONTENT")
+			for _, m := range resp.GetMemories() {
+				fmt.Printf("%s   %s   %s\n", m.GetId(), m.GetCreatedAt().AsTime().Format(time.DateOnly), m.GetContent())
+			}
+		case "delete":
+			if id == "" {
+				fmt.Println("Error: Memory ID is required")
+				os.Exit(1)
+			}
+
+			if _, err := cli.DeleteMemory(ctx, &pb.DeleteMemoryRequest{MemoryId: id}); err != nil {
+				fmt.Printf("Error deleting memory: %v\n", err)
+				os.Exit(1)
+			}
 
+			fmt.Println("Memory deleted.")
+		default:
+			fmt.Printf("Error: Unknown memory command %q, expected list or delete\n", action)
+			os.Exit(1)
+		}
 	case "list":
-		resp, err := cli.ListConversations(ctx, &pb.ListConversationsRequest{})
+		flags := flag.NewFlagSet("list", flag.ExitOnError)
+		limit := flags.Int("limit", 20, "maximum number of conversations to list")
+		all := flags.Bool("all", false, "list all conversations, ignoring --limit")
+		_ = flags.Parse(os.Args[2:])
+
+		conversations, err := listConversations(ctx, cli, *limit, *all)
 		if err != nil {
 			fmt.Printf("Error listing conversations: %v\n", err)
 			os.Exit(1)
 		}
 
-		if len(resp.Conversations) == 0 {
+		if len(conversations) == 0 {
 			fmt.Println("No conversations found.")
 			return
 		}
 
 		fmt.Println("ID                         TITLE")
-		for _, conv := range resp.Conversations {
+		for _, conv := range conversations {
 			fmt.Printf("%s   %s\n", conv.GetId(), conv.GetTitle())
 		}
 	case "show":
-		if len(os.Args) < 3 {
+		flags := flag.NewFlagSet("show", flag.ExitOnError)
+		verbose := flags.Bool("verbose", false, "include the tool calls made for every reply")
+		_ = flags.Parse(os.Args[2:])
+
+		// Flags are accepted both before and after the conversation ID.
+		id := flags.Arg(0)
+		if flags.NArg() > 0 {
+			_ = flags.Parse(flags.Args()[1:])
+		}
+
+		if id == "" {
 			fmt.Println("Error: Conversation ID is required")
 			os.Exit(1)
 		}
 
 		resp, err := cli.DescribeConversation(ctx, &pb.DescribeConversationRequest{
-			ConversationId: os.Args[2],
+			ConversationId: id,
 		})
 
 		if err != nil {
@@ -142,9 +285,80 @@ func main() {
 		fmt.Println("ID:", resp.GetConversation().GetId())
 		fmt.Println("Title:", resp.GetConversation().GetTitle())
 		fmt.Println("Timestamp:", resp.GetConversation().GetTimestamp().AsTime().Format(time.RFC1123))
+		if *verbose {
+			fmt.Println("Tools:", strings.Join(resp.GetConversation().GetTools(), ", "))
+		}
 		fmt.Println("")
-		for _, msg := range resp.GetConversation().GetMessages() {
+		printMessages(resp.GetConversation().GetMessages(), *verbose)
+	}
+}
+
+// printMessages prints the messages of a conversation. Tool calls are only printed when verbose is set.
+func printMessages(messages []*pb.Conversation_Message, verbose bool) {
+	for _, msg := range messages {
+		call := msg.GetToolCall()
+		if msg.GetRole() != pb.Conversation_TOOL {
 			fmt.Printf("%s, %s:\n%s\n\n", msg.GetRole(), msg.GetTimestamp().AsTime().Format(time.TimeOnly), msg.GetContent())
+			printCitations(msg.GetCitations())
+		} else if verbose {
+			fmt.Printf("TOOL, %s: %s(%s) took %s\n", msg.GetTimestamp().AsTime().Format(time.TimeOnly), call.GetName(), call.GetArguments(), call.GetDuration().AsDuration())
+			if call.GetError() != "" {
+				fmt.Printf("failed: %s\n\n", call.GetError())
+			} else {
+				fmt.Printf("%s\n\n", msg.GetContent())
+			}
 		}
 	}
 }
+
+// printCitations prints the passages of documents a reply cites, if any.
+func printCitations(citations []*pb.Citation) {
+	if len(citations) == 0 {
+		return
+	}
+
+	fmt.Println("Sources:")
+	for _, c := range citations {
+		fmt.Printf("[%d] %s, part %d: %s\n", c.GetNumber(), c.GetDocumentName(), c.GetChunk()+1, c.GetExcerpt())
+	}
+	fmt.Println()
+}
+
+// splitList splits a comma-separated flag value, ignoring blanks.
+func splitList(v string) []string {
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// listConversations pages through the most recent conversations until limit of them are fetched, or all of
+// them when all is set.
+func listConversations(ctx context.Context, cli pb.ChatService, limit int, all bool) ([]*pb.Conversation, error) {
+	var conversations []*pb.Conversation
+	req := &pb.ListConversationsRequest{}
+
+	for all || len(conversations) < limit {
+		req.PageSize = 100
+		if !all {
+			req.PageSize = int32(min(limit-len(conversations), 100))
+		}
+
+		resp, err := cli.ListConversations(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		conversations = append(conversations, resp.GetConversations()...)
+
+		if resp.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+
+	return conversations, nil
+}
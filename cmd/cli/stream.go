@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/pb"
+)
+
+// streamEvent mirrors the JSON payload of the server-sent events emitted by the streaming endpoint.
+type streamEvent struct {
+	Type           string `json:"-"`
+	ConversationID string `json:"conversation_id"`
+	Title          string `json:"title"`
+	Delta          string `json:"delta"`
+	Reply          string `json:"reply"`
+	ToolCall       *struct {
+		ID        string `json:"id"`
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+		Error     string `json:"error"`
+	} `json:"tool_call"`
+	Citations []*pb.Citation `json:"citations"`
+	Error     string         `json:"error"`
+}
+
+// streamRequest is the JSON body of the streaming endpoint. The persona and tools are only set to start a new
+// conversation, when ConversationID is empty.
+type streamRequest struct {
+	ConversationID string   `json:"conversation_id,omitempty"`
+	PersonaID      string   `json:"persona_id,omitempty"`
+	Tools          []string `json:"tools,omitempty"`
+	DisabledTools  []string `json:"disabled_tools,omitempty"`
+	Message        string   `json:"message"`
+}
+
+// streamReply sends the request to the streaming endpoint, and calls onEvent for every event received. It
+// returns the final "saved" event.
+func streamReply(ctx context.Context, client *http.Client, url string, sr streamRequest, onEvent func(streamEvent)) (streamEvent, error) {
+	body, err := json.Marshal(sr)
+	if err != nil {
+		return streamEvent{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url+"/stream/conversation", bytes.NewReader(body))
+	if err != nil {
+		return streamEvent{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return streamEvent{}, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		var twerr struct {
+			Msg string `json:"msg"`
+		}
+		raw, _ := io.ReadAll(resp.Body)
+		if json.Unmarshal(raw, &twerr) == nil && twerr.Msg != "" {
+			return streamEvent{}, fmt.Errorf("%s (HTTP %d)", twerr.Msg, resp.StatusCode)
+		}
+		return streamEvent{}, fmt.Errorf("unexpected HTTP status %d", resp.StatusCode)
+	}
+
+	var typ string
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			typ = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			var event streamEvent
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data:")), &event); err != nil {
+				return streamEvent{}, fmt.Errorf("failed to decode %q event: %w", typ, err)
+			}
+			event.Type = typ
+
+			switch typ {
+			case "error":
+				return streamEvent{}, fmt.Errorf("%s", event.Error)
+			case "saved":
+				return event, nil
+			}
+
+			onEvent(event)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return streamEvent{}, err
+	}
+
+	return streamEvent{}, fmt.Errorf("stream ended before the reply was saved")
+}
@@ -0,0 +1,65 @@
+// Command mcp-server serves the assistant's built-in tools over the Model Context Protocol, so other agents can
+// use them without going through the chat API. It talks over stdio by default, or serves streamable HTTP at /mcp
+// on the address given with -listen, authenticated with the key set of AUTH_KEYS_FILE when set.
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/httpx"
+	"github.com/acai-travel/tech-challenge/internal/mcp"
+	"github.com/acai-travel/tech-challenge/internal/tools"
+	"github.com/gorilla/mux"
+)
+
+func main() {
+	listen := flag.String("listen", "", "serve streamable HTTP on this address, e.g. :8081, instead of stdio")
+	flag.Parse()
+
+	server := mcp.NewServer(tools.NewRegistry(), mcp.Implementation{Name: "acai-tools", Version: "1.0.0"})
+	go tools.DefaultCalendars().Run(context.Background())
+
+	if *listen == "" {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		// Logs go to stderr, stdout carries the protocol.
+		if err := server.ServeStdio(ctx, os.Stdin, os.Stdout); err != nil {
+			slog.Error("Failed to read MCP messages", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	handler := mux.NewRouter()
+	handler.Use(httpx.Logger(), httpx.Recovery())
+	handler.Handle("/mcp", mustAuthenticator()(server))
+
+	slog.Info("Serving MCP over HTTP", "address", *listen)
+	if err := http.ListenAndServe(*listen, handler); err != nil {
+		panic(err)
+	}
+}
+
+// mustAuthenticator returns the authentication middleware for the key set in AUTH_KEYS_FILE. Without it,
+// authentication is disabled.
+func mustAuthenticator() func(http.Handler) http.Handler {
+	path := os.Getenv("AUTH_KEYS_FILE")
+	if path == "" {
+		slog.Warn("AUTH_KEYS_FILE not set, authentication is disabled")
+		return func(handler http.Handler) http.Handler { return handler }
+	}
+
+	keys, err := auth.LoadKeySet(path)
+	if err != nil {
+		panic(err)
+	}
+
+	return httpx.Authenticate(keys)
+}
@@ -0,0 +1,201 @@
+// Command mcp-testserver is a minimal MCP server for testing the MCP client. It serves a few tools over stdio, or
+// over streamable HTTP at /mcp with -listen, printing the endpoint URL once it listens:
+//   - echo returns its text,
+//   - add returns the sum of a and b,
+//   - fail always reports an error,
+//   - broken has an input schema that is not an object, and is not usable.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+var tools = []map[string]any{
+	{
+		"name":        "echo",
+		"description": "Returns its text",
+		"inputSchema": map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"text": map[string]any{"type": "string", "description": "Text to echo"}},
+			"required":   []string{"text"},
+		},
+		"annotations": map[string]any{"readOnlyHint": true},
+	},
+	{
+		"name":        "add",
+		"description": "Adds two numbers",
+		"inputSchema": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"a": map[string]any{"type": "number"},
+				"b": map[string]any{"type": []string{"number", "null"}},
+			},
+			"required": []string{"a", "b"},
+		},
+	},
+	{
+		"name":        "fail",
+		"title":       "Always fails",
+		"inputSchema": map[string]any{"type": "object"},
+	},
+	{
+		"name":        "broken",
+		"description": "Has an invalid input schema",
+		"inputSchema": "object",
+	},
+}
+
+func main() {
+	listen := flag.String("listen", "", "serve streamable HTTP on this address instead of stdio")
+	flag.Parse()
+
+	if *listen != "" {
+		serveHTTP(*listen)
+		return
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	out := json.NewEncoder(os.Stdout)
+
+	for scanner.Scan() {
+		var req message
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			continue
+		}
+
+		if resp := handle(&req); resp != nil {
+			_ = out.Encode(resp)
+		}
+	}
+}
+
+// handle returns the response to the request, or nil for notifications.
+func handle(req *message) *message {
+	if req.ID == nil {
+		return nil
+	}
+
+	resp := &message{JSONRPC: "2.0", ID: req.ID}
+
+	switch req.Method {
+	case "initialize":
+		resp.Result = map[string]any{
+			"protocolVersion": "2025-06-18",
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": "mcp-testserver", "version": "1.0.0"},
+		}
+	case "ping":
+		resp.Result = map[string]any{}
+	case "tools/list":
+		resp.Result = map[string]any{"tools": tools}
+	case "tools/call":
+		var params struct {
+			Name      string `json:"name"`
+			Arguments struct {
+				Text string  `json:"text"`
+				A    float64 `json:"a"`
+				B    float64 `json:"b"`
+			} `json:"arguments"`
+		}
+		_ = json.Unmarshal(req.Params, &params)
+
+		switch params.Name {
+		case "echo":
+			resp.Result = textResult(params.Arguments.Text, false)
+		case "add":
+			resp.Result = textResult(strconv.FormatFloat(params.Arguments.A+params.Arguments.B, 'f', -1, 64), false)
+		case "fail":
+			resp.Result = textResult("something went wrong", true)
+		default:
+			resp.Error = &rpcError{Code: -32602, Message: "unknown tool: " + params.Name}
+		}
+	default:
+		resp.Error = &rpcError{Code: -32601, Message: "method not found"}
+	}
+
+	return resp
+}
+
+func textResult(text string, isError bool) map[string]any {
+	return map[string]any{
+		"content": []map[string]any{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
+
+// serveHTTP serves the streamable HTTP transport. Tool calls are answered with a stream of server-sent events,
+// other requests with JSON, to exercise both kinds of responses.
+func serveHTTP(addr string) {
+	const session = "test-session"
+
+	http.HandleFunc("/mcp", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		var req message
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if req.Method != "initialize" && r.Header.Get("Mcp-Session-Id") != session {
+			http.Error(w, "unknown session", http.StatusNotFound)
+			return
+		}
+
+		resp := handle(&req)
+		if resp == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		data, _ := json.Marshal(resp)
+		w.Header().Set("Mcp-Session-Id", session)
+
+		if req.Method == "tools/call" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = fmt.Fprintf(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\"}\n\n")
+			_, _ = fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	})
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("http://%s/mcp\n", listener.Addr())
+	if err := http.Serve(listener, nil); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
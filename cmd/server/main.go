@@ -1,17 +1,29 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
+	"github.com/acai-travel/tech-challenge/internal/auth"
 	"github.com/acai-travel/tech-challenge/internal/chat"
 	"github.com/acai-travel/tech-challenge/internal/chat/assistant"
 	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/documents"
 	"github.com/acai-travel/tech-challenge/internal/httpx"
+	"github.com/acai-travel/tech-challenge/internal/llm"
+	"github.com/acai-travel/tech-challenge/internal/mcp"
 	"github.com/acai-travel/tech-challenge/internal/mongox"
 	"github.com/acai-travel/tech-challenge/internal/pb"
 	"github.com/acai-travel/tech-challenge/internal/telemetry"
+	"github.com/acai-travel/tech-challenge/internal/tools"
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/twitchtv/twirp"
@@ -23,10 +35,17 @@ func main() {
 	telemetry.InitTracing()
 	metrics := telemetry.NewMetrics()
 
-	mongo := mongox.MustConnect()
-	repo := model.New(mongo)
-	assist := assistant.New()
-	server := chat.NewServer(repo, assist)
+	repo, index := mustOpenStore()
+	library := documents.NewLibrary(mustEmbedder(), index)
+	opts, mcpClients := assistantOptions(repo, library)
+	assist := assistant.New(mustNewProvider(), opts...)
+	server := chat.NewServer(repo, assist, chat.WithDocuments(library))
+
+	// Deleted conversations are kept for a retention period before they are removed for good
+	go chat.PurgeDeleted(context.Background(), repo, mustDuration("DELETED_RETENTION", 30*24*time.Hour), time.Hour)
+
+	// Holiday calendars are fetched once, then refreshed in the background
+	go tools.DefaultCalendars().Run(context.Background())
 
 	// Configure handler with telemetry
 	handler := mux.NewRouter()
@@ -43,13 +62,239 @@ func main() {
 	// Metrics endpoint
 	handler.Handle("/metrics", promhttp.Handler())
 
+	// API handlers require authentication when a key set is configured
+	authenticate := mustAuthenticator()
+
 	// Twirp handler with automatic tracing
 	twirpHandler := pb.NewChatServiceServer(server, twirp.WithServerJSONSkipDefaults(true))
-	handler.PathPrefix("/twirp/").Handler(otelhttp.NewHandler(twirpHandler, "chat-api"))
+	handler.PathPrefix("/twirp/").Handler(otelhttp.NewHandler(authenticate(twirpHandler), "chat-api"))
+
+	// Streaming handler, answers with server-sent events while the reply is generated
+	handler.Handle("/stream/conversation", otelhttp.NewHandler(authenticate(server.StreamHandler()), "chat-stream"))
+
+	// Start server, until interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	httpServer := &http.Server{Addr: ":8080", Handler: handler}
+	go func() {
+		<-ctx.Done()
+
+		slog.Info("Shutting down server...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
 
-	// Start server
 	slog.Info("Starting server with metrics and tracing...")
-	if err := http.ListenAndServe(":8080", handler); err != nil {
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		panic(err)
+	}
+
+	// MCP servers run as subprocesses are stopped, and HTTP sessions ended
+	for _, client := range mcpClients {
+		if err := client.Close(); err != nil {
+			slog.Error("Failed to close MCP client", "server", client.Name(), "error", err)
+		}
+	}
+}
+
+// mustOpenStore opens the store selected by STORAGE_BACKEND: "mongo" (default), "bolt" for an
+// embedded database file at STORAGE_PATH, or "memory" for a store that is lost on restart. Uploaded documents
+// are kept in MongoDB with the mongo backend, and in memory otherwise.
+func mustOpenStore() (model.Store, documents.Index) {
+	switch backend := os.Getenv("STORAGE_BACKEND"); backend {
+	case "", "mongo":
+		db := mongox.MustConnect()
+
+		index := documents.NewMongoIndex(db)
+		if err := index.CreateIndexes(context.Background()); err != nil {
+			panic(fmt.Errorf("failed to create document indexes: %w", err))
+		}
+
+		return model.New(db), index
+	case "bolt":
+		path := os.Getenv("STORAGE_PATH")
+		if path == "" {
+			path = "acai.db"
+		}
+
+		store, err := model.OpenBoltStore(path)
+		if err != nil {
+			panic(err)
+		}
+
+		slog.Info("Using embedded conversation store", "path", path)
+		slog.Warn("Uploaded documents are kept in memory, they are lost on restart")
+		return store, documents.NewMemoryIndex()
+	case "memory":
+		slog.Warn("Using in-memory conversation store, conversations are lost on restart")
+		return model.NewInMemoryStore(), documents.NewMemoryIndex()
+	default:
+		panic(fmt.Sprintf("unknown STORAGE_BACKEND %q", backend))
+	}
+}
+
+// mustEmbedder creates the embedder of uploaded documents selected by DOCUMENT_EMBEDDER: "openai" (default),
+// using the DOCUMENT_EMBEDDING_MODEL model if set, or "hashing" for a local embedder needing no API, which only
+// matches documents sharing words with the question.
+func mustEmbedder() documents.Embedder {
+	switch embedder := os.Getenv("DOCUMENT_EMBEDDER"); embedder {
+	case "", "openai":
+		return documents.NewOpenAIEmbedder(os.Getenv("DOCUMENT_EMBEDDING_MODEL"))
+	case "hashing":
+		slog.Info("Using local hashing embedder for documents")
+		return &documents.HashingEmbedder{}
+	default:
+		panic(fmt.Sprintf("unknown DOCUMENT_EMBEDDER %q", embedder))
+	}
+}
+
+// mustNewProvider creates the model provider selected by LLM_PROVIDER: "openai" (default), configured with
+// OPENAI_API_KEY, or "openai-compatible" for any server implementing the OpenAI chat completions API at
+// LLM_BASE_URL, authenticated with the optional LLM_API_KEY.
+func mustNewProvider() llm.Provider {
+	switch provider := os.Getenv("LLM_PROVIDER"); provider {
+	case "", "openai":
+		return llm.NewOpenAI()
+	case "openai-compatible":
+		baseURL := os.Getenv("LLM_BASE_URL")
+		if baseURL == "" {
+			panic("LLM_BASE_URL is required for the openai-compatible provider")
+		}
+
+		slog.Info("Using OpenAI-compatible model provider", "base_url", baseURL)
+		return llm.NewOpenAICompatible(baseURL, os.Getenv("LLM_API_KEY"))
+	default:
+		panic(fmt.Sprintf("unknown LLM_PROVIDER %q", provider))
+	}
+}
+
+// assistantOptions configures the assistant models from LLM_TITLE_MODEL, LLM_REPLY_MODEL and
+// LLM_SUMMARY_MODEL, and the prompt token budget of replies from LLM_CONTEXT_BUDGET, keeping the defaults for
+// the ones not set. Its tools are the built-in ones, the search of documents in library, the memories about users
+// kept in memories, and those of the MCP servers listed in MCP_CONFIG, whose clients are returned to be closed on
+// shutdown.
+func assistantOptions(memories model.MemoryStore, library *documents.Library) ([]assistant.Option, []*mcp.Client) {
+	title, reply := assistant.DefaultTitleModel, assistant.DefaultReplyModel
+	if v := os.Getenv("LLM_TITLE_MODEL"); v != "" {
+		title = v
+	}
+	if v := os.Getenv("LLM_REPLY_MODEL"); v != "" {
+		reply = v
+	}
+
+	opts := []assistant.Option{assistant.WithModels(title, reply), assistant.WithMemories(memories)}
+
+	if v := os.Getenv("LLM_SUMMARY_MODEL"); v != "" {
+		opts = append(opts, assistant.WithSummaryModel(v))
+	}
+
+	if v := os.Getenv("LLM_CONTEXT_BUDGET"); v != "" {
+		budget, err := strconv.Atoi(v)
+		if err != nil || budget <= 0 {
+			panic(fmt.Sprintf("invalid LLM_CONTEXT_BUDGET %q, expected a positive number of tokens", v))
+		}
+		opts = append(opts, assistant.WithContextBudget(budget))
+	}
+
+	if v := os.Getenv("TOOL_PARALLELISM"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			panic(fmt.Sprintf("invalid TOOL_PARALLELISM %q, expected a positive number of tool calls", v))
+		}
+		opts = append(opts, assistant.WithToolParallelism(n))
+	}
+
+	registry := tools.NewRegistry()
+	registry.Register(&tools.DocumentSearchTool{Library: library, UserID: auth.UserID})
+	toolMemories := assistant.Memories(memories)
+	registry.Register(&tools.RememberTool{Store: toolMemories, UserID: auth.UserID})
+	registry.Register(&tools.ForgetTool{Store: toolMemories, UserID: auth.UserID})
+	registry.Register(&tools.RecallTool{Store: toolMemories, UserID: auth.UserID})
+
+	var clients []*mcp.Client
+	if path := os.Getenv("MCP_CONFIG"); path != "" {
+		cfg, err := mcp.LoadConfig(path)
+		if err != nil {
+			panic(err)
+		}
+
+		clients = mcp.Register(context.Background(), registry, cfg)
+	}
+
+	if cache := mustToolCache(); cache != nil {
+		registry = registry.WithCache(cache)
+	}
+
+	opts = append(opts, assistant.WithTools(registry))
+
+	return opts, clients
+}
+
+// mustToolCache opens the tool result cache selected by TOOL_CACHE: "memory" (default) for an LRU cache of
+// TOOL_CACHE_SIZE entries, "bolt" for a cache file at TOOL_CACHE_PATH that survives restarts, or "off".
+func mustToolCache() tools.Cache {
+	switch backend := os.Getenv("TOOL_CACHE"); backend {
+	case "", "memory":
+		size := 1000
+		if v := os.Getenv("TOOL_CACHE_SIZE"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 {
+				panic(fmt.Sprintf("invalid TOOL_CACHE_SIZE %q, expected a positive number of entries", v))
+			}
+			size = n
+		}
+		return tools.NewLRUCache(size)
+	case "bolt":
+		path := os.Getenv("TOOL_CACHE_PATH")
+		if path == "" {
+			path = "tools-cache.db"
+		}
+
+		cache, err := tools.OpenBoltCache(path)
+		if err != nil {
+			panic(err)
+		}
+
+		slog.Info("Using persistent tool cache", "path", path)
+		return cache
+	case "off":
+		return nil
+	default:
+		panic(fmt.Sprintf("unknown TOOL_CACHE %q", backend))
+	}
+}
+
+// mustAuthenticator returns the authentication middleware for the key set in AUTH_KEYS_FILE. Without it,
+// authentication is disabled and every caller shares the anonymous owner's conversations.
+func mustAuthenticator() func(http.Handler) http.Handler {
+	path := os.Getenv("AUTH_KEYS_FILE")
+	if path == "" {
+		slog.Warn("AUTH_KEYS_FILE not set, authentication is disabled")
+		return func(handler http.Handler) http.Handler { return handler }
+	}
+
+	keys, err := auth.LoadKeySet(path)
+	if err != nil {
 		panic(err)
 	}
+
+	slog.Info("Authentication enabled", "api_keys", len(keys.APIKeys), "jwt_keys", len(keys.JWTKeys))
+	return httpx.Authenticate(keys)
+}
+
+// mustDuration parses the duration in the environment variable, e.g. "72h", or returns def when it is not set.
+func mustDuration(name string, def time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s %q: %v", name, v, err))
+	}
+
+	return d
 }
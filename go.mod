@@ -10,6 +10,7 @@ require (
 	github.com/openai/openai-go/v2 v2.1.0
 	github.com/prometheus/client_golang v1.17.0
 	github.com/twitchtv/twirp v8.1.3+incompatible
+	go.etcd.io/bbolt v1.3.11
 	go.mongodb.org/mongo-driver v1.17.4
 	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp v0.46.0
 	go.opentelemetry.io/otel v1.21.0
@@ -0,0 +1,120 @@
+// Package auth authenticates API callers and carries their identity through the request context.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// minSecretLength is the minimum size of JWT secrets, in bytes: HS256 keys must be at least as long as the hash
+// (RFC 7518, section 3.2).
+const minSecretLength = 32
+
+// ErrInvalidCredentials is returned when a token is neither a known API key nor a valid JWT.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+type contextKey struct{}
+
+// WithUser returns a context carrying the authenticated user's ID.
+func WithUser(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, contextKey{}, userID)
+}
+
+// UserID returns the authenticated user's ID, or an empty string for anonymous callers when authentication is
+// disabled.
+func UserID(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// APIKey grants access to a user. Only the SHA-256 hash of the key is stored, so the key set file does not
+// leak usable credentials.
+type APIKey struct {
+	UserID string `json:"user_id"`
+	SHA256 string `json:"sha256"` // Hex-encoded SHA-256 of the key.
+}
+
+// JWTKey is a shared secret used to verify HS256 signed JWTs. The token's "sub" claim is the user ID.
+type JWTKey struct {
+	ID     string `json:"kid"`    // Matched against the token's "kid" header, if both are set.
+	Secret string `json:"secret"` // Base64-encoded secret.
+}
+
+// KeySet holds the credentials accepted by the API.
+type KeySet struct {
+	APIKeys []APIKey `json:"api_keys"`
+	JWTKeys []JWTKey `json:"jwt_keys"`
+
+	// now is overridden in tests.
+	now func() time.Time
+}
+
+// LoadKeySet reads a key set from a JSON file. API keys must name their user, since callers without one are
+// anonymous, and JWT secrets must be at least 32 bytes, so tokens cannot be forged with a guessable key.
+func LoadKeySet(path string) (*KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key set: %w", err)
+	}
+
+	var ks KeySet
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return nil, fmt.Errorf("failed to parse key set %s: %w", path, err)
+	}
+
+	for i, k := range ks.APIKeys {
+		if strings.TrimSpace(k.UserID) == "" {
+			return nil, fmt.Errorf("API key #%d in %s has no user_id", i+1, path)
+		}
+	}
+
+	for _, k := range ks.JWTKeys {
+		secret, err := base64.StdEncoding.DecodeString(k.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("JWT key %q has an invalid base64 secret: %w", k.ID, err)
+		}
+		if len(secret) < minSecretLength {
+			return nil, fmt.Errorf("JWT key %q has a secret of %d bytes, at least %d are required", k.ID, len(secret), minSecretLength)
+		}
+	}
+
+	return &ks, nil
+}
+
+// HashAPIKey returns the hex-encoded SHA-256 of key, the form API keys are stored in.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify checks a bearer token, either an API key or an HS256 JWT, and returns the user it belongs to.
+func (ks *KeySet) Verify(token string) (string, error) {
+	if strings.Count(token, ".") == 2 {
+		return ks.verifyJWT(token)
+	}
+
+	hash := HashAPIKey(token)
+	for _, k := range ks.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(hash), []byte(strings.ToLower(k.SHA256))) == 1 {
+			return k.UserID, nil
+		}
+	}
+
+	return "", ErrInvalidCredentials
+}
+
+func (ks *KeySet) clock() time.Time {
+	if ks.now != nil {
+		return ks.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("super-secret-signing-key-of-32-bytes")
+
+// signJWT builds an HS256 token for the given header and claims.
+func signJWT(t *testing.T, header, claims map[string]any, secret []byte) string {
+	t.Helper()
+
+	enc := func(v any) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("failed to encode JWT segment: %v", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	input := enc(header) + "." + enc(claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(input))
+
+	return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestKeySet_Verify(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	ks := &KeySet{
+		APIKeys: []APIKey{{UserID: "alice", SHA256: HashAPIKey("alice-key")}},
+		JWTKeys: []JWTKey{{ID: "main", Secret: base64.StdEncoding.EncodeToString(testSecret)}},
+		now:     func() time.Time { return now },
+	}
+
+	hs256 := map[string]any{"alg": "HS256", "typ": "JWT", "kid": "main"}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    string
+		wantErr bool
+	}{
+		{name: "known API key", token: "alice-key", want: "alice"},
+		{name: "unknown API key", token: "mallory-key", wantErr: true},
+		{
+			name:  "valid JWT",
+			token: signJWT(t, hs256, map[string]any{"sub": "bob", "exp": now.Add(time.Hour).Unix()}, testSecret),
+			want:  "bob",
+		},
+		{
+			name:  "valid JWT without key ID",
+			token: signJWT(t, map[string]any{"alg": "HS256"}, map[string]any{"sub": "bob"}, testSecret),
+			want:  "bob",
+		},
+		{
+			name:    "JWT signed with another secret",
+			token:   signJWT(t, hs256, map[string]any{"sub": "bob"}, []byte("other-secret")),
+			wantErr: true,
+		},
+		{
+			name:    "JWT with unknown key ID",
+			token:   signJWT(t, map[string]any{"alg": "HS256", "kid": "old"}, map[string]any{"sub": "bob"}, testSecret),
+			wantErr: true,
+		},
+		{
+			name:    "expired JWT",
+			token:   signJWT(t, hs256, map[string]any{"sub": "bob", "exp": now.Add(-time.Minute).Unix()}, testSecret),
+			wantErr: true,
+		},
+		{
+			name:    "JWT not valid yet",
+			token:   signJWT(t, hs256, map[string]any{"sub": "bob", "nbf": now.Add(time.Minute).Unix()}, testSecret),
+			wantErr: true,
+		},
+		{
+			name:    "JWT without subject",
+			token:   signJWT(t, hs256, map[string]any{}, testSecret),
+			wantErr: true,
+		},
+		{
+			name:    "unsigned JWT",
+			token:   signJWT(t, map[string]any{"alg": "none"}, map[string]any{"sub": "bob"}, testSecret),
+			wantErr: true,
+		},
+		{name: "malformed JWT", token: "a.b.c", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ks.Verify(tt.token)
+
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCredentials) {
+					t.Errorf("expected ErrInvalidCredentials, got user %q and error %v", got, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected user %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLoadKeySet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	data := `{
+		"api_keys": [{"user_id": "alice", "sha256": "` + HashAPIKey("alice-key") + `"}],
+		"jwt_keys": [{"kid": "main", "secret": "` + base64.StdEncoding.EncodeToString(testSecret) + `"}]
+	}`
+
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("failed to write key set: %v", err)
+	}
+
+	ks, err := LoadKeySet(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if user, err := ks.Verify("alice-key"); err != nil || user != "alice" {
+		t.Errorf("expected alice, got %q (%v)", user, err)
+	}
+
+	invalid := map[string]string{
+		"invalid secret":  `{"jwt_keys": [{"kid": "bad", "secret": "%%%"}]}`,
+		"empty secret":    `{"jwt_keys": [{"kid": "bad", "secret": ""}]}`,
+		"missing secret":  `{"jwt_keys": [{"kid": "bad"}]}`,
+		"short secret":    `{"jwt_keys": [{"kid": "bad", "secret": "` + base64.StdEncoding.EncodeToString([]byte("short-secret")) + `"}]}`,
+		"empty user ID":   `{"api_keys": [{"user_id": "", "sha256": "` + HashAPIKey("key") + `"}]}`,
+		"missing user ID": `{"api_keys": [{"sha256": "` + HashAPIKey("key") + `"}]}`,
+	}
+
+	for name, data := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+				t.Fatalf("failed to write key set: %v", err)
+			}
+
+			if _, err := LoadKeySet(path); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Subject   string `json:"sub"`
+	ExpiresAt int64  `json:"exp"`
+	NotBefore int64  `json:"nbf"`
+}
+
+// verifyJWT checks the signature and validity window of an HS256 JWT and returns its subject.
+func (ks *KeySet) verifyJWT(token string) (string, error) {
+	parts := strings.Split(token, ".")
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return "", fmt.Errorf("%w: malformed JWT header", ErrInvalidCredentials)
+	}
+
+	if header.Algorithm != "HS256" {
+		return "", fmt.Errorf("%w: unsupported JWT algorithm %q", ErrInvalidCredentials, header.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("%w: malformed JWT signature", ErrInvalidCredentials)
+	}
+
+	if !ks.validSignature(header.KeyID, parts[0]+"."+parts[1], signature) {
+		return "", fmt.Errorf("%w: JWT signature mismatch", ErrInvalidCredentials)
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return "", fmt.Errorf("%w: malformed JWT claims", ErrInvalidCredentials)
+	}
+
+	now := ks.clock()
+	if claims.ExpiresAt != 0 && !now.Before(time.Unix(claims.ExpiresAt, 0)) {
+		return "", fmt.Errorf("%w: JWT expired", ErrInvalidCredentials)
+	}
+
+	if claims.NotBefore != 0 && now.Before(time.Unix(claims.NotBefore, 0)) {
+		return "", fmt.Errorf("%w: JWT not valid yet", ErrInvalidCredentials)
+	}
+
+	if claims.Subject == "" {
+		return "", fmt.Errorf("%w: JWT has no subject", ErrInvalidCredentials)
+	}
+
+	return claims.Subject, nil
+}
+
+// validSignature reports whether any key matching kid signed the input. Tokens without a key ID are checked
+// against every key.
+func (ks *KeySet) validSignature(kid, input string, signature []byte) bool {
+	for _, k := range ks.JWTKeys {
+		if kid != "" && k.ID != "" && kid != k.ID {
+			continue
+		}
+
+		secret, err := base64.StdEncoding.DecodeString(k.Secret)
+		if err != nil {
+			continue
+		}
+
+		if hmac.Equal(signature, sign(secret, input)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sign(secret []byte, input string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(input))
+	return mac.Sum(nil)
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -3,52 +3,134 @@ package assistant
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/llm"
 	"github.com/acai-travel/tech-challenge/internal/tools"
-	"github.com/openai/openai-go/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // maxToolCallIterations defines the maximum number of tool call iterations to prevent infinite loops.
 const maxToolCallIterations = 15
 
+const (
+	// DefaultTitleModel is the model used to generate conversation titles unless configured otherwise.
+	DefaultTitleModel = "o1"
+	// DefaultReplyModel is the model used to generate replies unless configured otherwise.
+	DefaultReplyModel = "gpt-4.1"
+	// DefaultSummaryModel is the model used to summarize long conversations unless configured otherwise.
+	DefaultSummaryModel = "gpt-4.1-mini"
+	// DefaultContextBudget is the number of prompt tokens a reply may use unless configured otherwise.
+	DefaultContextBudget = 16_000
+	// DefaultToolParallelism is the number of tool calls of a turn run at once unless configured otherwise.
+	DefaultToolParallelism = 4
+)
+
 // Assistant provides AI-powered conversation capabilities with tool support.
 type Assistant struct {
-	cli   openai.Client
-	tools *tools.Registry
+	llm           llm.Provider
+	tools         *tools.Registry
+	titleModel    string
+	replyModel    string
+	summaryModel  string
+	contextBudget int
+	toolCalls     int // Number of tool calls of a turn run at once.
+	memories      model.MemoryStore
+}
+
+// Option configures an Assistant.
+type Option func(*Assistant)
+
+// WithModels overrides the models used for titles and replies.
+func WithModels(title, reply string) Option {
+	return func(a *Assistant) {
+		a.titleModel = title
+		a.replyModel = reply
+	}
+}
+
+// WithSummaryModel overrides the model used to summarize the older turns of long conversations.
+func WithSummaryModel(model string) Option {
+	return func(a *Assistant) {
+		a.summaryModel = model
+	}
 }
 
-// New creates a new Assistant with OpenAI client and tool registry.
-func New() *Assistant {
-	return &Assistant{
-		cli:   openai.NewClient(),
-		tools: tools.NewRegistry(),
+// WithContextBudget limits the number of prompt tokens sent to the model for a reply. Older turns of
+// conversations exceeding it are summarized. The budget is also capped by the reply model's context window.
+func WithContextBudget(tokens int) Option {
+	return func(a *Assistant) {
+		a.contextBudget = tokens
 	}
 }
 
+// WithToolParallelism limits the number of tool calls of a turn run at once.
+func WithToolParallelism(n int) Option {
+	return func(a *Assistant) {
+		a.toolCalls = n
+	}
+}
+
+// WithTools overrides the tools available to the assistant.
+func WithTools(registry *tools.Registry) Option {
+	return func(a *Assistant) {
+		a.tools = registry
+	}
+}
+
+// WithMemories gives replies the facts remembered about the user in the store, the ones most relevant to the
+// last message are added to the system prompt.
+func WithMemories(store model.MemoryStore) Option {
+	return func(a *Assistant) {
+		a.memories = store
+	}
+}
+
+// New creates a new Assistant talking to the given model provider, with all built-in tools registered.
+func New(provider llm.Provider, opts ...Option) *Assistant {
+	a := &Assistant{
+		llm:           provider,
+		tools:         tools.NewRegistry(),
+		titleModel:    DefaultTitleModel,
+		replyModel:    DefaultReplyModel,
+		summaryModel:  DefaultSummaryModel,
+		contextBudget: DefaultContextBudget,
+		toolCalls:     DefaultToolParallelism,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
 func (a *Assistant) Title(ctx context.Context, conv *model.Conversation) (string, error) {
-	if len(conv.Messages) == 0 {
+	path := conv.ActivePath()
+	if len(path) == 0 {
 		return "An empty conversation", nil
 	}
 
 	slog.InfoContext(ctx, "Generating title for conversation", "conversation_id", conv.ID)
 
-	msgs := []openai.ChatCompletionMessageParamUnion{
-		openai.SystemMessage("Generate a concise, descriptive title (2-6 words) that summarizes the main topic of the user's question. Do not answer the question, just create a brief topic summary. Examples: 'Weather in Barcelona', 'Today's Date', 'Barcelona Holidays'."),
+	msgs := []llm.Message{
+		llm.SystemMessage("Generate a concise, descriptive title (2-6 words) that summarizes the main topic of the user's question. Do not answer the question, just create a brief topic summary. Examples: 'Weather in Barcelona', 'Today's Date', 'Barcelona Holidays'."),
 	}
 
 	// Add only the first user message for title generation.
-	for _, m := range conv.Messages {
+	for _, m := range path {
 		if m.Role == model.RoleUser {
-			msgs = append(msgs, openai.UserMessage(m.Content))
+			msgs = append(msgs, llm.UserMessage(m.Content))
 			break
 		}
 	}
 
-	resp, err := a.cli.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
-		Model:    openai.ChatModelO1,
+	resp, err := a.llm.Complete(ctx, llm.Request{
+		Model:    a.titleModel,
 		Messages: msgs,
 	})
 
@@ -56,11 +138,11 @@ func (a *Assistant) Title(ctx context.Context, conv *model.Conversation) (string
 		return "", err
 	}
 
-	if len(resp.Choices) == 0 || strings.TrimSpace(resp.Choices[0].Message.Content) == "" {
-		return "", errors.New("empty response from OpenAI for title generation")
+	if strings.TrimSpace(resp.Message.Content) == "" {
+		return "", errors.New("empty response from model for title generation")
 	}
 
-	title := resp.Choices[0].Message.Content
+	title := resp.Message.Content
 	title = strings.ReplaceAll(title, "\n", " ")
 	title = strings.Trim(title, " \t\r\n-\"'")
 
@@ -71,60 +153,115 @@ func (a *Assistant) Title(ctx context.Context, conv *model.Conversation) (string
 	return title, nil
 }
 
-func (a *Assistant) Reply(ctx context.Context, conv *model.Conversation) (string, error) {
-	if len(conv.Messages) == 0 {
-		return "", errors.New("conversation has no messages")
+// Reply generates the assistant's answer to the last message of the conversation's active path, executing tools
+// as requested. It returns the messages to append to the conversation: a tool message for every tool called,
+// followed by the answer.
+func (a *Assistant) Reply(ctx context.Context, conv *model.Conversation) ([]*model.Message, error) {
+	return a.reply(ctx, conv, nil)
+}
+
+// ReplyStream behaves like Reply, but reports reply tokens and tool calls to onEvent as they happen.
+func (a *Assistant) ReplyStream(ctx context.Context, conv *model.Conversation, onEvent func(model.Event)) ([]*model.Message, error) {
+	return a.reply(ctx, conv, onEvent)
+}
+
+func (a *Assistant) reply(ctx context.Context, conv *model.Conversation, onEvent func(model.Event)) ([]*model.Message, error) {
+	if len(conv.ActivePath()) == 0 {
+		return nil, errors.New("conversation has no messages")
 	}
 
 	slog.InfoContext(ctx, "Generating reply for conversation", "conversation_id", conv.ID)
 
-	msgs := []openai.ChatCompletionMessageParamUnion{
-		openai.SystemMessage("You are a helpful, concise AI assistant. Provide accurate, safe, and clear responses."),
-	}
+	settings := a.settings(conv)
+	settings.systemPrompt += a.memoryPrompt(ctx, conv)
+	conv.Tools = settings.tools.Names()
+	msgs := a.prompt(ctx, conv, settings)
 
-	for _, m := range conv.Messages {
-		switch m.Role {
-		case model.RoleUser:
-			msgs = append(msgs, openai.UserMessage(m.Content))
-		case model.RoleAssistant:
-			msgs = append(msgs, openai.AssistantMessage(m.Content))
-		}
-	}
+	// Tools learn the conversation they are called for, and record the passages of documents they quote.
+	ctx, citations := tools.WithCitations(tools.WithConversation(ctx, conv.ID.Hex()))
 
+	var added []*model.Message
 	for range maxToolCallIterations {
-		resp, err := a.cli.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
-			Model:    openai.ChatModelGPT4_1,
-			Messages: msgs,
-			Tools:    a.tools.GetTools(),
-		})
-
+		req := settings.request(msgs)
+		resp, err := a.complete(ctx, req, onEvent)
 		if err != nil {
-			return "", err
-		}
-
-		if len(resp.Choices) == 0 {
-			return "", errors.New("no choices returned by OpenAI")
+			return nil, err
 		}
 
-		if message := resp.Choices[0].Message; len(message.ToolCalls) > 0 {
-			msgs = append(msgs, message.ToParam())
+		conv.Usage.ContextTokens = recordUsage(conv, req, resp)
 
-			for _, call := range message.ToolCalls {
-				slog.InfoContext(ctx, "Tool call received", "name", call.Function.Name, "args", call.Function.Arguments)
+		if message := resp.Message; len(message.ToolCalls) > 0 {
+			msgs = append(msgs, message)
 
-				// Execute tool using registry.
-				result, err := a.tools.Execute(ctx, call.Function.Name, call.Function.Arguments)
-				if err != nil {
-					result = "Error executing tool: " + err.Error()
-				}
-				msgs = append(msgs, openai.ToolMessage(result, call.ID))
+			for _, m := range a.executeTools(ctx, settings.tools, message.ToolCalls, onEvent) {
+				msgs = append(msgs, llm.ToolMessage(m.Content, m.ToolCall.ID))
+				added = append(added, m)
 			}
 
 			continue
 		}
 
-		return resp.Choices[0].Message.Content, nil
+		answer := newMessage(model.RoleAssistant, resp.Message.Content, nil)
+		answer.Citations = cited(answer.Content, citations.List())
+
+		return append(added, answer), nil
 	}
 
-	return "", errors.New("too many tool calls, unable to generate reply")
+	return nil, errors.New("too many tool calls, unable to generate reply")
+}
+
+// cited returns the citations the answer refers to as [n], or all of them when it refers to none: the model
+// still drew on the passages, it just did not say where.
+func cited(answer string, citations []tools.Citation) []model.Citation {
+	var all, referred []model.Citation
+	for _, c := range citations {
+		citation := model.Citation{
+			Number:       c.Number,
+			DocumentID:   c.DocumentID,
+			DocumentName: c.DocumentName,
+			Chunk:        c.Chunk,
+			Excerpt:      c.Excerpt,
+		}
+
+		all = append(all, citation)
+		if strings.Contains(answer, fmt.Sprintf("[%d]", c.Number)) {
+			referred = append(referred, citation)
+		}
+	}
+
+	if len(referred) == 0 {
+		return all
+	}
+	return referred
+}
+
+// newMessage creates a message sent now.
+func newMessage(role model.Role, content string, call *model.ToolCall) *model.Message {
+	return &model.Message{
+		ID:        primitive.NewObjectID(),
+		Role:      role,
+		Content:   content,
+		ToolCall:  call,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// complete requests a completion from the model. When onEvent is set the completion is streamed, and each
+// content chunk is reported as it arrives.
+func (a *Assistant) complete(ctx context.Context, req llm.Request, onEvent func(model.Event)) (*llm.Completion, error) {
+	if onEvent == nil {
+		return a.llm.Complete(ctx, req)
+	}
+
+	return a.llm.Stream(ctx, req, func(delta string) {
+		onEvent(model.Event{Type: model.EventDelta, Delta: delta})
+	})
+}
+
+// emit reports the event to onEvent, if set.
+func emit(onEvent func(model.Event), event model.Event) {
+	if onEvent != nil {
+		onEvent(event)
+	}
 }
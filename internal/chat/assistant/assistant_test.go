@@ -1,19 +1,48 @@
 package assistant
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/acai-travel/tech-challenge/internal/auth"
 	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/llm"
+	"github.com/acai-travel/tech-challenge/internal/llm/llmtest"
+	"github.com/acai-travel/tech-challenge/internal/tools"
+	"github.com/google/go-cmp/cmp"
+	"github.com/openai/openai-go/v2/option"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+var update = flag.Bool("update", false, "update golden files")
+
+func newConversation(messages ...string) *model.Conversation {
+	conv := &model.Conversation{ID: primitive.NewObjectID()}
+	for i, content := range messages {
+		role := model.RoleUser
+		if i%2 == 1 {
+			role = model.RoleAssistant
+		}
+		conv.Messages = append(conv.Messages, &model.Message{ID: primitive.NewObjectID(), Role: role, Content: content})
+	}
+	return conv
+}
+
 func TestAssistant_Title(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("returns default title for empty conversation", func(t *testing.T) {
-		assistant := New()
+		assistant := New(llmtest.NewScripted())
 		conv := &model.Conversation{
 			ID:       primitive.NewObjectID(),
 			Messages: []*model.Message{}, // Empty messages
@@ -30,31 +59,651 @@ func TestAssistant_Title(t *testing.T) {
 		}
 	})
 
-	t.Run("generates title from user message", func(t *testing.T) {
-		// Skip this test if no OpenAI API key is set
-		if !hasOpenAIKey() {
-			t.Skip("Skipping OpenAI integration test - no API key set")
+	t.Run("generates title from first user message", func(t *testing.T) {
+		provider := llmtest.NewScripted(llmtest.Reply("\"Weather in Barcelona\"\n"))
+		assistant := New(provider, WithModels("title-model", "reply-model"))
+
+		title, err := assistant.Title(ctx, newConversation("What is the weather like in Barcelona?", "Sunny.", "And tomorrow?"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if title != "Weather in Barcelona" {
+			t.Errorf("expected title %q, got %q", "Weather in Barcelona", title)
+		}
+
+		req := provider.Requests()[0]
+		if req.Model != "title-model" {
+			t.Errorf("expected title model, got %q", req.Model)
+		}
+
+		if len(req.Messages) != 2 || req.Messages[0].Role != llm.RoleSystem || req.Messages[1].Content != "What is the weather like in Barcelona?" {
+			t.Errorf("expected system prompt and first user message only, got %+v", req.Messages)
+		}
+
+		if len(req.Tools) != 0 {
+			t.Errorf("expected no tools for title generation, got %d", len(req.Tools))
 		}
+	})
+
+	t.Run("returns provider errors", func(t *testing.T) {
+		assistant := New(llmtest.NewScripted(llmtest.Fail(context.DeadlineExceeded)))
 
-		// This test would require actual OpenAI API calls
-		// For now, just verify the assistant can be created
-		assistant := New()
-		if assistant == nil {
-			t.Fatal("failed to create assistant")
+		if _, err := assistant.Title(ctx, newConversation("Hello")); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected deadline exceeded error, got %v", err)
 		}
-		t.Skip("OpenAI integration test - requires API key and network")
 	})
 
-	t.Run("handles timeout gracefully", func(t *testing.T) {
-		t.Skip("Timeout test requires OpenAI integration - skipping for now")
+	t.Run("rejects empty title", func(t *testing.T) {
+		assistant := New(llmtest.NewScripted(llmtest.Reply("  \n")))
+
+		if _, err := assistant.Title(ctx, newConversation("Hello")); err == nil {
+			t.Error("expected error for empty title, got nil")
+		}
 	})
 
 	t.Run("title length constraints", func(t *testing.T) {
-		t.Skip("Length constraint test requires OpenAI integration - skipping for now")
+		assistant := New(llmtest.NewScripted(llmtest.Reply(strings.Repeat("a", 120))))
+
+		title, err := assistant.Title(ctx, newConversation("Hello"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(title) != 80 {
+			t.Errorf("expected title truncated to 80 characters, got %d", len(title))
+		}
 	})
 }
 
-// hasOpenAIKey checks if OpenAI API key is available for integration tests
-func hasOpenAIKey() bool {
-	return os.Getenv("OPENAI_API_KEY") != ""
-}
\ No newline at end of file
+// replyTranscript is the golden record of a Reply run: the requests the model received, each listing only
+// the messages added since the previous request, and the outcome.
+type replyTranscript struct {
+	Requests []transcriptRequest `json:"requests"`
+	Events   []string            `json:"events,omitempty"`
+	Tools    []string            `json:"tools,omitempty"` // Tool messages returned with the reply.
+	Reply    string              `json:"reply,omitempty"`
+	Error    string              `json:"error,omitempty"`
+}
+
+type transcriptRequest struct {
+	Model       string                    `json:"model"`
+	Stream      bool                      `json:"stream,omitempty"`
+	Tools       []string                  `json:"tools,omitempty"`
+	NewMessages []llmtest.RecordedMessage `json:"new_messages"`
+}
+
+func TestAssistant_Reply(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []string
+		stream   bool
+	}{
+		{name: "multi_step_tool_use", messages: []string{"What is (2 + 3) * 4?"}},
+		{name: "multi_step_tool_use_stream", messages: []string{"What is (2 + 3) * 4?"}, stream: true},
+		{name: "parallel_tool_calls", messages: []string{"Hi!", "Hello! How can I help?", "What are 1 + 1 and 10 - 4?"}},
+		{name: "tool_errors", messages: []string{"What is the ACAI stock price, and 2 to the power of 8?"}},
+		{name: "iteration_exhaustion", messages: []string{"Keep adding one and one."}},
+	}
+
+	// A fixed set of tools, so the transcripts do not change whenever a built-in tool is added.
+	registry := &tools.Registry{}
+	registry.Register(&tools.CalculatorTool{})
+	registry.Register(&tools.DateTool{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := strings.TrimSuffix(tt.name, "_stream")
+			srv := llmtest.NewServer(t, llmtest.LoadScript(t, filepath.Join("testdata", "reply", script+".script.json")))
+			assistant := New(llm.NewOpenAICompatible(srv.URL, "test-key", option.WithMaxRetries(0)), WithTools(registry))
+
+			var got replyTranscript
+			var messages []*model.Message
+			var err error
+
+			if tt.stream {
+				var delta strings.Builder
+				messages, err = assistant.ReplyStream(context.Background(), newConversation(tt.messages...), func(e model.Event) {
+					if e.Type == model.EventDelta {
+						delta.WriteString(e.Delta)
+						return
+					}
+					if delta.Len() > 0 {
+						got.Events = append(got.Events, "delta: "+delta.String())
+						delta.Reset()
+					}
+					event := string(e.Type) + ": " + e.ToolCall.Name + " " + e.ToolCall.Arguments
+					if e.Type == model.EventToolCallFinished {
+						event += " => " + e.ToolCall.Result
+					}
+					got.Events = append(got.Events, event)
+				})
+				if delta.Len() > 0 {
+					got.Events = append(got.Events, "delta: "+delta.String())
+				}
+			} else {
+				messages, err = assistant.Reply(context.Background(), newConversation(tt.messages...))
+			}
+
+			for i, m := range messages {
+				if i == len(messages)-1 {
+					got.Reply = m.Content
+					break
+				}
+				if m.Role != model.RoleTool || m.ToolCall == nil {
+					t.Fatalf("expected tool messages before the reply, got %+v", m)
+				}
+				result := m.Content
+				if m.ToolCall.Error != "" {
+					result = "error: " + m.ToolCall.Error
+				}
+				got.Tools = append(got.Tools, fmt.Sprintf("%s %s => %s", m.ToolCall.Name, m.ToolCall.Arguments, result))
+			}
+			if err != nil {
+				got.Error = err.Error()
+			}
+
+			var previous []llmtest.RecordedMessage
+			for i, req := range srv.Requests() {
+				if len(req.Messages) < len(previous) || len(previous) > 0 && !cmp.Equal(previous, req.Messages[:len(previous)]) {
+					t.Fatalf("request #%d does not extend the messages of the previous request", i+1)
+				}
+
+				got.Requests = append(got.Requests, transcriptRequest{
+					Model:       req.Model,
+					Stream:      req.Stream,
+					Tools:       req.Tools,
+					NewMessages: req.Messages[len(previous):],
+				})
+				previous = req.Messages
+			}
+
+			if n := srv.Remaining(); n != 0 {
+				t.Errorf("expected the whole script to be played, %d steps left", n)
+			}
+
+			golden := filepath.Join("testdata", "reply", tt.name+".golden.json")
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(false)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(got); err != nil {
+				t.Fatalf("failed to encode transcript: %v", err)
+			}
+			data := strings.TrimSpace(buf.String())
+
+			if *update {
+				if err := os.WriteFile(golden, []byte(data+"\n"), 0o644); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+			}
+
+			if diff := cmp.Diff(strings.TrimSpace(string(want)), data); diff != "" {
+				t.Errorf("Reply() transcript mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAssistant_ContextWindow(t *testing.T) {
+	ctx := context.Background()
+
+	// Seven messages of about 200 tokens each, well over the budget of 1300 tokens.
+	long := func(prefix string) string {
+		return prefix + " " + strings.Repeat("word ", 160)
+	}
+	longConversation := func() *model.Conversation {
+		return newConversation(long("u1"), long("a1"), long("u2"), long("a2"), long("u3"), long("a3"), long("u4"))
+	}
+
+	newAssistant := func(provider llm.Provider) *Assistant {
+		return New(provider, WithModels("title-model", "gpt-4o"), WithSummaryModel("summary-model"), WithContextBudget(1300), WithTools(&tools.Registry{}))
+	}
+
+	contents := func(msgs []llm.Message) []string {
+		var out []string
+		for _, m := range msgs {
+			out = append(out, strings.Fields(m.Content)[0])
+		}
+		return out
+	}
+
+	t.Run("summarizes older turns and reuses the summary", func(t *testing.T) {
+		provider := llmtest.NewScripted(llmtest.Reply("Summary: first turns."), llmtest.Reply("a4"), llmtest.Reply("a5"))
+		assistant := newAssistant(provider)
+		conv := longConversation()
+
+		if _, err := assistant.Reply(ctx, conv); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		summaryReq := provider.Requests()[0]
+		if summaryReq.Model != "summary-model" || len(summaryReq.Tools) != 0 {
+			t.Errorf("expected a summary request without tools, got model %q and %d tools", summaryReq.Model, len(summaryReq.Tools))
+		}
+
+		transcript := summaryReq.Messages[1].Content
+		if !strings.Contains(transcript, "USER: u1") || !strings.Contains(transcript, "ASSISTANT: a2") || strings.Contains(transcript, "u3") {
+			t.Errorf("expected the first two turns to be summarized, got %q", transcript)
+		}
+
+		if conv.Summary == nil || conv.Summary.ThroughID != conv.Messages[3].ID || conv.Summary.Content != "Summary: first turns." {
+			t.Fatalf("expected the summary to be stored through the fourth message, got %+v", conv.Summary)
+		}
+
+		replyReq := provider.Requests()[1]
+		if diff := cmp.Diff([]string{"You", "Summary", "u3", "a3", "u4"}, contents(replyReq.Messages)); diff != "" {
+			t.Errorf("reply request messages mismatch (-want +got):\n%s", diff)
+		}
+
+		if got, want := conv.Usage.ContextTokens, llm.EstimateTokens(replyReq); got != want {
+			t.Errorf("expected context tokens %d, got %d", want, got)
+		}
+
+		if conv.Usage.PromptTokens <= conv.Usage.ContextTokens || conv.Usage.CompletionTokens == 0 {
+			t.Errorf("expected usage of both requests to be recorded, got %+v", conv.Usage)
+		}
+
+		// The next turn fits the budget with the stored summary, so it is not summarized again.
+		summary := conv.Summary
+		conv.Messages = append(conv.Messages,
+			&model.Message{ID: primitive.NewObjectID(), Role: model.RoleAssistant, Content: "a4"},
+			&model.Message{ID: primitive.NewObjectID(), Role: model.RoleUser, Content: "u5"},
+		)
+
+		if _, err := assistant.Reply(ctx, conv); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff([]string{"You", "Summary", "u3", "a3", "u4", "a4", "u5"}, contents(provider.Requests()[2].Messages)); diff != "" {
+			t.Errorf("second reply request messages mismatch (-want +got):\n%s", diff)
+		}
+
+		if conv.Summary != summary {
+			t.Errorf("expected the summary to be reused, got %+v", conv.Summary)
+		}
+	})
+
+	t.Run("ignores summaries of other branches", func(t *testing.T) {
+		provider := llmtest.NewScripted(llmtest.Reply("Sunny."))
+		assistant := newAssistant(provider)
+		conv := newConversation("u1", "a1", "u2")
+		conv.Summary = &model.Summary{Content: "Summary of another branch.", ThroughID: primitive.NewObjectID()}
+
+		if _, err := assistant.Reply(ctx, conv); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff([]string{"You", "u1", "a1", "u2"}, contents(provider.Requests()[0].Messages)); diff != "" {
+			t.Errorf("reply request messages mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("leaves older turns out when summarizing fails", func(t *testing.T) {
+		provider := llmtest.NewScripted(llmtest.Fail(errors.New("rate limited")), llmtest.Reply("a4"))
+		assistant := newAssistant(provider)
+		conv := longConversation()
+
+		if _, err := assistant.Reply(ctx, conv); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff([]string{"You", "u3", "a3", "u4"}, contents(provider.Requests()[1].Messages)); diff != "" {
+			t.Errorf("reply request messages mismatch (-want +got):\n%s", diff)
+		}
+
+		if conv.Summary != nil {
+			t.Errorf("expected no summary to be stored, got %+v", conv.Summary)
+		}
+	})
+}
+
+func TestAssistant_ToolMessages(t *testing.T) {
+	ctx := context.Background()
+	provider := llmtest.NewScripted(
+		llmtest.CallTools(llm.ToolCall{ID: "call_1", Name: "calculate", Arguments: `{"expression": "2 + 3"}`}),
+		llmtest.Reply("2 + 3 is 5."),
+		llmtest.Reply("You asked about 2 + 3."),
+	)
+	assistant := New(provider)
+	conv := newConversation("What is 2 + 3?")
+
+	messages, err := assistant.Reply(ctx, conv)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(messages) != 2 {
+		t.Fatalf("expected a tool message and the reply, got %d messages", len(messages))
+	}
+
+	tool := messages[0]
+	if tool.Role != model.RoleTool || tool.Content != "2 + 3 = 5" || tool.ToolCall == nil {
+		t.Fatalf("unexpected tool message: %+v", tool)
+	}
+	if tool.ToolCall.ID != "call_1" || tool.ToolCall.Name != "calculate" || tool.ToolCall.Error != "" || tool.ToolCall.Duration <= 0 {
+		t.Errorf("unexpected tool call: %+v", tool.ToolCall)
+	}
+
+	// Stored tool messages are replayed to the model as the tool calls and their results.
+	for _, m := range messages {
+		conv.Append(m)
+	}
+	conv.Append(&model.Message{ID: primitive.NewObjectID(), Role: model.RoleUser, Content: "What did I ask?"})
+
+	if _, err := assistant.Reply(ctx, conv); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []llm.Message{
+		llm.UserMessage("What is 2 + 3?"),
+		{Role: llm.RoleAssistant, ToolCalls: []llm.ToolCall{{ID: "call_1", Name: "calculate", Arguments: `{"expression": "2 + 3"}`}}},
+		llm.ToolMessage("2 + 3 = 5", "call_1"),
+		llm.AssistantMessage("2 + 3 is 5."),
+		llm.UserMessage("What did I ask?"),
+	}
+	if diff := cmp.Diff(want, provider.Requests()[2].Messages[1:]); diff != "" {
+		t.Errorf("replayed messages mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAssistant_Persona(t *testing.T) {
+	temperature := 0.2
+	provider := llmtest.NewScripted(
+		llmtest.CallTools(llm.ToolCall{ID: "call_1", Name: "get_weather", Arguments: `{"location": "Barcelona"}`}),
+		llmtest.Reply("I can only do maths."),
+	)
+	assistant := New(provider)
+
+	conv := newConversation("What is the weather in Barcelona?")
+	conv.Persona = &model.Persona{
+		ID:           "maths-tutor",
+		SystemPrompt: "You are a patient maths tutor.",
+		Model:        "gpt-4o-mini",
+		Temperature:  &temperature,
+		Tools:        model.ToolSelection{Enabled: []string{"calculate"}},
+	}
+
+	messages, err := assistant.Reply(context.Background(), conv)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req := provider.Requests()[0]
+	if req.Model != "gpt-4o-mini" || req.Temperature == nil || *req.Temperature != temperature {
+		t.Errorf("expected the persona's model and temperature, got %q and %v", req.Model, req.Temperature)
+	}
+
+	if req.Messages[0].Content != "You are a patient maths tutor." {
+		t.Errorf("expected the persona's system prompt, got %q", req.Messages[0].Content)
+	}
+
+	var names []string
+	for _, tool := range req.Tools {
+		names = append(names, tool.Name)
+	}
+	if diff := cmp.Diff([]string{"calculate"}, names); diff != "" {
+		t.Errorf("advertised tools mismatch (-want +got):\n%s", diff)
+	}
+
+	// Tools the persona may not use are not run, even when the model asks for them.
+	if call := messages[0].ToolCall; call == nil || call.Error != "unknown tool: get_weather" {
+		t.Errorf("expected the weather tool to be refused, got %+v", messages[0])
+	}
+}
+
+func TestAssistant_ToolSelection(t *testing.T) {
+	tests := []struct {
+		name         string
+		persona      *model.Persona
+		conversation model.ToolSelection
+		want         []string
+	}{
+		{name: "every tool by default", want: []string{"calculate", "calculate_date", "calculate_distance", "convert_currency", "convert_time", "find_airport", "get_flight_status", "get_holidays", "get_today_date", "get_weather"}},
+		{
+			name:         "conversation disables network tools",
+			conversation: model.ToolSelection{Disabled: []string{"get_weather", "get_holidays", "convert_currency", "calculate_date", "get_flight_status"}},
+			want:         []string{"calculate", "calculate_distance", "convert_time", "find_airport", "get_today_date"},
+		},
+		{
+			name:         "conversation narrows down the persona's tools",
+			persona:      &model.Persona{Tools: model.ToolSelection{Enabled: []string{"calculate", "get_weather", "get_today_date"}}},
+			conversation: model.ToolSelection{Enabled: []string{"calculate", "get_weather", "get_holidays"}, Disabled: []string{"get_weather"}},
+			want:         []string{"calculate"},
+		},
+		{
+			name:    "persona disables a tool",
+			persona: &model.Persona{Tools: model.ToolSelection{Disabled: []string{"calculate"}}},
+			want:    []string{"calculate_date", "calculate_distance", "convert_currency", "convert_time", "find_airport", "get_flight_status", "get_holidays", "get_today_date", "get_weather"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := llmtest.NewScripted(llmtest.Reply("Hello!"))
+			conv := newConversation("Hi!")
+			conv.Persona, conv.ToolSelection = tt.persona, tt.conversation
+
+			if _, err := New(provider).Reply(context.Background(), conv); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var advertised []string
+			for _, tool := range provider.Requests()[0].Tools {
+				advertised = append(advertised, tool.Name)
+			}
+			if diff := cmp.Diff(tt.want, advertised); diff != "" {
+				t.Errorf("advertised tools mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.want, conv.Tools); diff != "" {
+				t.Errorf("recorded tools mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// funcTool is a tool running a function, with no parameters.
+type funcTool struct {
+	name    string
+	timeout time.Duration
+	run     func(ctx context.Context) (string, error)
+}
+
+func (f *funcTool) Name() string                                          { return f.name }
+func (f *funcTool) Description() string                                   { return "Test tool " + f.name }
+func (f *funcTool) Parameters() *tools.Schema                             { return &tools.Schema{Type: "object"} }
+func (f *funcTool) Timeout() time.Duration                                { return f.timeout }
+func (f *funcTool) Execute(ctx context.Context, _ string) (string, error) { return f.run(ctx) }
+
+func TestAssistant_ParallelToolCalls(t *testing.T) {
+	// The first two calls only return once both are running.
+	var started sync.WaitGroup
+	started.Add(2)
+	rendezvous := func(result string) func(context.Context) (string, error) {
+		return func(ctx context.Context) (string, error) {
+			started.Done()
+			started.Wait()
+			return result, nil
+		}
+	}
+
+	registry := &tools.Registry{}
+	registry.Register(&funcTool{name: "first", run: rendezvous("one")})
+	registry.Register(&funcTool{name: "second", run: rendezvous("two")})
+	registry.Register(&funcTool{name: "slow", timeout: 10 * time.Millisecond, run: func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}})
+
+	provider := llmtest.NewScripted(
+		llmtest.CallTools(
+			llm.ToolCall{ID: "call_1", Name: "first", Arguments: `{}`},
+			llm.ToolCall{ID: "call_2", Name: "slow", Arguments: `{}`},
+			llm.ToolCall{ID: "call_3", Name: "second", Arguments: `{}`},
+		),
+		llmtest.Reply("Done."),
+	)
+
+	var mu sync.Mutex
+	var events []model.EventType
+	onEvent := func(e model.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e.Type)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	messages, err := New(provider, WithTools(registry), WithToolParallelism(2)).ReplyStream(ctx, newConversation("Go!"), onEvent)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	timeout := `{"error":{"tool":"slow","code":"timeout","message":"call timed out after 10ms"}}`
+	want := []llm.Message{
+		llm.ToolMessage("one", "call_1"),
+		llm.ToolMessage(timeout, "call_2"),
+		llm.ToolMessage("two", "call_3"),
+	}
+	if diff := cmp.Diff(want, provider.Requests()[1].Messages[3:]); diff != "" {
+		t.Errorf("tool results mismatch (-want +got):\n%s", diff)
+	}
+
+	if len(messages) != 4 || messages[1].ToolCall.Error != "call timed out after 10ms" || messages[3].Content != "Done." {
+		t.Errorf("unexpected messages: %+v", messages)
+	}
+
+	var calls int
+	for _, e := range events {
+		if e == model.EventToolCallStarted || e == model.EventToolCallFinished {
+			calls++
+		}
+	}
+	if calls != 6 {
+		t.Errorf("expected started and finished events for 3 calls, got %v", events)
+	}
+}
+
+func TestAssistant_Citations(t *testing.T) {
+	conv := newConversation("What is my baggage allowance?")
+
+	registry := &tools.Registry{}
+	registry.Register(&funcTool{name: "search", run: func(ctx context.Context) (string, error) {
+		if got := tools.ConversationID(ctx); got != conv.ID.Hex() {
+			t.Errorf("tool called for conversation %q, want %q", got, conv.ID.Hex())
+		}
+
+		tools.Cite(ctx, tools.Citation{DocumentID: "doc1", DocumentName: "policy.md", Chunk: 0, Excerpt: "Two bags of 23 kg."})
+		tools.Cite(ctx, tools.Citation{DocumentID: "doc2", DocumentName: "booking.txt", Chunk: 3, Excerpt: "Fare: Basic."})
+		return "[1] policy.md, part 1: ...\n\n[2] booking.txt, part 4: ...", nil
+	}})
+
+	policy := model.Citation{Number: 1, DocumentID: "doc1", DocumentName: "policy.md", Chunk: 0, Excerpt: "Two bags of 23 kg."}
+	booking := model.Citation{Number: 2, DocumentID: "doc2", DocumentName: "booking.txt", Chunk: 3, Excerpt: "Fare: Basic."}
+
+	tests := []struct {
+		name  string
+		reply string
+		want  []model.Citation
+	}{
+		{name: "referred to", reply: "Your Basic fare [2] includes no checked bag.", want: []model.Citation{booking}},
+		{name: "not referred to", reply: "You may check two bags of 23 kg.", want: []model.Citation{policy, booking}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := llmtest.NewScripted(
+				llmtest.CallTools(llm.ToolCall{ID: "call_1", Name: "search", Arguments: `{}`}),
+				llmtest.Reply(tt.reply),
+			)
+
+			messages, err := New(provider, WithTools(registry)).Reply(context.Background(), conv)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			answer := messages[len(messages)-1]
+			if diff := cmp.Diff(tt.want, answer.Citations); diff != "" {
+				t.Errorf("citations mismatch (-want +got):\n%s", diff)
+			}
+			if tool := messages[0]; tool.Citations != nil {
+				t.Errorf("tool message has citations: %+v", tool.Citations)
+			}
+		})
+	}
+}
+
+func TestAssistant_Memories(t *testing.T) {
+	store := model.NewInMemoryStore()
+	for _, m := range []*model.Memory{
+		{ID: primitive.NewObjectID(), OwnerID: "alice", Content: "Is vegetarian", CreatedAt: time.Now()},
+		{ID: primitive.NewObjectID(), OwnerID: "bob", Content: "Lives in Madrid", CreatedAt: time.Now()},
+	} {
+		if err := store.CreateMemory(context.Background(), m); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		owner string
+		want  string
+	}{
+		{name: "remembered", owner: "alice", want: systemPrompt + "\n\nWhat you remember about the user from earlier conversations, use it when relevant:\n- Is vegetarian"},
+		{name: "nothing remembered", owner: "carol", want: systemPrompt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := llmtest.NewScripted(llmtest.Reply("Try the falafel."))
+
+			ctx := auth.WithUser(context.Background(), tt.owner)
+			if _, err := New(provider, WithMemories(store)).Reply(ctx, newConversation("Where should I eat tonight?")); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, provider.Requests()[0].Messages[0].Content); diff != "" {
+				t.Errorf("system prompt mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMemories(t *testing.T) {
+	ctx := context.Background()
+	store := model.NewInMemoryStore()
+	memories := Memories(store)
+
+	created := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	id, err := memories.CreateMemory(ctx, "alice", tools.Memory{Content: "Is vegetarian", ConversationID: "conv-1", CreatedAt: created})
+	if err != nil {
+		t.Fatalf("CreateMemory() error = %v", err)
+	}
+
+	got, err := memories.ListMemories(ctx, "alice")
+	if err != nil {
+		t.Fatalf("ListMemories() error = %v", err)
+	}
+
+	want := []tools.Memory{{ID: id, Content: "Is vegetarian", ConversationID: "conv-1", CreatedAt: created}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("memories mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := memories.DeleteMemory(ctx, "bob", id); err == nil {
+		t.Error("DeleteMemory() of another owner succeeded")
+	}
+	if err := memories.DeleteMemory(ctx, "alice", id); err != nil {
+		t.Fatalf("DeleteMemory() error = %v", err)
+	}
+	if got, _ := memories.ListMemories(ctx, "alice"); len(got) != 0 {
+		t.Errorf("memories left after deletion: %+v", got)
+	}
+}
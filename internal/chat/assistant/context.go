@@ -0,0 +1,179 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/llm"
+)
+
+const (
+	systemPrompt = "You are a helpful, concise AI assistant. Provide accurate, safe, and clear responses."
+
+	summaryPrompt = "Summarize the conversation below for an assistant that will continue it. Keep the facts, names, " +
+		"numbers, decisions and open questions the user may refer back to. Reply with the summary only, in plain text."
+)
+
+// prompt returns the messages sent to the model to reply to the conversation's active path. When they exceed
+// the context budget, the older turns are replaced by a summary, kept on the conversation so it is reused on
+// later turns rather than recomputed.
+func (a *Assistant) prompt(ctx context.Context, conv *model.Conversation, s replySettings) []llm.Message {
+	path := conv.ActivePath()
+
+	// A summary only applies while its last message is still on the active path.
+	summary, start := "", 0
+	if s := conv.Summary; s != nil {
+		for i, m := range path {
+			if m.ID == s.ThroughID {
+				summary, start = s.Content, i+1
+				break
+			}
+		}
+	}
+
+	msgs := s.history(summary, path[start:])
+	budget := a.budget(s.model)
+	if llm.EstimateTokens(s.request(msgs)) <= budget {
+		return msgs
+	}
+
+	keep := start + s.recentTurns(path[start:], budget/2)
+	if keep == start {
+		slog.WarnContext(ctx, "Conversation exceeds the context budget, but has nothing left to summarize", "conversation_id", conv.ID)
+		return msgs
+	}
+
+	content, err := a.summarize(ctx, conv, summary, path[start:keep])
+	if err != nil {
+		// Dropping the older turns still lets the conversation go on, with less context.
+		slog.ErrorContext(ctx, "Failed to summarize conversation, older turns are left out", "conversation_id", conv.ID, "error", err)
+		return s.history(summary, path[keep:])
+	}
+
+	conv.Summary = &model.Summary{Content: content, ThroughID: path[keep-1].ID, CreatedAt: time.Now()}
+	return s.history(content, path[keep:])
+}
+
+// budget returns the number of prompt tokens a reply generated by the model may use, leaving a quarter of its
+// context window for the completion.
+func (a *Assistant) budget(model string) int {
+	return min(a.contextBudget, llm.ContextWindow(model)*3/4)
+}
+
+// recentTurns returns the index of the first message of the most recent turns taking at most budget tokens.
+// Turns start with a user message and are kept whole, the last one even if it exceeds the budget.
+func (s replySettings) recentTurns(messages []*model.Message, budget int) int {
+	keep, used := len(messages), 0
+	for i := len(messages) - 1; i >= 0; i-- {
+		used += llm.EstimateTokens(llm.Request{Model: s.model, Messages: []llm.Message{llm.UserMessage(messages[i].Content)}})
+		if used > budget && keep < len(messages) {
+			break
+		}
+
+		if messages[i].Role == model.RoleUser {
+			keep = i
+		}
+	}
+
+	return keep
+}
+
+// summarize condenses the previous summary, if any, and the messages into a new summary.
+func (a *Assistant) summarize(ctx context.Context, conv *model.Conversation, previous string, messages []*model.Message) (string, error) {
+	slog.InfoContext(ctx, "Summarizing conversation", "conversation_id", conv.ID, "messages", len(messages))
+
+	var transcript strings.Builder
+	if previous != "" {
+		fmt.Fprintf(&transcript, "Summary of the earlier conversation:\n%s\n\n", previous)
+	}
+	for _, m := range messages {
+		if m.ToolCall != nil {
+			fmt.Fprintf(&transcript, "TOOL %s(%s): %s\n\n", m.ToolCall.Name, m.ToolCall.Arguments, m.Content)
+			continue
+		}
+		fmt.Fprintf(&transcript, "%s: %s\n\n", strings.ToUpper(string(m.Role)), m.Content)
+	}
+
+	req := llm.Request{
+		Model:    a.summaryModel,
+		Messages: []llm.Message{llm.SystemMessage(summaryPrompt), llm.UserMessage(strings.TrimSpace(transcript.String()))},
+	}
+
+	resp, err := a.llm.Complete(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	recordUsage(conv, req, resp)
+
+	content := strings.TrimSpace(resp.Message.Content)
+	if content == "" {
+		return "", errors.New("empty response from model for conversation summary")
+	}
+
+	return content, nil
+}
+
+// history builds the messages sent to the model: the system prompt, the summary of older turns, if any, and
+// the given messages.
+func (s replySettings) history(summary string, messages []*model.Message) []llm.Message {
+	msgs := []llm.Message{llm.SystemMessage(s.systemPrompt)}
+	if summary != "" {
+		msgs = append(msgs, llm.SystemMessage("Summary of the earlier conversation:\n"+summary))
+	}
+
+	// Tool messages are replayed as an assistant message requesting the calls, followed by their results.
+	// Consecutive calls are requested together, even if the model originally made them one after another.
+	request := -1
+	for _, m := range messages {
+		if m.Role != model.RoleTool {
+			request = -1
+		}
+
+		switch m.Role {
+		case model.RoleUser:
+			msgs = append(msgs, llm.UserMessage(m.Content))
+		case model.RoleAssistant:
+			msgs = append(msgs, llm.AssistantMessage(m.Content))
+		case model.RoleTool:
+			if m.ToolCall == nil {
+				continue
+			}
+
+			if request < 0 {
+				msgs = append(msgs, llm.Message{Role: llm.RoleAssistant})
+				request = len(msgs) - 1
+			}
+
+			msgs[request].ToolCalls = append(msgs[request].ToolCalls, llm.ToolCall{
+				ID:        m.ToolCall.ID,
+				Name:      m.ToolCall.Name,
+				Arguments: m.ToolCall.Arguments,
+			})
+			msgs = append(msgs, llm.ToolMessage(m.Content, m.ToolCall.ID))
+		}
+	}
+
+	return msgs
+}
+
+// recordUsage adds the tokens spent on the completion to the conversation, estimating them when the provider
+// does not report usage, and returns the prompt tokens.
+func recordUsage(conv *model.Conversation, req llm.Request, resp *llm.Completion) int {
+	usage := resp.Usage
+	if usage.PromptTokens == 0 {
+		usage.PromptTokens = llm.EstimateTokens(req)
+	}
+	if usage.CompletionTokens == 0 {
+		usage.CompletionTokens = llm.EstimateTokens(llm.Request{Model: req.Model, Messages: []llm.Message{resp.Message}})
+	}
+
+	conv.Usage.PromptTokens += usage.PromptTokens
+	conv.Usage.CompletionTokens += usage.CompletionTokens
+	return usage.PromptTokens
+}
@@ -0,0 +1,87 @@
+package assistant
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/tools"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxPromptMemories is the number of memories about the user added to the system prompt of a reply.
+const maxPromptMemories = 20
+
+// memoryPrompt returns the instructions to append to the system prompt listing the memories about the caller
+// most relevant to the conversation's last message, or "" when there are none. The caller is the user the memory
+// tools act for. Replies are still generated when the memories cannot be loaded, without them.
+func (a *Assistant) memoryPrompt(ctx context.Context, conv *model.Conversation) string {
+	if a.memories == nil {
+		return ""
+	}
+
+	memories, err := Memories(a.memories).ListMemories(ctx, auth.UserID(ctx))
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to load memories", "conversation_id", conv.ID, "error", err)
+		return ""
+	}
+
+	if len(memories) == 0 {
+		return ""
+	}
+
+	path := conv.ActivePath()
+
+	var b strings.Builder
+	b.WriteString("\n\nWhat you remember about the user from earlier conversations, use it when relevant:")
+	for _, m := range tools.RelevantMemories(memories, path[len(path)-1].Content, maxPromptMemories) {
+		b.WriteString("\n- ")
+		b.WriteString(m.Content)
+	}
+
+	return b.String()
+}
+
+// Memories adapts the store to the memory tools, see tools.RememberTool.
+func Memories(store model.MemoryStore) tools.MemoryStore {
+	return toolMemories{store}
+}
+
+type toolMemories struct {
+	store model.MemoryStore
+}
+
+func (s toolMemories) CreateMemory(ctx context.Context, ownerID string, m tools.Memory) (string, error) {
+	id := primitive.NewObjectID()
+	err := s.store.CreateMemory(ctx, &model.Memory{
+		ID:             id,
+		OwnerID:        ownerID,
+		Content:        m.Content,
+		ConversationID: m.ConversationID,
+		CreatedAt:      m.CreatedAt,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id.Hex(), nil
+}
+
+func (s toolMemories) ListMemories(ctx context.Context, ownerID string) ([]tools.Memory, error) {
+	memories, err := s.store.ListMemories(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]tools.Memory, len(memories))
+	for i, m := range memories {
+		out[i] = tools.Memory{ID: m.ID.Hex(), Content: m.Content, ConversationID: m.ConversationID, CreatedAt: m.CreatedAt}
+	}
+	return out, nil
+}
+
+func (s toolMemories) DeleteMemory(ctx context.Context, ownerID, id string) error {
+	return s.store.DeleteMemory(ctx, ownerID, id)
+}
@@ -0,0 +1,51 @@
+package assistant
+
+import (
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/llm"
+	"github.com/acai-travel/tech-challenge/internal/tools"
+)
+
+// replySettings are the settings replies to a conversation are generated with: the assistant's own, overridden
+// by the ones of the persona the conversation was started with.
+type replySettings struct {
+	systemPrompt string
+	model        string
+	temperature  *float64
+
+	// tools are the tools the reply may use, narrowed down by the persona's and the conversation's selections.
+	tools *tools.Registry
+}
+
+func (a *Assistant) settings(conv *model.Conversation) replySettings {
+	s := replySettings{
+		systemPrompt: systemPrompt,
+		model:        a.replyModel,
+		tools:        a.tools,
+	}
+
+	if p := conv.Persona; p != nil {
+		if p.SystemPrompt != "" {
+			s.systemPrompt = p.SystemPrompt
+		}
+
+		if p.Model != "" {
+			s.model = p.Model
+		}
+
+		s.temperature = p.Temperature
+		s.tools = s.tools.Select(p.Tools.Enabled, p.Tools.Disabled)
+	}
+
+	s.tools = s.tools.Select(conv.ToolSelection.Enabled, conv.ToolSelection.Disabled)
+	return s
+}
+
+func (s replySettings) request(msgs []llm.Message) llm.Request {
+	return llm.Request{
+		Model:       s.model,
+		Messages:    msgs,
+		Tools:       s.tools.GetTools(),
+		Temperature: s.temperature,
+	}
+}
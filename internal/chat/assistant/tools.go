@@ -0,0 +1,77 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/llm"
+	"github.com/acai-travel/tech-challenge/internal/tools"
+)
+
+// executeTools runs the tool calls the model asked for in one turn, a.toolCalls at most at once, and returns their
+// tool messages in call order. Failed calls, including timed out and canceled ones, are reported to the model in
+// their results rather than failing the reply.
+func (a *Assistant) executeTools(ctx context.Context, registry *tools.Registry, calls []llm.ToolCall, onEvent func(model.Event)) []*model.Message {
+	// Calls report their events concurrently, onEvent is not expected to be safe for concurrent use.
+	var mu sync.Mutex
+	report := func(event model.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		emit(onEvent, event)
+	}
+
+	results := make([]*model.Message, len(calls))
+	slots := make(chan struct{}, max(a.toolCalls, 1))
+
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		slots <- struct{}{}
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			results[i] = executeTool(ctx, registry, call, report)
+		}()
+	}
+
+	wg.Wait()
+	return results
+}
+
+// executeTool runs a tool call, and returns its tool message.
+func executeTool(ctx context.Context, registry *tools.Registry, call llm.ToolCall, report func(model.Event)) *model.Message {
+	slog.InfoContext(ctx, "Tool call received", "name", call.Name, "args", call.Arguments)
+
+	event := &model.ToolCallEvent{ID: call.ID, Name: call.Name, Arguments: call.Arguments}
+	report(model.Event{Type: model.EventToolCallStarted, ToolCall: event})
+
+	started := time.Now()
+	result, err := registry.Execute(ctx, call.Name, call.Arguments)
+	if err != nil {
+		// Errors are fed back to the model as structured results, so it can correct its call.
+		var terr *tools.ToolError
+		if !errors.As(err, &terr) {
+			terr = &tools.ToolError{Tool: call.Name, Code: tools.CodeExecutionFailed, Message: err.Error()}
+		}
+		result = terr.Result()
+		event.Error = err.Error()
+	}
+	event.Result = result
+	report(model.Event{Type: model.EventToolCallFinished, ToolCall: event})
+
+	return newMessage(model.RoleTool, result, &model.ToolCall{
+		ID:        call.ID,
+		Name:      call.Name,
+		Arguments: call.Arguments,
+		Error:     event.Error,
+		Duration:  time.Since(started),
+	})
+}
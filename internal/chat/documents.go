@@ -0,0 +1,71 @@
+package chat
+
+import (
+	"context"
+	"log/slog"
+	"path"
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/documents"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxDocumentSize bounds the size of uploaded documents, in bytes.
+const maxDocumentSize = 5 << 20
+
+func (s *Server) UploadDocument(ctx context.Context, req *pb.UploadDocumentRequest) (*pb.UploadDocumentResponse, error) {
+	if s.documents == nil {
+		return nil, twirp.NewError(twirp.Unimplemented, "document uploads are not enabled")
+	}
+
+	// Only the base name is kept, clients may send the path the document was uploaded from.
+	name := strings.TrimSpace(path.Base(strings.ReplaceAll(req.GetName(), `\`, "/")))
+	if name == "" || name == "." || name == "/" {
+		return nil, twirp.RequiredArgumentError("name")
+	}
+
+	content := req.GetContent()
+	if len(content) == 0 {
+		return nil, twirp.RequiredArgumentError("content")
+	}
+	if len(content) > maxDocumentSize {
+		return nil, twirp.InvalidArgumentError("content", "must be at most 5 MB")
+	}
+
+	text, contentType, err := documents.Text(req.GetContentType(), content)
+	if err != nil {
+		return nil, twirp.InvalidArgumentError("content", err.Error())
+	}
+	if strings.TrimSpace(text) == "" {
+		return nil, twirp.InvalidArgumentError("content", "must contain text")
+	}
+
+	doc := &documents.Document{
+		ID:          primitive.NewObjectID(),
+		OwnerID:     auth.UserID(ctx),
+		Name:        name,
+		ContentType: contentType,
+		Size:        len(content),
+		CreatedAt:   now(),
+	}
+
+	// Documents of a conversation are only searched in it, so it must be one of the caller's.
+	if id := req.GetConversationId(); id != "" {
+		conversation, err := s.repo.DescribeConversation(ctx, doc.OwnerID, id)
+		if err != nil {
+			return nil, err
+		}
+		doc.ConversationID = conversation.ID.Hex()
+	}
+
+	if err := s.documents.Add(ctx, doc, text); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	slog.InfoContext(ctx, "Document uploaded", "document_id", doc.ID.Hex(), "chunks", doc.Chunks, "size", doc.Size)
+
+	return &pb.UploadDocumentResponse{Document: doc.Proto()}, nil
+}
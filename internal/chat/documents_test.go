@@ -0,0 +1,178 @@
+package chat
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/documents"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/acai-travel/tech-challenge/internal/tools"
+	"github.com/google/go-cmp/cmp"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// CitingAssistant answers with a citation of the first passage of the documents matching the question.
+type CitingAssistant struct {
+	MockAssistant
+	library *documents.Library
+}
+
+func (m *CitingAssistant) Reply(ctx context.Context, conv *model.Conversation) ([]*model.Message, error) {
+	path := conv.ActivePath()
+
+	matches, err := m.library.Search(ctx, auth.UserID(ctx), conv.ID.Hex(), path[len(path)-1].Content, 1)
+	if err != nil || len(matches) == 0 {
+		return []*model.Message{answer("I could not find it.")}, err
+	}
+
+	reply := answer("Found it [1].")
+	reply.Citations = []model.Citation{{
+		Number:       1,
+		DocumentID:   matches[0].DocumentID.Hex(),
+		DocumentName: matches[0].DocumentName,
+		Chunk:        matches[0].Index,
+		Excerpt:      matches[0].Text,
+	}}
+	return []*model.Message{reply}, nil
+}
+
+func TestServer_UploadDocument(t *testing.T) {
+	ctx := auth.WithUser(context.Background(), "alice")
+
+	t.Run("uploads are disabled without a library", WithFixture(func(t *testing.T, f *Fixture) {
+		_, err := NewServer(f.Store, nil).UploadDocument(ctx, &pb.UploadDocumentRequest{Name: "a.txt", Content: []byte("text")})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Unimplemented {
+			t.Errorf("expected twirp.Unimplemented error, got %v", err)
+		}
+	}))
+
+	t.Run("rejects invalid documents", WithFixture(func(t *testing.T, f *Fixture) {
+		library := documents.NewLibrary(&documents.HashingEmbedder{}, documents.NewMemoryIndex())
+		srv := NewServer(f.Store, nil, WithDocuments(library))
+
+		bob := f.CreateConversation(func(c *model.Conversation) { c.OwnerID = "bob" })
+
+		tests := []struct {
+			name string
+			req  *pb.UploadDocumentRequest
+			code twirp.ErrorCode
+		}{
+			{name: "no name", req: &pb.UploadDocumentRequest{Content: []byte("text")}, code: twirp.InvalidArgument},
+			{name: "no content", req: &pb.UploadDocumentRequest{Name: "a.txt"}, code: twirp.InvalidArgument},
+			{name: "blank content", req: &pb.UploadDocumentRequest{Name: "a.txt", Content: []byte(" \n\n ")}, code: twirp.InvalidArgument},
+			{name: "pdf without pages", req: &pb.UploadDocumentRequest{Name: "a.pdf", ContentType: "application/pdf", Content: []byte("%PDF-1.7")}, code: twirp.InvalidArgument},
+			{name: "too large", req: &pb.UploadDocumentRequest{Name: "a.txt", Content: []byte(strings.Repeat("a", maxDocumentSize+1))}, code: twirp.InvalidArgument},
+			{name: "conversation of another user", req: &pb.UploadDocumentRequest{Name: "a.txt", Content: []byte("text"), ConversationId: bob.ID.Hex()}, code: twirp.NotFound},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := srv.UploadDocument(ctx, tt.req)
+				if te, ok := err.(twirp.Error); !ok || te.Code() != tt.code {
+					t.Errorf("expected twirp.%s error, got %v", tt.code, err)
+				}
+			})
+		}
+	}))
+
+	t.Run("replies cite uploaded documents", WithFixture(func(t *testing.T, f *Fixture) {
+		library := documents.NewLibrary(&documents.HashingEmbedder{}, documents.NewMemoryIndex())
+		srv := NewServer(f.Store, &CitingAssistant{library: library}, WithDocuments(library))
+
+		conv := f.CreateConversation(func(c *model.Conversation) { c.OwnerID = "alice" })
+
+		content := "Booking reference X7K2P.\n\nHotel Arts, Barcelona, check-in on May 3rd."
+		uploaded, err := srv.UploadDocument(ctx, &pb.UploadDocumentRequest{
+			Name:           `C:\Users\alice\booking.txt`,
+			Content:        []byte(content),
+			ConversationId: conv.ID.Hex(),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		doc := uploaded.GetDocument()
+		want := &pb.Document{
+			Id:             doc.GetId(),
+			Name:           "booking.txt",
+			ContentType:    "text/plain; charset=utf-8",
+			Size:           int64(len(content)),
+			Chunks:         1,
+			ConversationId: conv.ID.Hex(),
+			CreatedAt:      doc.GetCreatedAt(),
+		}
+		if diff := cmp.Diff(want, doc, protocmp.Transform()); diff != "" {
+			t.Errorf("uploaded document mismatch (-want +got):\n%s", diff)
+		}
+
+		resp, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: conv.ID.Hex(), Message: "Which hotel did I book?"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		citations := []*pb.Citation{{
+			Number:       1,
+			DocumentId:   doc.GetId(),
+			DocumentName: "booking.txt",
+			Excerpt:      content,
+		}}
+		if diff := cmp.Diff(citations, resp.GetCitations(), protocmp.Transform()); diff != "" {
+			t.Errorf("reply citations mismatch (-want +got):\n%s", diff)
+		}
+
+		// Citations are kept with the answer.
+		described, err := srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: conv.ID.Hex()})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		messages := described.GetConversation().GetMessages()
+		if diff := cmp.Diff(citations, messages[len(messages)-1].GetCitations(), protocmp.Transform()); diff != "" {
+			t.Errorf("stored citations mismatch (-want +got):\n%s", diff)
+		}
+
+		// The document is not searched in the user's other conversations.
+		started, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Which hotel did I book?"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if started.GetReply() != "I could not find it." || len(started.GetCitations()) != 0 {
+			t.Errorf("unexpected reply in another conversation: %v", started)
+		}
+	}))
+
+	t.Run("searches the text of uploaded PDFs", WithFixture(func(t *testing.T, f *Fixture) {
+		library := documents.NewLibrary(&documents.HashingEmbedder{}, documents.NewMemoryIndex())
+		srv := NewServer(f.Store, nil, WithDocuments(library))
+
+		content, err := os.ReadFile(filepath.Join("..", "documents", "pdf", "testdata", "booking.pdf"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		uploaded, err := srv.UploadDocument(ctx, &pb.UploadDocumentRequest{Name: "booking.pdf", Content: content})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if doc := uploaded.GetDocument(); doc.GetContentType() != "application/pdf" || doc.GetSize() != int64(len(content)) {
+			t.Errorf("unexpected document: %v", doc)
+		}
+
+		search := &tools.DocumentSearchTool{Library: library, UserID: auth.UserID}
+		got, err := search.Execute(ctx, `{"query": "checked baggage allowance", "max_count": 1}`)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !strings.HasPrefix(got, "[1] booking.pdf, part ") || !strings.Contains(got, "Checked baggage allowance: one bag of 23 kg per passenger.") {
+			t.Errorf("unexpected search results: %q", got)
+		}
+	}))
+}
@@ -0,0 +1,102 @@
+package chat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestServer_Memories(t *testing.T) {
+	ctx := auth.WithUser(context.Background(), "alice")
+
+	t.Run("lists and deletes the caller's memories", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, nil)
+
+		created := now()
+		for i, m := range []*model.Memory{
+			{ID: primitive.NewObjectID(), OwnerID: "alice", Content: "Lives in Barcelona", ConversationID: "conv-1"},
+			{ID: primitive.NewObjectID(), OwnerID: "alice", Content: "Is vegetarian"},
+			{ID: primitive.NewObjectID(), OwnerID: "bob", Content: "Lives in Madrid"},
+		} {
+			m.CreatedAt = created.Add(time.Duration(i) * time.Second)
+			if err := f.Store.CreateMemory(ctx, m); err != nil {
+				t.Fatalf("failed to create memory: %v", err)
+			}
+			t.Cleanup(func() { _ = f.Store.DeleteMemory(ctx, m.OwnerID, m.ID.Hex()) })
+		}
+
+		contents := func() []string {
+			t.Helper()
+
+			resp, err := srv.ListMemories(ctx, &pb.ListMemoriesRequest{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var out []string
+			for _, m := range resp.GetMemories() {
+				out = append(out, m.GetContent())
+			}
+			return out
+		}
+
+		if diff := cmp.Diff([]string{"Lives in Barcelona", "Is vegetarian"}, contents()); diff != "" {
+			t.Errorf("memories mismatch (-want +got):\n%s", diff)
+		}
+
+		resp, err := srv.ListMemories(ctx, &pb.ListMemoriesRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		first := resp.GetMemories()[0]
+		if first.GetConversationId() != "conv-1" || !first.GetCreatedAt().AsTime().Equal(created) {
+			t.Errorf("unexpected memory: %v", first)
+		}
+
+		if _, err := srv.DeleteMemory(ctx, &pb.DeleteMemoryRequest{MemoryId: first.GetId()}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff([]string{"Is vegetarian"}, contents()); diff != "" {
+			t.Errorf("memories mismatch after delete (-want +got):\n%s", diff)
+		}
+	}))
+
+	t.Run("rejects invalid deletes", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, nil)
+
+		bob := &model.Memory{ID: primitive.NewObjectID(), OwnerID: "bob", Content: "Lives in Madrid", CreatedAt: now()}
+		if err := f.Store.CreateMemory(ctx, bob); err != nil {
+			t.Fatalf("failed to create memory: %v", err)
+		}
+		t.Cleanup(func() { _ = f.Store.DeleteMemory(ctx, bob.OwnerID, bob.ID.Hex()) })
+
+		tests := []struct {
+			name string
+			id   string
+			code twirp.ErrorCode
+		}{
+			{name: "no ID", id: "", code: twirp.InvalidArgument},
+			{name: "invalid ID", id: "not-an-id", code: twirp.NotFound},
+			{name: "memory of another user", id: bob.ID.Hex(), code: twirp.NotFound},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := srv.DeleteMemory(ctx, &pb.DeleteMemoryRequest{MemoryId: tt.id})
+				if te, ok := err.(twirp.Error); !ok || te.Code() != tt.code {
+					t.Errorf("expected twirp.%s error, got %v", tt.code, err)
+				}
+			})
+		}
+	}))
+}
@@ -0,0 +1,330 @@
+package model
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/twitchtv/twirp"
+	"go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	conversationBucket = []byte(conversationCollection)
+	personaBucket      = []byte(personaCollection)
+	memoryBucket       = []byte(memoryCollection)
+)
+
+// BoltStore keeps conversations in an embedded bbolt database file, for single-binary deployments that do
+// not want to run MongoDB. Documents are stored BSON-encoded, conversations keyed by their ObjectID, and personas
+// and memories by their owner and ID.
+type BoltStore struct {
+	db *bbolt.DB
+}
+
+// OpenBoltStore opens (or creates) the database file at path.
+func OpenBoltStore(path string) (*BoltStore, error) {
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bolt database %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range [][]byte{conversationBucket, personaBucket, memoryBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to initialize bolt database %s: %w", path, err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// Close releases the database file.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *BoltStore) CreateConversation(ctx context.Context, c *Conversation) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(conversationBucket)
+		if b.Get(c.ID[:]) != nil {
+			return twirp.NewError(twirp.AlreadyExists, "conversation already exists")
+		}
+
+		return boltPut(b, c.ID[:], c)
+	})
+}
+
+func (s *BoltStore) DescribeConversation(ctx context.Context, ownerID, id string) (*Conversation, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, twirp.NotFoundError("invalid conversation ID")
+	}
+
+	var c *Conversation
+	err = s.db.View(func(tx *bbolt.Tx) error {
+		c, err = boltGet[Conversation](tx.Bucket(conversationBucket), oid[:])
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if c == nil || !c.visibleTo(ownerID) {
+		return nil, twirp.NotFoundError("conversation not found")
+	}
+
+	return c, nil
+}
+
+func (s *BoltStore) ListConversations(ctx context.Context, ownerID string, q ListQuery) (*ConversationPage, error) {
+	var items []*Conversation
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(conversationBucket).ForEach(func(_, v []byte) error {
+			var c Conversation
+			if err := bson.Unmarshal(v, &c); err != nil {
+				return err
+			}
+			if c.visibleTo(ownerID) {
+				c.Messages = nil
+				items = append(items, &c)
+			}
+			return nil
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return q.page(items)
+}
+
+func (s *BoltStore) UpdateConversation(ctx context.Context, c *Conversation) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(conversationBucket)
+
+		existing, err := boltGet[Conversation](b, c.ID[:])
+		if err != nil {
+			return err
+		}
+
+		if existing == nil || !existing.visibleTo(c.OwnerID) {
+			return twirp.NotFoundError("conversation not found")
+		}
+
+		return boltPut(b, c.ID[:], c)
+	})
+}
+
+func (s *BoltStore) DeleteConversation(ctx context.Context, ownerID, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return twirp.NotFoundError("invalid conversation ID")
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(conversationBucket)
+
+		c, err := boltGet[Conversation](b, oid[:])
+		if err != nil {
+			return err
+		}
+
+		if c == nil || !c.visibleTo(ownerID) {
+			return twirp.NotFoundError("conversation not found")
+		}
+
+		now := time.Now()
+		c.DeletedAt = &now
+		return boltPut(b, oid[:], c)
+	})
+}
+
+func (s *BoltStore) PurgeConversations(ctx context.Context, cutoff time.Time) (int, error) {
+	purged := 0
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(conversationBucket)
+
+		// Keys are collected first, bbolt does not allow modifying a bucket while iterating over it.
+		var keys [][]byte
+		err := b.ForEach(func(k, v []byte) error {
+			var c Conversation
+			if err := bson.Unmarshal(v, &c); err != nil {
+				return err
+			}
+			if c.DeletedAt != nil && !c.DeletedAt.After(cutoff) {
+				keys = append(keys, k)
+			}
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+
+		for _, k := range keys {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		purged = len(keys)
+		return nil
+	})
+
+	return purged, err
+}
+
+func (s *BoltStore) CreatePersona(ctx context.Context, p *Persona) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(personaBucket)
+		if b.Get([]byte(p.key())) != nil {
+			return twirp.NewError(twirp.AlreadyExists, "persona already exists")
+		}
+
+		return boltPut(b, []byte(p.key()), p)
+	})
+}
+
+func (s *BoltStore) DescribePersona(ctx context.Context, ownerID, id string) (*Persona, error) {
+	var p *Persona
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		p, err = boltGet[Persona](tx.Bucket(personaBucket), []byte(personaKey(ownerID, id)))
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if p == nil {
+		return nil, twirp.NotFoundError("persona not found")
+	}
+
+	return p, nil
+}
+
+func (s *BoltStore) ListPersonas(ctx context.Context, ownerID string) ([]*Persona, error) {
+	var personas []*Persona
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		// Keys start with the owner ID and are sorted, so the owner's personas are next to each other by ID. Owner
+		// IDs may contain slashes, the prefix can match the personas of other owners too.
+		prefix := []byte(personaKey(ownerID, ""))
+		c := tx.Bucket(personaBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			var p Persona
+			if err := bson.Unmarshal(v, &p); err != nil {
+				return err
+			}
+			if p.OwnerID == ownerID {
+				personas = append(personas, &p)
+			}
+		}
+		return nil
+	})
+
+	return personas, err
+}
+
+func (s *BoltStore) UpdatePersona(ctx context.Context, p *Persona) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(personaBucket)
+		if b.Get([]byte(p.key())) == nil {
+			return twirp.NotFoundError("persona not found")
+		}
+
+		return boltPut(b, []byte(p.key()), p)
+	})
+}
+
+func (s *BoltStore) DeletePersona(ctx context.Context, ownerID, id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(personaBucket)
+
+		key := []byte(personaKey(ownerID, id))
+		if b.Get(key) == nil {
+			return twirp.NotFoundError("persona not found")
+		}
+
+		return b.Delete(key)
+	})
+}
+
+func (s *BoltStore) CreateMemory(ctx context.Context, m *Memory) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return boltPut(tx.Bucket(memoryBucket), []byte(m.key()), m)
+	})
+}
+
+func (s *BoltStore) ListMemories(ctx context.Context, ownerID string) ([]*Memory, error) {
+	var memories []*Memory
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		// The owner's memories are next to each other, see ListPersonas.
+		prefix := []byte(memoryKey(ownerID, ""))
+		c := tx.Bucket(memoryBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			var m Memory
+			if err := bson.Unmarshal(v, &m); err != nil {
+				return err
+			}
+			if m.OwnerID == ownerID {
+				memories = append(memories, &m)
+			}
+		}
+		return nil
+	})
+
+	sortMemories(memories)
+	return memories, err
+}
+
+func (s *BoltStore) DeleteMemory(ctx context.Context, ownerID, id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(memoryBucket)
+
+		key := []byte(memoryKey(ownerID, id))
+		if b.Get(key) == nil {
+			return twirp.NotFoundError("memory not found")
+		}
+
+		return b.Delete(key)
+	})
+}
+
+// boltPut stores v BSON-encoded under the given key.
+func boltPut(b *bbolt.Bucket, key []byte, v any) error {
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return b.Put(key, data)
+}
+
+// boltGet loads the document stored under the given key, or nil if there is none.
+func boltGet[T any](b *bbolt.Bucket, key []byte) (*T, error) {
+	data := b.Get(key)
+	if data == nil {
+		return nil, nil
+	}
+
+	var v T
+	if err := bson.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
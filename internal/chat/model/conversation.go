@@ -9,11 +9,53 @@ import (
 )
 
 type Conversation struct {
-	ID        primitive.ObjectID `bson:"_id"`
-	Title     string             `bson:"subject"`
+	ID            primitive.ObjectID `bson:"_id"`
+	OwnerID       string             `bson:"owner_id"`
+	Title         string             `bson:"subject"`
+	CreatedAt     time.Time          `bson:"created_at"`
+	UpdatedAt     time.Time          `bson:"updated_at"`
+	Messages      []*Message         `bson:"messages"` // Every message of every branch, in creation order.
+	ActiveLeafID  primitive.ObjectID `bson:"active_leaf_id,omitempty"`
+	Summary       *Summary           `bson:"summary,omitempty"`
+	Usage         Usage              `bson:"usage"`
+	Persona       *Persona           `bson:"persona,omitempty"` // Copy of the persona it was started with.
+	ToolSelection ToolSelection      `bson:"tool_selection"`
+	Tools         []string           `bson:"tools,omitempty"` // Tools the latest reply could use.
+	Archived      bool               `bson:"archived"`
+	DeletedAt     *time.Time         `bson:"deleted_at,omitempty"` // Set when soft-deleted, until purged.
+}
+
+// Summary condenses the beginning of a conversation, so the model does not need to be sent all of it.
+type Summary struct {
+	Content   string             `bson:"content"`
+	ThroughID primitive.ObjectID `bson:"through_id"` // Last message covered by the summary.
 	CreatedAt time.Time          `bson:"created_at"`
-	UpdatedAt time.Time          `bson:"updated_at"`
-	Messages  []*Message         `bson:"messages"`
+}
+
+// Usage counts the tokens spent on a conversation.
+type Usage struct {
+	PromptTokens     int `bson:"prompt_tokens"`
+	CompletionTokens int `bson:"completion_tokens"`
+	ContextTokens    int `bson:"context_tokens"` // Prompt size of the latest request to the model.
+}
+
+func (u Usage) Proto() *pb.TokenUsage {
+	return &pb.TokenUsage{
+		PromptTokens:     int64(u.PromptTokens),
+		CompletionTokens: int64(u.CompletionTokens),
+		ContextTokens:    int64(u.ContextTokens),
+	}
+}
+
+// ToolSelection restricts the tools the assistant may use.
+type ToolSelection struct {
+	Enabled  []string `bson:"enabled,omitempty"`  // Only these tools, every tool when empty.
+	Disabled []string `bson:"disabled,omitempty"` // Never these tools, even if enabled.
+}
+
+// visibleTo reports whether the owner can see the conversation: it is theirs and not deleted.
+func (c *Conversation) visibleTo(ownerID string) bool {
+	return c.OwnerID == ownerID && c.DeletedAt == nil
 }
 
 func (c *Conversation) Proto() *pb.Conversation {
@@ -21,11 +63,23 @@ func (c *Conversation) Proto() *pb.Conversation {
 		Id:        c.ID.Hex(),
 		Title:     c.Title,
 		Timestamp: timestamppb.New(c.UpdatedAt),
+		Archived:  c.Archived,
+		Usage:     c.Usage.Proto(),
+	}
+
+	if c.Persona != nil {
+		proto.PersonaId = c.Persona.ID
 	}
 
-	for _, m := range c.Messages {
+	proto.Tools = c.Tools
+
+	for _, m := range c.ActivePath() {
 		proto.Messages = append(proto.Messages, m.Proto())
 	}
 
+	for _, f := range c.Forks() {
+		proto.Forks = append(proto.Forks, f.Proto())
+	}
+
 	return proto
 }
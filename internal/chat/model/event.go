@@ -0,0 +1,40 @@
+package model
+
+// EventType identifies the kind of progress reported while a reply is being generated.
+type EventType string
+
+const (
+	// EventStarted is sent once the conversation the reply belongs to is known.
+	EventStarted EventType = "started"
+	// EventDelta carries the next chunk of the assistant's reply.
+	EventDelta EventType = "delta"
+	// EventToolCallStarted is sent right before a tool is executed.
+	EventToolCallStarted EventType = "tool_call_started"
+	// EventToolCallFinished is sent once a tool has returned.
+	EventToolCallFinished EventType = "tool_call_finished"
+	// EventSaved is sent once the reply has been persisted.
+	EventSaved EventType = "saved"
+	// EventError is sent when the reply could not be generated or saved.
+	EventError EventType = "error"
+)
+
+// Event describes a single step of progress while answering a user message.
+type Event struct {
+	Type           EventType      `json:"-"`
+	ConversationID string         `json:"conversation_id,omitempty"`
+	Title          string         `json:"title,omitempty"`
+	Delta          string         `json:"delta,omitempty"`
+	Reply          string         `json:"reply,omitempty"`
+	ToolCall       *ToolCallEvent `json:"tool_call,omitempty"`
+	Citations      []Citation     `json:"citations,omitempty"` // Sent with EventSaved.
+	Error          string         `json:"error,omitempty"`
+}
+
+// ToolCallEvent describes a tool invocation made by the assistant.
+type ToolCallEvent struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments,omitempty"`
+	Result    string `json:"result,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
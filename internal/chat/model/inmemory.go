@@ -0,0 +1,291 @@
+package model
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// InMemoryStore keeps conversations in process memory. It is meant for tests and ephemeral deployments,
+// everything is lost when the process exits.
+type InMemoryStore struct {
+	mu            sync.RWMutex
+	conversations map[primitive.ObjectID]*Conversation
+	personas      map[string]*Persona
+	memories      map[primitive.ObjectID]*Memory
+}
+
+// NewInMemoryStore creates an empty in-memory store.
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{
+		conversations: make(map[primitive.ObjectID]*Conversation),
+		personas:      make(map[string]*Persona),
+		memories:      make(map[primitive.ObjectID]*Memory),
+	}
+}
+
+func (s *InMemoryStore) CreateConversation(ctx context.Context, c *Conversation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.conversations[c.ID]; exists {
+		return twirp.NewError(twirp.AlreadyExists, "conversation already exists")
+	}
+
+	clone, err := clone(c)
+	if err != nil {
+		return err
+	}
+
+	s.conversations[c.ID] = clone
+	return nil
+}
+
+func (s *InMemoryStore) DescribeConversation(ctx context.Context, ownerID, id string) (*Conversation, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, twirp.NotFoundError("invalid conversation ID")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, exists := s.conversations[oid]
+	if !exists || !c.visibleTo(ownerID) {
+		return nil, twirp.NotFoundError("conversation not found")
+	}
+
+	return clone(c)
+}
+
+func (s *InMemoryStore) ListConversations(ctx context.Context, ownerID string, q ListQuery) (*ConversationPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []*Conversation
+	for _, c := range s.conversations {
+		if c.visibleTo(ownerID) {
+			items = append(items, c)
+		}
+	}
+
+	page, err := q.page(items)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, c := range page.Conversations {
+		summary := *c
+		summary.Messages = nil
+
+		if page.Conversations[i], err = clone(&summary); err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+func (s *InMemoryStore) UpdateConversation(ctx context.Context, c *Conversation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, exists := s.conversations[c.ID]; !exists || !existing.visibleTo(c.OwnerID) {
+		return twirp.NotFoundError("conversation not found")
+	}
+
+	clone, err := clone(c)
+	if err != nil {
+		return err
+	}
+
+	s.conversations[c.ID] = clone
+	return nil
+}
+
+func (s *InMemoryStore) DeleteConversation(ctx context.Context, ownerID, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return twirp.NotFoundError("invalid conversation ID")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, exists := s.conversations[oid]
+	if !exists || !c.visibleTo(ownerID) {
+		return twirp.NotFoundError("conversation not found")
+	}
+
+	now := time.Now()
+	c.DeletedAt = &now
+	return nil
+}
+
+func (s *InMemoryStore) PurgeConversations(ctx context.Context, cutoff time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	purged := 0
+	for id, c := range s.conversations {
+		if c.DeletedAt != nil && !c.DeletedAt.After(cutoff) {
+			delete(s.conversations, id)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+func (s *InMemoryStore) CreatePersona(ctx context.Context, p *Persona) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.personas[p.key()]; exists {
+		return twirp.NewError(twirp.AlreadyExists, "persona already exists")
+	}
+
+	clone, err := clone(p)
+	if err != nil {
+		return err
+	}
+
+	s.personas[p.key()] = clone
+	return nil
+}
+
+func (s *InMemoryStore) DescribePersona(ctx context.Context, ownerID, id string) (*Persona, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, exists := s.personas[personaKey(ownerID, id)]
+	if !exists {
+		return nil, twirp.NotFoundError("persona not found")
+	}
+
+	return clone(p)
+}
+
+func (s *InMemoryStore) ListPersonas(ctx context.Context, ownerID string) ([]*Persona, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var personas []*Persona
+	for _, p := range s.personas {
+		if p.OwnerID != ownerID {
+			continue
+		}
+
+		clone, err := clone(p)
+		if err != nil {
+			return nil, err
+		}
+		personas = append(personas, clone)
+	}
+
+	slices.SortFunc(personas, func(a, b *Persona) int { return strings.Compare(a.ID, b.ID) })
+	return personas, nil
+}
+
+func (s *InMemoryStore) UpdatePersona(ctx context.Context, p *Persona) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.personas[p.key()]; !exists {
+		return twirp.NotFoundError("persona not found")
+	}
+
+	clone, err := clone(p)
+	if err != nil {
+		return err
+	}
+
+	s.personas[p.key()] = clone
+	return nil
+}
+
+func (s *InMemoryStore) DeletePersona(ctx context.Context, ownerID, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := personaKey(ownerID, id)
+	if _, exists := s.personas[key]; !exists {
+		return twirp.NotFoundError("persona not found")
+	}
+
+	delete(s.personas, key)
+	return nil
+}
+
+func (s *InMemoryStore) CreateMemory(ctx context.Context, m *Memory) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clone, err := clone(m)
+	if err != nil {
+		return err
+	}
+
+	s.memories[m.ID] = clone
+	return nil
+}
+
+func (s *InMemoryStore) ListMemories(ctx context.Context, ownerID string) ([]*Memory, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var memories []*Memory
+	for _, m := range s.memories {
+		if m.OwnerID != ownerID {
+			continue
+		}
+
+		clone, err := clone(m)
+		if err != nil {
+			return nil, err
+		}
+		memories = append(memories, clone)
+	}
+
+	sortMemories(memories)
+	return memories, nil
+}
+
+func (s *InMemoryStore) DeleteMemory(ctx context.Context, ownerID, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return twirp.NotFoundError("invalid memory ID")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if m, exists := s.memories[oid]; !exists || m.OwnerID != ownerID {
+		return twirp.NotFoundError("memory not found")
+	}
+
+	delete(s.memories, oid)
+	return nil
+}
+
+// clone deep-copies v through its BSON representation, so stored values never share memory with callers and
+// behave exactly like documents round-tripped through a database.
+func clone[T any](v *T) (*T, error) {
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var out T
+	if err := bson.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
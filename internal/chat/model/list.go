@@ -0,0 +1,125 @@
+package model
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ListQuery selects a page of conversations. Zero values mean no limit and no filter.
+type ListQuery struct {
+	PageSize        int
+	PageToken       string // NextPageToken of the previous page.
+	UpdatedAfter    time.Time
+	UpdatedBefore   time.Time
+	OldestFirst     bool
+	IncludeArchived bool
+}
+
+// ConversationPage is a page of conversations, listed without their messages.
+type ConversationPage struct {
+	Conversations []*Conversation
+	NextPageToken string // Empty on the last page.
+}
+
+// cursor is the position after the last conversation of a page. Conversations are ordered by creation time,
+// with ties broken by ID, so the position stays stable while conversations are added or updated.
+type cursor struct {
+	CreatedAt time.Time          `json:"t"`
+	ID        primitive.ObjectID `json:"id"`
+}
+
+func encodeCursor(c *Conversation) string {
+	data, _ := json.Marshal(cursor{CreatedAt: c.CreatedAt, ID: c.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses a page token, returning nil for the first page.
+func decodeCursor(token string) (*cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, twirp.InvalidArgumentError("page_token", "is not valid")
+	}
+
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID.IsZero() {
+		return nil, twirp.InvalidArgumentError("page_token", "is not valid")
+	}
+
+	return &c, nil
+}
+
+// compare orders conversations the way q lists them.
+func (q ListQuery) compare(a, b *Conversation) int {
+	c := a.CreatedAt.Compare(b.CreatedAt)
+	if c == 0 {
+		c = bytes.Compare(a.ID[:], b.ID[:])
+	}
+
+	if q.OldestFirst {
+		return c
+	}
+	return -c
+}
+
+// matches reports whether c passes the archive and update time filters.
+func (q ListQuery) matches(c *Conversation) bool {
+	if c.Archived && !q.IncludeArchived {
+		return false
+	}
+
+	if !q.UpdatedAfter.IsZero() && !c.UpdatedAt.After(q.UpdatedAfter) {
+		return false
+	}
+
+	if !q.UpdatedBefore.IsZero() && !c.UpdatedAt.Before(q.UpdatedBefore) {
+		return false
+	}
+
+	return true
+}
+
+// page applies the query to conversations held in memory, for the stores that cannot query their documents.
+// The returned conversations are the given ones, callers copy them as needed.
+func (q ListQuery) page(items []*Conversation) (*ConversationPage, error) {
+	after, err := decodeCursor(q.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	items = slices.DeleteFunc(items, func(c *Conversation) bool {
+		return !q.matches(c)
+	})
+	slices.SortFunc(items, q.compare)
+
+	if after != nil {
+		last := &Conversation{ID: after.ID, CreatedAt: after.CreatedAt}
+		i, _ := slices.BinarySearchFunc(items, last, q.compare)
+		for i < len(items) && q.compare(items[i], last) <= 0 {
+			i++
+		}
+		items = items[i:]
+	}
+
+	return q.limit(items), nil
+}
+
+// limit cuts the ordered conversations following the page token down to a page.
+func (q ListQuery) limit(items []*Conversation) *ConversationPage {
+	page := &ConversationPage{Conversations: items}
+	if q.PageSize > 0 && len(items) > q.PageSize {
+		page.Conversations = items[:q.PageSize]
+		page.NextPageToken = encodeCursor(page.Conversations[q.PageSize-1])
+	}
+
+	return page
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Memory is a fact about a user the assistant remembers across their conversations, e.g. their home city or
+// dietary needs. Like conversations, memories belong to an owner and are only ever shown to them.
+type Memory struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	OwnerID        string             `bson:"owner_id"`
+	Content        string             `bson:"content"`
+	ConversationID string             `bson:"conversation_id,omitempty"` // The conversation it was made in, if any.
+	CreatedAt      time.Time          `bson:"created_at"`
+}
+
+func (m *Memory) Proto() *pb.Memory {
+	return &pb.Memory{
+		Id:             m.ID.Hex(),
+		Content:        m.Content,
+		ConversationId: m.ConversationID,
+		CreatedAt:      timestamppb.New(m.CreatedAt),
+	}
+}
+
+// key identifies the memory among the memories of every owner.
+func (m *Memory) key() string {
+	return memoryKey(m.OwnerID, m.ID.Hex())
+}
+
+func memoryKey(ownerID, id string) string {
+	return ownerID + "/" + id
+}
+
+// sortMemories sorts memories oldest first, by ID for the ones created at the same time.
+func sortMemories(memories []*Memory) {
+	slices.SortFunc(memories, func(a, b *Memory) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), bytes.Compare(a.ID[:], b.ID[:]))
+	})
+}
@@ -5,22 +5,81 @@ import (
 
 	"github.com/acai-travel/tech-challenge/internal/pb"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Message struct {
 	ID        primitive.ObjectID `bson:"_id"`
+	ParentID  primitive.ObjectID `bson:"parent_id,omitempty"` // Zero for the first message.
 	Role      Role               `bson:"role"`
 	Content   string             `bson:"content"`
+	ToolCall  *ToolCall          `bson:"tool_call,omitempty"` // Set on tool messages, whose content is the result.
+	Citations []Citation         `bson:"citations,omitempty"` // Passages of documents the answer draws on.
 	CreatedAt time.Time          `bson:"created_at"`
 	UpdatedAt time.Time          `bson:"updated_at"`
 }
 
+// ToolCall is a tool invocation made by the assistant while replying.
+type ToolCall struct {
+	ID        string        `bson:"id"`
+	Name      string        `bson:"name"`
+	Arguments string        `bson:"arguments"`
+	Error     string        `bson:"error,omitempty"`
+	Duration  time.Duration `bson:"duration"`
+}
+
+// Citation is a passage of an uploaded document an answer draws on, referred to as [Number] in its content.
+type Citation struct {
+	Number       int    `bson:"number" json:"number"`
+	DocumentID   string `bson:"document_id" json:"document_id"`
+	DocumentName string `bson:"document_name" json:"document_name"`
+	Chunk        int    `bson:"chunk" json:"chunk"`
+	Excerpt      string `bson:"excerpt" json:"excerpt"`
+}
+
+func (c *Citation) Proto() *pb.Citation {
+	return &pb.Citation{
+		Number:       int32(c.Number),
+		DocumentId:   c.DocumentID,
+		DocumentName: c.DocumentName,
+		Chunk:        int32(c.Chunk),
+		Excerpt:      c.Excerpt,
+	}
+}
+
+// CitationsProto returns the citations as protos.
+func CitationsProto(citations []Citation) []*pb.Citation {
+	var protos []*pb.Citation
+	for i := range citations {
+		protos = append(protos, citations[i].Proto())
+	}
+	return protos
+}
+
 func (m *Message) Proto() *pb.Conversation_Message {
-	return &pb.Conversation_Message{
+	proto := &pb.Conversation_Message{
 		Id:        m.ID.Hex(),
 		Role:      m.Role.Proto(),
 		Content:   m.Content,
 		Timestamp: timestamppb.New(m.CreatedAt),
 	}
+
+	if !m.ParentID.IsZero() {
+		proto.ParentId = m.ParentID.Hex()
+	}
+
+	if c := m.ToolCall; c != nil {
+		proto.ToolCall = &pb.Conversation_ToolCall{
+			Id:        c.ID,
+			Name:      c.Name,
+			Arguments: c.Arguments,
+			Error:     c.Error,
+			Duration:  durationpb.New(c.Duration),
+		}
+	}
+
+	proto.Citations = CitationsProto(m.Citations)
+
+	return proto
 }
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Persona is a named set of assistant settings conversations can be started with. Conversations keep a copy of
+// the persona they were started with, so later changes to it only apply to new conversations.
+type Persona struct {
+	ID           string        `bson:"id"` // Chosen by the owner, unique among their personas.
+	OwnerID      string        `bson:"owner_id"`
+	Name         string        `bson:"name"`
+	SystemPrompt string        `bson:"system_prompt"`
+	Model        string        `bson:"model,omitempty"`       // Empty for the assistant's default reply model.
+	Temperature  *float64      `bson:"temperature,omitempty"` // Nil for the model's default temperature.
+	Tools        ToolSelection `bson:"tools"`
+	CreatedAt    time.Time     `bson:"created_at"`
+	UpdatedAt    time.Time     `bson:"updated_at"`
+}
+
+// key identifies the persona among the personas of every owner. Persona IDs cannot contain a slash.
+func (p *Persona) key() string {
+	return personaKey(p.OwnerID, p.ID)
+}
+
+func personaKey(ownerID, id string) string {
+	return ownerID + "/" + id
+}
+
+func (p *Persona) Proto() *pb.Persona {
+	return &pb.Persona{
+		Id:            p.ID,
+		Name:          p.Name,
+		SystemPrompt:  p.SystemPrompt,
+		Model:         p.Model,
+		Temperature:   p.Temperature,
+		Tools:         p.Tools.Enabled,
+		DisabledTools: p.Tools.Disabled,
+		CreatedAt:     timestamppb.New(p.CreatedAt),
+		UpdatedAt:     timestamppb.New(p.UpdatedAt),
+	}
+}
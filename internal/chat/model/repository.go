@@ -3,6 +3,7 @@ package model
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/twitchtv/twirp"
 	"go.mongodb.org/mongo-driver/bson"
@@ -13,8 +14,11 @@ import (
 
 const (
 	conversationCollection = "conversations"
+	personaCollection      = "personas"
+	memoryCollection       = "memories"
 )
 
+// Repository is the MongoDB-backed Store.
 type Repository struct {
 	conn *mongo.Database
 }
@@ -30,7 +34,7 @@ func (r *Repository) CreateConversation(ctx context.Context, c *Conversation) er
 	return err
 }
 
-func (r *Repository) DescribeConversation(ctx context.Context, id string) (*Conversation, error) {
+func (r *Repository) DescribeConversation(ctx context.Context, ownerID, id string) (*Conversation, error) {
 	var c Conversation
 
 	oid, err := primitive.ObjectIDFromHex(id)
@@ -38,7 +42,7 @@ func (r *Repository) DescribeConversation(ctx context.Context, id string) (*Conv
 		return nil, twirp.NotFoundError("invalid conversation ID")
 	}
 
-	err = r.conn.Collection(conversationCollection).FindOne(ctx, map[string]any{"_id": oid}).Decode(&c)
+	err = r.conn.Collection(conversationCollection).FindOne(ctx, ownedBy(ownerID, bson.M{"_id": oid})).Decode(&c)
 	if errors.Is(err, mongo.ErrNoDocuments) {
 		return nil, twirp.NotFoundError("conversation not found")
 	}
@@ -50,13 +54,51 @@ func (r *Repository) DescribeConversation(ctx context.Context, id string) (*Conv
 	return &c, nil
 }
 
-func (r *Repository) ListConversations(ctx context.Context) ([]*Conversation, error) {
+func (r *Repository) ListConversations(ctx context.Context, ownerID string, q ListQuery) (*ConversationPage, error) {
+	after, err := decodeCursor(q.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := ownedBy(ownerID, bson.M{})
+
+	updated := bson.M{}
+	if !q.UpdatedAfter.IsZero() {
+		updated["$gt"] = q.UpdatedAfter
+	}
+	if !q.UpdatedBefore.IsZero() {
+		updated["$lt"] = q.UpdatedBefore
+	}
+	if len(updated) > 0 {
+		filter["updated_at"] = updated
+	}
+
+	if !q.IncludeArchived {
+		filter["archived"] = bson.M{"$ne": true}
+	}
+
+	direction, op := -1, "$lt"
+	if q.OldestFirst {
+		direction, op = 1, "$gt"
+	}
+
+	if after != nil {
+		filter["$or"] = bson.A{
+			bson.M{"created_at": bson.M{op: after.CreatedAt}},
+			bson.M{"created_at": after.CreatedAt, "_id": bson.M{op: after.ID}},
+		}
+	}
+
 	opts := options.Find().
-		SetSort(bson.D{{Key: "created_at", Value: -1}})
+		SetSort(bson.D{{Key: "created_at", Value: direction}, {Key: "_id", Value: direction}}).
+		SetProjection(bson.M{"messages": 0})
 
-	cursor, err := r.conn.Collection(conversationCollection).
-		Find(ctx, map[string]any{}, opts)
+	if q.PageSize > 0 {
+		// Fetch one extra conversation to know whether there is a next page.
+		opts.SetLimit(int64(q.PageSize) + 1)
+	}
 
+	cursor, err := r.conn.Collection(conversationCollection).Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -81,26 +123,199 @@ func (r *Repository) ListConversations(ctx context.Context) ([]*Conversation, er
 		return nil, err
 	}
 
-	return items, nil
+	return q.limit(items), nil
 }
 
 func (r *Repository) UpdateConversation(ctx context.Context, c *Conversation) error {
-	_, err := r.conn.Collection(conversationCollection).UpdateOne(ctx,
-		map[string]any{"_id": c.ID},
+	res, err := r.conn.Collection(conversationCollection).UpdateOne(ctx,
+		ownedBy(c.OwnerID, bson.M{"_id": c.ID}),
 		map[string]any{"$set": c})
 
-	if errors.Is(err, mongo.ErrNoDocuments) {
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("conversation not found")
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteConversation(ctx context.Context, ownerID, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return twirp.NotFoundError("invalid conversation ID")
+	}
+
+	res, err := r.conn.Collection(conversationCollection).UpdateOne(ctx,
+		ownedBy(ownerID, bson.M{"_id": oid}),
+		bson.M{"$set": bson.M{"deleted_at": time.Now()}})
+
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
 		return twirp.NotFoundError("conversation not found")
 	}
 
+	return nil
+}
+
+func (r *Repository) PurgeConversations(ctx context.Context, cutoff time.Time) (int, error) {
+	res, err := r.conn.Collection(conversationCollection).DeleteMany(ctx, bson.M{"deleted_at": bson.M{"$lte": cutoff}})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(res.DeletedCount), nil
+}
+
+// personaDocument is how personas are stored in MongoDB, identified by their owner and ID.
+type personaDocument struct {
+	Key     string `bson:"_id"`
+	Persona `bson:",inline"`
+}
+
+func (r *Repository) CreatePersona(ctx context.Context, p *Persona) error {
+	_, err := r.conn.Collection(personaCollection).InsertOne(ctx, personaDocument{Key: p.key(), Persona: *p})
+	if mongo.IsDuplicateKeyError(err) {
+		return twirp.NewError(twirp.AlreadyExists, "persona already exists")
+	}
+
 	return err
 }
 
-func (r *Repository) DeleteConversation(ctx context.Context, id string) error {
-	_, err := r.conn.Collection(conversationCollection).DeleteOne(ctx, map[string]any{"_id": id})
+func (r *Repository) DescribePersona(ctx context.Context, ownerID, id string) (*Persona, error) {
+	var doc personaDocument
+
+	err := r.conn.Collection(personaCollection).FindOne(ctx, bson.M{"_id": personaKey(ownerID, id)}).Decode(&doc)
 	if errors.Is(err, mongo.ErrNoDocuments) {
-		return twirp.NotFoundError("conversation not found")
+		return nil, twirp.NotFoundError("persona not found")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &doc.Persona, nil
+}
+
+func (r *Repository) ListPersonas(ctx context.Context, ownerID string) ([]*Persona, error) {
+	cursor, err := r.conn.Collection(personaCollection).Find(ctx, bson.M{"owner_id": ownerID},
+		options.Find().SetSort(bson.D{{Key: "id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = cursor.Close(ctx)
+	}()
+
+	var personas []*Persona
+
+	for cursor.Next(ctx) {
+		var doc personaDocument
+
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+
+		personas = append(personas, &doc.Persona)
+	}
+
+	return personas, cursor.Err()
+}
+
+func (r *Repository) UpdatePersona(ctx context.Context, p *Persona) error {
+	res, err := r.conn.Collection(personaCollection).ReplaceOne(ctx, bson.M{"_id": p.key()},
+		personaDocument{Key: p.key(), Persona: *p})
+
+	if err != nil {
+		return err
 	}
 
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("persona not found")
+	}
+
+	return nil
+}
+
+func (r *Repository) DeletePersona(ctx context.Context, ownerID, id string) error {
+	res, err := r.conn.Collection(personaCollection).DeleteOne(ctx, bson.M{"_id": personaKey(ownerID, id)})
+	if err != nil {
+		return err
+	}
+
+	if res.DeletedCount == 0 {
+		return twirp.NotFoundError("persona not found")
+	}
+
+	return nil
+}
+
+func (r *Repository) CreateMemory(ctx context.Context, m *Memory) error {
+	_, err := r.conn.Collection(memoryCollection).InsertOne(ctx, m)
 	return err
 }
+
+func (r *Repository) ListMemories(ctx context.Context, ownerID string) ([]*Memory, error) {
+	// ObjectIDs grow with time, sorting by ID keeps memories created in the same millisecond in order too.
+	cursor, err := r.conn.Collection(memoryCollection).Find(ctx, bson.M{"owner_id": ownerID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = cursor.Close(ctx)
+	}()
+
+	var memories []*Memory
+
+	for cursor.Next(ctx) {
+		var m Memory
+
+		if err := cursor.Decode(&m); err != nil {
+			return nil, err
+		}
+
+		memories = append(memories, &m)
+	}
+
+	return memories, cursor.Err()
+}
+
+func (r *Repository) DeleteMemory(ctx context.Context, ownerID, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return twirp.NotFoundError("invalid memory ID")
+	}
+
+	res, err := r.conn.Collection(memoryCollection).DeleteOne(ctx, bson.M{"_id": oid, "owner_id": ownerID})
+	if err != nil {
+		return err
+	}
+
+	if res.DeletedCount == 0 {
+		return twirp.NotFoundError("memory not found")
+	}
+
+	return nil
+}
+
+// ownedBy scopes the filter to the owner's documents that are not deleted. Documents created before
+// conversations had owners belong to the anonymous owner.
+func ownedBy(ownerID string, filter bson.M) bson.M {
+	filter["deleted_at"] = nil
+
+	if ownerID == "" {
+		filter["owner_id"] = bson.M{"$in": bson.A{nil, ""}}
+	} else {
+		filter["owner_id"] = ownerID
+	}
+
+	return filter
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// ConversationStore persists conversations. Every query is scoped to an owner: conversations of other owners,
+// and deleted ones, are reported missing, with a twirp.NotFound error the server can return to clients as-is.
+// An empty owner ID is the anonymous owner used when authentication is disabled.
+type ConversationStore interface {
+	CreateConversation(ctx context.Context, c *Conversation) error
+	DescribeConversation(ctx context.Context, ownerID, id string) (*Conversation, error)
+	ListConversations(ctx context.Context, ownerID string, q ListQuery) (*ConversationPage, error)
+	// UpdateConversation replaces the conversation, as long as it belongs to c.OwnerID.
+	UpdateConversation(ctx context.Context, c *Conversation) error
+	// DeleteConversation soft-deletes the conversation: it is reported missing from then on, and kept until
+	// PurgeConversations removes it for good.
+	DeleteConversation(ctx context.Context, ownerID, id string) error
+	// PurgeConversations permanently removes the conversations of every owner deleted at or before cutoff, and
+	// returns how many were removed.
+	PurgeConversations(ctx context.Context, cutoff time.Time) (int, error)
+}
+
+// PersonaStore persists personas. Like conversations, personas are scoped to an owner, and the ones of other
+// owners are reported missing with a twirp.NotFound error.
+type PersonaStore interface {
+	// CreatePersona fails with a twirp.AlreadyExists error if the owner has a persona with the same ID.
+	CreatePersona(ctx context.Context, p *Persona) error
+	DescribePersona(ctx context.Context, ownerID, id string) (*Persona, error)
+	// ListPersonas returns all the owner's personas, sorted by ID.
+	ListPersonas(ctx context.Context, ownerID string) ([]*Persona, error)
+	// UpdatePersona replaces the persona with the same owner and ID.
+	UpdatePersona(ctx context.Context, p *Persona) error
+	DeletePersona(ctx context.Context, ownerID, id string) error
+}
+
+// MemoryStore persists what the assistant remembers about users. Memories are scoped to an owner, and the ones
+// of other owners are reported missing with a twirp.NotFound error.
+type MemoryStore interface {
+	CreateMemory(ctx context.Context, m *Memory) error
+	// ListMemories returns all the owner's memories, oldest first.
+	ListMemories(ctx context.Context, ownerID string) ([]*Memory, error)
+	DeleteMemory(ctx context.Context, ownerID, id string) error
+}
+
+// Store persists everything the chat service keeps.
+type Store interface {
+	ConversationStore
+	PersonaStore
+	MemoryStore
+}
+
+var (
+	_ Store = (*Repository)(nil)
+	_ Store = (*InMemoryStore)(nil)
+	_ Store = (*BoltStore)(nil)
+)
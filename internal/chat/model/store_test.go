@@ -0,0 +1,537 @@
+package model_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/google/go-cmp/cmp"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// stores returns every Store implementation the contract is checked against. MongoDB is only
+// included when TEST_STORAGE_BACKEND=mongo, since it needs a running database.
+func stores(t *testing.T) map[string]model.Store {
+	bolt, err := model.OpenBoltStore(filepath.Join(t.TempDir(), "acai.db"))
+	if err != nil {
+		t.Fatalf("failed to open bolt store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = bolt.Close()
+	})
+
+	all := map[string]model.Store{
+		"memory": model.NewInMemoryStore(),
+		"bolt":   bolt,
+	}
+
+	if os.Getenv("TEST_STORAGE_BACKEND") == "mongo" {
+		all["mongo"] = model.New(ConnectMongo())
+	}
+
+	// Tests clean up by deleting their conversations, which only hides them. Cleanups run last in first out,
+	// so this runs after all of them.
+	for _, store := range all {
+		t.Cleanup(func() { _, _ = store.PurgeConversations(context.Background(), time.Now()) })
+	}
+
+	return all
+}
+
+func newConversation(createdAt time.Time) *model.Conversation {
+	return &model.Conversation{
+		ID:        primitive.NewObjectID(),
+		Title:     "Weather in Barcelona",
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+		Messages: []*model.Message{{
+			ID:        primitive.NewObjectID(),
+			Role:      model.RoleUser,
+			Content:   "What is the weather like in Barcelona?",
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}},
+	}
+}
+
+func isNotFound(err error) bool {
+	te, ok := err.(twirp.Error)
+	return ok && te.Code() == twirp.NotFound
+}
+
+func TestConversationStore(t *testing.T) {
+	ctx := context.Background()
+
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			t.Run("create and describe", func(t *testing.T) {
+				c := newConversation(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+				if err := store.CreateConversation(ctx, c); err != nil {
+					t.Fatalf("CreateConversation() error: %v", err)
+				}
+				t.Cleanup(func() { _ = store.DeleteConversation(ctx, "", c.ID.Hex()) })
+
+				got, err := store.DescribeConversation(ctx, "", c.ID.Hex())
+				if err != nil {
+					t.Fatalf("DescribeConversation() error: %v", err)
+				}
+
+				if diff := cmp.Diff(c, got); diff != "" {
+					t.Errorf("DescribeConversation() mismatch (-want +got):\n%s", diff)
+				}
+			})
+
+			t.Run("describe missing or invalid ID is not found", func(t *testing.T) {
+				for _, id := range []string{primitive.NewObjectID().Hex(), "not-an-id"} {
+					if _, err := store.DescribeConversation(ctx, "", id); !isNotFound(err) {
+						t.Errorf("DescribeConversation(%q) expected not found, got %v", id, err)
+					}
+				}
+			})
+
+			t.Run("update replaces the conversation", func(t *testing.T) {
+				c := newConversation(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+				if err := store.CreateConversation(ctx, c); err != nil {
+					t.Fatalf("CreateConversation() error: %v", err)
+				}
+				t.Cleanup(func() { _ = store.DeleteConversation(ctx, "", c.ID.Hex()) })
+
+				c.Title = "Renamed"
+				c.Messages = append(c.Messages, &model.Message{
+					ID:        primitive.NewObjectID(),
+					Role:      model.RoleAssistant,
+					Content:   "Sunny.",
+					CreatedAt: c.CreatedAt,
+					UpdatedAt: c.CreatedAt,
+				})
+
+				if err := store.UpdateConversation(ctx, c); err != nil {
+					t.Fatalf("UpdateConversation() error: %v", err)
+				}
+
+				got, err := store.DescribeConversation(ctx, "", c.ID.Hex())
+				if err != nil {
+					t.Fatalf("DescribeConversation() error: %v", err)
+				}
+
+				if diff := cmp.Diff(c, got); diff != "" {
+					t.Errorf("DescribeConversation() after update mismatch (-want +got):\n%s", diff)
+				}
+			})
+
+			t.Run("update missing conversation is not found", func(t *testing.T) {
+				if err := store.UpdateConversation(ctx, newConversation(time.Now().UTC())); !isNotFound(err) {
+					t.Errorf("UpdateConversation() expected not found, got %v", err)
+				}
+			})
+
+			t.Run("list returns newest first without messages", func(t *testing.T) {
+				older := newConversation(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+				newer := newConversation(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC))
+
+				for _, c := range []*model.Conversation{older, newer} {
+					if err := store.CreateConversation(ctx, c); err != nil {
+						t.Fatalf("CreateConversation() error: %v", err)
+					}
+					t.Cleanup(func() { _ = store.DeleteConversation(ctx, "", c.ID.Hex()) })
+				}
+
+				page, err := store.ListConversations(ctx, "", model.ListQuery{})
+				if err != nil {
+					t.Fatalf("ListConversations() error: %v", err)
+				}
+
+				var got []primitive.ObjectID
+				for _, c := range page.Conversations {
+					if c.ID == older.ID || c.ID == newer.ID {
+						got = append(got, c.ID)
+					}
+					if len(c.Messages) != 0 {
+						t.Errorf("ListConversations() returned %d messages for %s, expected none", len(c.Messages), c.ID.Hex())
+					}
+				}
+
+				if diff := cmp.Diff([]primitive.ObjectID{newer.ID, older.ID}, got); diff != "" {
+					t.Errorf("ListConversations() order mismatch (-want +got):\n%s", diff)
+				}
+			})
+
+			t.Run("list pages, filters and sorts", func(t *testing.T) {
+				base := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+
+				// Five conversations a day apart, the last two created at the same time to exercise the ID
+				// tie-break, each updated an hour after creation.
+				var all []*model.Conversation
+				for i := range 5 {
+					c := newConversation(base.AddDate(0, 0, min(i, 3)))
+					c.OwnerID = "pager"
+					c.UpdatedAt = c.CreatedAt.Add(time.Hour)
+					if err := store.CreateConversation(ctx, c); err != nil {
+						t.Fatalf("CreateConversation() error: %v", err)
+					}
+					t.Cleanup(func() { _ = store.DeleteConversation(ctx, "pager", c.ID.Hex()) })
+					all = append(all, c)
+				}
+
+				// listAll follows page tokens until the last page and returns the listed IDs.
+				listAll := func(t *testing.T, q model.ListQuery) []primitive.ObjectID {
+					var ids []primitive.ObjectID
+					for range 10 {
+						page, err := store.ListConversations(ctx, "pager", q)
+						if err != nil {
+							t.Fatalf("ListConversations() error: %v", err)
+						}
+						if q.PageSize > 0 && len(page.Conversations) > q.PageSize {
+							t.Fatalf("ListConversations() returned %d conversations, page size is %d", len(page.Conversations), q.PageSize)
+						}
+						for _, c := range page.Conversations {
+							ids = append(ids, c.ID)
+						}
+						if page.NextPageToken == "" {
+							return ids
+						}
+						q.PageToken = page.NextPageToken
+					}
+					t.Fatal("ListConversations() did not reach the last page")
+					return nil
+				}
+
+				ids := func(cs ...*model.Conversation) []primitive.ObjectID {
+					var out []primitive.ObjectID
+					for _, c := range cs {
+						out = append(out, c.ID)
+					}
+					return out
+				}
+
+				// ObjectIDs increase, so the later of the two conversations created at the same time is newer.
+				tests := []struct {
+					name string
+					q    model.ListQuery
+					want []primitive.ObjectID
+				}{
+					{name: "newest first", q: model.ListQuery{PageSize: 2}, want: ids(all[4], all[3], all[2], all[1], all[0])},
+					{name: "oldest first", q: model.ListQuery{PageSize: 2, OldestFirst: true}, want: ids(all...)},
+					{name: "single page", q: model.ListQuery{PageSize: 5}, want: ids(all[4], all[3], all[2], all[1], all[0])},
+					{
+						name: "updated after",
+						q:    model.ListQuery{PageSize: 1, UpdatedAfter: base.AddDate(0, 0, 1).Add(time.Hour)},
+						want: ids(all[4], all[3], all[2]),
+					},
+					{
+						name: "updated between",
+						q:    model.ListQuery{PageSize: 1, UpdatedAfter: base, UpdatedBefore: base.AddDate(0, 0, 2), OldestFirst: true},
+						want: ids(all[0], all[1]),
+					},
+				}
+
+				for _, tt := range tests {
+					t.Run(tt.name, func(t *testing.T) {
+						if diff := cmp.Diff(tt.want, listAll(t, tt.q)); diff != "" {
+							t.Errorf("ListConversations() mismatch (-want +got):\n%s", diff)
+						}
+					})
+				}
+
+				t.Run("invalid page token", func(t *testing.T) {
+					_, err := store.ListConversations(ctx, "pager", model.ListQuery{PageToken: "not a token"})
+					if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+						t.Errorf("ListConversations() expected invalid argument, got %v", err)
+					}
+				})
+			})
+
+			t.Run("queries are scoped to the owner", func(t *testing.T) {
+				alice := newConversation(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+				alice.OwnerID = "alice"
+				if err := store.CreateConversation(ctx, alice); err != nil {
+					t.Fatalf("CreateConversation() error: %v", err)
+				}
+				t.Cleanup(func() { _ = store.DeleteConversation(ctx, "alice", alice.ID.Hex()) })
+
+				if _, err := store.DescribeConversation(ctx, "bob", alice.ID.Hex()); !isNotFound(err) {
+					t.Errorf("DescribeConversation() by another owner expected not found, got %v", err)
+				}
+
+				if _, err := store.DescribeConversation(ctx, "", alice.ID.Hex()); !isNotFound(err) {
+					t.Errorf("DescribeConversation() by anonymous owner expected not found, got %v", err)
+				}
+
+				page, err := store.ListConversations(ctx, "bob", model.ListQuery{})
+				if err != nil {
+					t.Fatalf("ListConversations() error: %v", err)
+				}
+				for _, c := range page.Conversations {
+					if c.ID == alice.ID {
+						t.Errorf("ListConversations() returned a conversation of another owner")
+					}
+				}
+
+				stolen := *alice
+				stolen.OwnerID = "bob"
+				stolen.Title = "Stolen"
+				if err := store.UpdateConversation(ctx, &stolen); !isNotFound(err) {
+					t.Errorf("UpdateConversation() by another owner expected not found, got %v", err)
+				}
+
+				if err := store.DeleteConversation(ctx, "bob", alice.ID.Hex()); !isNotFound(err) {
+					t.Errorf("DeleteConversation() by another owner expected not found, got %v", err)
+				}
+
+				got, err := store.DescribeConversation(ctx, "alice", alice.ID.Hex())
+				if err != nil {
+					t.Fatalf("DescribeConversation() by owner error: %v", err)
+				}
+				if got.Title != alice.Title {
+					t.Errorf("expected title %q to be untouched, got %q", alice.Title, got.Title)
+				}
+			})
+
+			t.Run("delete hides the conversation until purged", func(t *testing.T) {
+				c := newConversation(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+				c.OwnerID = "deleter"
+				if err := store.CreateConversation(ctx, c); err != nil {
+					t.Fatalf("CreateConversation() error: %v", err)
+				}
+
+				if err := store.DeleteConversation(ctx, "deleter", c.ID.Hex()); err != nil {
+					t.Fatalf("DeleteConversation() error: %v", err)
+				}
+
+				if _, err := store.DescribeConversation(ctx, "deleter", c.ID.Hex()); !isNotFound(err) {
+					t.Errorf("DescribeConversation() after delete expected not found, got %v", err)
+				}
+
+				page, err := store.ListConversations(ctx, "deleter", model.ListQuery{IncludeArchived: true})
+				if err != nil {
+					t.Fatalf("ListConversations() error: %v", err)
+				}
+				if len(page.Conversations) != 0 {
+					t.Errorf("ListConversations() after delete returned %d conversations", len(page.Conversations))
+				}
+
+				if err := store.UpdateConversation(ctx, c); !isNotFound(err) {
+					t.Errorf("UpdateConversation() after delete expected not found, got %v", err)
+				}
+
+				if err := store.DeleteConversation(ctx, "deleter", c.ID.Hex()); !isNotFound(err) {
+					t.Errorf("DeleteConversation() twice expected not found, got %v", err)
+				}
+
+				if n, err := store.PurgeConversations(ctx, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil || n != 0 {
+					t.Errorf("PurgeConversations() before the deletion purged %d conversations (%v), expected none", n, err)
+				}
+
+				if n, err := store.PurgeConversations(ctx, time.Now()); err != nil || n < 1 {
+					t.Errorf("PurgeConversations() after the deletion purged %d conversations (%v), expected it", n, err)
+				}
+			})
+
+			t.Run("archived conversations are only listed on request", func(t *testing.T) {
+				c := newConversation(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+				c.OwnerID = "archiver"
+				c.Archived = true
+				if err := store.CreateConversation(ctx, c); err != nil {
+					t.Fatalf("CreateConversation() error: %v", err)
+				}
+				t.Cleanup(func() { _ = store.DeleteConversation(ctx, "archiver", c.ID.Hex()) })
+
+				for _, include := range []bool{false, true} {
+					page, err := store.ListConversations(ctx, "archiver", model.ListQuery{IncludeArchived: include})
+					if err != nil {
+						t.Fatalf("ListConversations() error: %v", err)
+					}
+
+					if listed := len(page.Conversations) == 1; listed != include {
+						t.Errorf("ListConversations(IncludeArchived: %t) listed the archived conversation: %t", include, listed)
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestPersonaStore(t *testing.T) {
+	ctx := context.Background()
+	temperature := 0.3
+
+	newPersona := func(ownerID, id string) *model.Persona {
+		return &model.Persona{
+			ID:           id,
+			OwnerID:      ownerID,
+			Name:         "Travel agent",
+			SystemPrompt: "You are a travel agent.",
+			Model:        "gpt-4o",
+			Temperature:  &temperature,
+			Tools:        model.ToolSelection{Enabled: []string{"get_weather", "get_holidays"}, Disabled: []string{"get_holidays"}},
+			CreatedAt:    time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+			UpdatedAt:    time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		}
+	}
+
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			create := func(t *testing.T, p *model.Persona) {
+				t.Helper()
+				if err := store.CreatePersona(ctx, p); err != nil {
+					t.Fatalf("CreatePersona() error: %v", err)
+				}
+				t.Cleanup(func() { _ = store.DeletePersona(ctx, p.OwnerID, p.ID) })
+			}
+
+			t.Run("create, describe, update and delete", func(t *testing.T) {
+				p := newPersona("alice", "travel-agent")
+				create(t, p)
+
+				got, err := store.DescribePersona(ctx, "alice", "travel-agent")
+				if err != nil {
+					t.Fatalf("DescribePersona() error: %v", err)
+				}
+				if diff := cmp.Diff(p, got); diff != "" {
+					t.Errorf("DescribePersona() mismatch (-want +got):\n%s", diff)
+				}
+
+				if err := store.CreatePersona(ctx, newPersona("alice", "travel-agent")); err == nil {
+					t.Errorf("CreatePersona() with a taken ID expected an error")
+				}
+
+				p.SystemPrompt = "You are a terse travel agent."
+				p.Temperature = nil
+				p.Tools = model.ToolSelection{}
+				if err := store.UpdatePersona(ctx, p); err != nil {
+					t.Fatalf("UpdatePersona() error: %v", err)
+				}
+
+				got, err = store.DescribePersona(ctx, "alice", "travel-agent")
+				if err != nil {
+					t.Fatalf("DescribePersona() after update error: %v", err)
+				}
+				if diff := cmp.Diff(p, got); diff != "" {
+					t.Errorf("DescribePersona() after update mismatch (-want +got):\n%s", diff)
+				}
+
+				if err := store.DeletePersona(ctx, "alice", "travel-agent"); err != nil {
+					t.Fatalf("DeletePersona() error: %v", err)
+				}
+
+				if _, err := store.DescribePersona(ctx, "alice", "travel-agent"); !isNotFound(err) {
+					t.Errorf("DescribePersona() after delete expected not found, got %v", err)
+				}
+
+				if err := store.UpdatePersona(ctx, p); !isNotFound(err) {
+					t.Errorf("UpdatePersona() after delete expected not found, got %v", err)
+				}
+			})
+
+			t.Run("personas are scoped to the owner and listed by ID", func(t *testing.T) {
+				create(t, newPersona("carol", "zoologist"))
+				create(t, newPersona("carol", "chef"))
+				create(t, newPersona("carol/x", "chef"))
+				create(t, newPersona("dave", "chef"))
+
+				personas, err := store.ListPersonas(ctx, "carol")
+				if err != nil {
+					t.Fatalf("ListPersonas() error: %v", err)
+				}
+
+				var ids []string
+				for _, p := range personas {
+					if p.OwnerID != "carol" {
+						t.Errorf("ListPersonas() returned a persona of %q", p.OwnerID)
+					}
+					ids = append(ids, p.ID)
+				}
+				if diff := cmp.Diff([]string{"chef", "zoologist"}, ids); diff != "" {
+					t.Errorf("ListPersonas() IDs mismatch (-want +got):\n%s", diff)
+				}
+
+				if _, err := store.DescribePersona(ctx, "erin", "chef"); !isNotFound(err) {
+					t.Errorf("DescribePersona() by another owner expected not found, got %v", err)
+				}
+
+				if err := store.DeletePersona(ctx, "erin", "chef"); !isNotFound(err) {
+					t.Errorf("DeletePersona() by another owner expected not found, got %v", err)
+				}
+			})
+		})
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+
+	newMemory := func(ownerID, content string, createdAt time.Time) *model.Memory {
+		return &model.Memory{
+			ID:             primitive.NewObjectID(),
+			OwnerID:        ownerID,
+			Content:        content,
+			ConversationID: primitive.NewObjectID().Hex(),
+			CreatedAt:      createdAt,
+		}
+	}
+
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			create := func(t *testing.T, m *model.Memory) {
+				t.Helper()
+				if err := store.CreateMemory(ctx, m); err != nil {
+					t.Fatalf("CreateMemory() error: %v", err)
+				}
+				t.Cleanup(func() { _ = store.DeleteMemory(ctx, m.OwnerID, m.ID.Hex()) })
+			}
+
+			day := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+			vegetarian := newMemory("alice", "Is vegetarian", day.Add(time.Hour))
+			home := newMemory("alice", "Lives in Barcelona", day)
+			create(t, vegetarian)
+			create(t, home)
+			create(t, newMemory("alice/x", "Prefers aisle seats", day))
+			create(t, newMemory("bob", "Lives in Lisbon", day))
+
+			t.Run("memories are scoped to the owner and listed oldest first", func(t *testing.T) {
+				got, err := store.ListMemories(ctx, "alice")
+				if err != nil {
+					t.Fatalf("ListMemories() error: %v", err)
+				}
+				if diff := cmp.Diff([]*model.Memory{home, vegetarian}, got); diff != "" {
+					t.Errorf("ListMemories() mismatch (-want +got):\n%s", diff)
+				}
+
+				if got, err := store.ListMemories(ctx, "carol"); err != nil || len(got) != 0 {
+					t.Errorf("ListMemories() of an owner without memories = %v, %v", got, err)
+				}
+			})
+
+			t.Run("delete", func(t *testing.T) {
+				if err := store.DeleteMemory(ctx, "bob", home.ID.Hex()); !isNotFound(err) {
+					t.Errorf("DeleteMemory() by another owner expected not found, got %v", err)
+				}
+
+				if err := store.DeleteMemory(ctx, "alice", home.ID.Hex()); err != nil {
+					t.Fatalf("DeleteMemory() error: %v", err)
+				}
+
+				if err := store.DeleteMemory(ctx, "alice", home.ID.Hex()); !isNotFound(err) {
+					t.Errorf("DeleteMemory() twice expected not found, got %v", err)
+				}
+
+				if err := store.DeleteMemory(ctx, "alice", "not-an-id"); !isNotFound(err) {
+					t.Errorf("DeleteMemory() with an invalid ID expected not found, got %v", err)
+				}
+
+				got, err := store.ListMemories(ctx, "alice")
+				if err != nil {
+					t.Fatalf("ListMemories() error: %v", err)
+				}
+				if diff := cmp.Diff([]*model.Memory{vegetarian}, got); diff != "" {
+					t.Errorf("ListMemories() after delete mismatch (-want +got):\n%s", diff)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package model
+
+import (
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Conversations are trees of messages: each message points to the one it follows, and editing a message or
+// regenerating a reply adds a sibling, starting a new branch. ActiveLeafID selects the branch being shown and
+// continued, the active path is the chain of messages leading to it.
+//
+// Conversations stored before messages had parents have no active leaf, their messages are a single path in
+// slice order.
+
+// Fork lists the alternative branches at a point of the active path.
+type Fork struct {
+	ParentID primitive.ObjectID // Zero for alternatives of the first message.
+	Branches []*Message         // First message of each branch, oldest first.
+	ActiveID primitive.ObjectID // Branch on the active path.
+}
+
+// ActivePath returns the messages from the first one to the active leaf.
+func (c *Conversation) ActivePath() []*Message {
+	if c.ActiveLeafID.IsZero() {
+		return c.Messages
+	}
+
+	byID := make(map[primitive.ObjectID]*Message, len(c.Messages))
+	for _, m := range c.Messages {
+		byID[m.ID] = m
+	}
+
+	var path []*Message
+	for m := byID[c.ActiveLeafID]; m != nil; m = byID[m.ParentID] {
+		path = append(path, m)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
+
+// Message returns the message with the given ID, or nil if there is none.
+func (c *Conversation) Message(id primitive.ObjectID) *Message {
+	for _, m := range c.Messages {
+		if m.ID == id {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// Append adds the message at the end of the active path.
+func (c *Conversation) Append(m *Message) {
+	c.link()
+	c.branch(c.ActiveLeafID, m)
+}
+
+// Alternative adds the message as another version of the message with the given ID, starting a new branch that
+// becomes active. It reports whether the message exists.
+func (c *Conversation) Alternative(id primitive.ObjectID, m *Message) bool {
+	c.link()
+
+	original := c.Message(id)
+	if original == nil {
+		return false
+	}
+
+	c.branch(original.ParentID, m)
+	return true
+}
+
+// branch adds the message as a child of parentID, zero for a first message, and makes it the active leaf.
+func (c *Conversation) branch(parentID primitive.ObjectID, m *Message) {
+	m.ParentID = parentID
+	c.Messages = append(c.Messages, m)
+	c.ActiveLeafID = m.ID
+}
+
+// Select makes the branch through the message active, following the most recent reply at every step down to a
+// leaf. It reports whether the message exists.
+func (c *Conversation) Select(id primitive.ObjectID) bool {
+	c.link()
+
+	if c.Message(id) == nil {
+		return false
+	}
+
+	for next := id; !next.IsZero(); {
+		c.ActiveLeafID, next = next, primitive.NilObjectID
+		for _, m := range c.Messages {
+			if m.ParentID == c.ActiveLeafID {
+				next = m.ID // Messages are in creation order, the last child is the most recent.
+			}
+		}
+	}
+
+	return true
+}
+
+// Forks returns the alternative branches along the active path, from the first message down.
+func (c *Conversation) Forks() []Fork {
+	if c.ActiveLeafID.IsZero() {
+		return nil
+	}
+
+	var forks []Fork
+	for _, m := range c.ActivePath() {
+		var siblings []*Message
+		for _, s := range c.Messages {
+			if s.ParentID == m.ParentID {
+				siblings = append(siblings, s)
+			}
+		}
+
+		if len(siblings) > 1 {
+			forks = append(forks, Fork{ParentID: m.ParentID, Branches: siblings, ActiveID: m.ID})
+		}
+	}
+
+	return forks
+}
+
+// link turns the messages of a conversation stored before messages had parents into a single path.
+func (c *Conversation) link() {
+	if !c.ActiveLeafID.IsZero() || len(c.Messages) == 0 {
+		return
+	}
+
+	for i, m := range c.Messages[1:] {
+		m.ParentID = c.Messages[i].ID
+	}
+	c.ActiveLeafID = c.Messages[len(c.Messages)-1].ID
+}
+
+func (f Fork) Proto() *pb.Conversation_Fork {
+	proto := &pb.Conversation_Fork{ActiveMessageId: f.ActiveID.Hex()}
+	if !f.ParentID.IsZero() {
+		proto.ParentId = f.ParentID.Hex()
+	}
+
+	for _, m := range f.Branches {
+		proto.Branches = append(proto.Branches, m.Proto())
+	}
+
+	return proto
+}
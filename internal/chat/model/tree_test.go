@@ -0,0 +1,105 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/google/go-cmp/cmp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func message(role model.Role, content string) *model.Message {
+	return &model.Message{ID: primitive.NewObjectID(), Role: role, Content: content}
+}
+
+func contents(messages []*model.Message) []string {
+	var out []string
+	for _, m := range messages {
+		out = append(out, m.Content)
+	}
+	return out
+}
+
+func TestConversation_Branches(t *testing.T) {
+	t.Run("conversations without an active leaf are a single path", func(t *testing.T) {
+		c := &model.Conversation{Messages: []*model.Message{
+			message(model.RoleUser, "Hi"),
+			message(model.RoleAssistant, "Hello"),
+		}}
+
+		if diff := cmp.Diff([]string{"Hi", "Hello"}, contents(c.ActivePath())); diff != "" {
+			t.Errorf("ActivePath() mismatch (-want +got):\n%s", diff)
+		}
+
+		c.Append(message(model.RoleUser, "Weather?"))
+
+		if diff := cmp.Diff([]string{"Hi", "Hello", "Weather?"}, contents(c.ActivePath())); diff != "" {
+			t.Errorf("ActivePath() after Append mismatch (-want +got):\n%s", diff)
+		}
+
+		if c.Messages[1].ParentID != c.Messages[0].ID || c.Messages[2].ParentID != c.Messages[1].ID {
+			t.Errorf("expected Append to link the existing messages into a path")
+		}
+	})
+
+	t.Run("alternatives start new branches", func(t *testing.T) {
+		c := &model.Conversation{}
+		question := message(model.RoleUser, "Weather in Paris?")
+		c.Append(question)
+		c.Append(message(model.RoleAssistant, "Rainy."))
+		c.Append(message(model.RoleUser, "And tomorrow?"))
+
+		edited := message(model.RoleUser, "Weather in Rome?")
+		if !c.Alternative(question.ID, edited) {
+			t.Fatal("Alternative() reported a missing message")
+		}
+		reply := message(model.RoleAssistant, "Sunny.")
+		c.Append(reply)
+
+		if diff := cmp.Diff([]string{"Weather in Rome?", "Sunny."}, contents(c.ActivePath())); diff != "" {
+			t.Errorf("ActivePath() mismatch (-want +got):\n%s", diff)
+		}
+
+		regenerated := message(model.RoleAssistant, "Sunny and warm.")
+		c.Alternative(reply.ID, regenerated)
+
+		forks := c.Forks()
+		if len(forks) != 2 {
+			t.Fatalf("expected forks at the first message and at the reply, got %d", len(forks))
+		}
+
+		if got := forks[0]; !got.ParentID.IsZero() || got.ActiveID != edited.ID || len(got.Branches) != 2 || got.Branches[0] != question {
+			t.Errorf("unexpected fork of the first message: %+v", got)
+		}
+
+		if got := forks[1]; got.ParentID != edited.ID || got.ActiveID != regenerated.ID || len(got.Branches) != 2 {
+			t.Errorf("unexpected fork of the reply: %+v", got)
+		}
+
+		if c.Alternative(primitive.NewObjectID(), message(model.RoleUser, "?")) {
+			t.Error("Alternative() of a missing message reported success")
+		}
+	})
+
+	t.Run("select follows the most recent replies", func(t *testing.T) {
+		c := &model.Conversation{}
+		question := message(model.RoleUser, "Weather in Paris?")
+		c.Append(question)
+		c.Append(message(model.RoleAssistant, "Rainy."))
+		c.Append(message(model.RoleUser, "And tomorrow?"))
+		c.Append(message(model.RoleAssistant, "Cloudy."))
+		c.Alternative(question.ID, message(model.RoleUser, "Weather in Rome?"))
+
+		if !c.Select(question.ID) {
+			t.Fatal("Select() reported a missing message")
+		}
+
+		if diff := cmp.Diff([]string{"Weather in Paris?", "Rainy.", "And tomorrow?", "Cloudy."}, contents(c.ActivePath())); diff != "" {
+			t.Errorf("ActivePath() after Select mismatch (-want +got):\n%s", diff)
+		}
+
+		if c.Select(primitive.NewObjectID()) {
+			t.Error("Select() of a missing message reported success")
+		}
+	})
+}
@@ -0,0 +1,164 @@
+package chat
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+)
+
+// personaID matches valid persona IDs, lowercase words separated by dashes, e.g. "travel-agent".
+var personaID = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+const maxPersonaIDLength = 64
+
+func (s *Server) CreatePersona(ctx context.Context, req *pb.CreatePersonaRequest) (*pb.CreatePersonaResponse, error) {
+	persona, err := personaFromProto(ctx, req.GetPersona())
+	if err != nil {
+		return nil, err
+	}
+
+	persona.CreatedAt = now()
+	persona.UpdatedAt = persona.CreatedAt
+
+	if err := s.repo.CreatePersona(ctx, persona); err != nil {
+		return nil, err
+	}
+
+	return &pb.CreatePersonaResponse{Persona: persona.Proto()}, nil
+}
+
+func (s *Server) DescribePersona(ctx context.Context, req *pb.DescribePersonaRequest) (*pb.DescribePersonaResponse, error) {
+	if req.GetPersonaId() == "" {
+		return nil, twirp.RequiredArgumentError("persona_id")
+	}
+
+	persona, err := s.repo.DescribePersona(ctx, auth.UserID(ctx), req.GetPersonaId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DescribePersonaResponse{Persona: persona.Proto()}, nil
+}
+
+func (s *Server) ListPersonas(ctx context.Context, req *pb.ListPersonasRequest) (*pb.ListPersonasResponse, error) {
+	personas, err := s.repo.ListPersonas(ctx, auth.UserID(ctx))
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	resp := &pb.ListPersonasResponse{}
+	for _, p := range personas {
+		resp.Personas = append(resp.Personas, p.Proto())
+	}
+
+	return resp, nil
+}
+
+func (s *Server) UpdatePersona(ctx context.Context, req *pb.UpdatePersonaRequest) (*pb.UpdatePersonaResponse, error) {
+	persona, err := personaFromProto(ctx, req.GetPersona())
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.DescribePersona(ctx, persona.OwnerID, persona.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	persona.CreatedAt = existing.CreatedAt
+	persona.UpdatedAt = now()
+
+	if err := s.repo.UpdatePersona(ctx, persona); err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdatePersonaResponse{Persona: persona.Proto()}, nil
+}
+
+func (s *Server) DeletePersona(ctx context.Context, req *pb.DeletePersonaRequest) (*pb.DeletePersonaResponse, error) {
+	if req.GetPersonaId() == "" {
+		return nil, twirp.RequiredArgumentError("persona_id")
+	}
+
+	if err := s.repo.DeletePersona(ctx, auth.UserID(ctx), req.GetPersonaId()); err != nil {
+		return nil, err
+	}
+
+	return &pb.DeletePersonaResponse{}, nil
+}
+
+// now returns the current time at the millisecond precision of stored times, so the personas returned by the
+// RPCs are the ones later described.
+func now() time.Time {
+	return time.Now().Truncate(time.Millisecond)
+}
+
+// personaFromProto validates the persona settings sent by the caller, and returns the caller's persona with
+// them. The name defaults to the ID.
+func personaFromProto(ctx context.Context, p *pb.Persona) (*model.Persona, error) {
+	switch {
+	case p == nil:
+		return nil, twirp.RequiredArgumentError("persona")
+	case p.GetId() == "":
+		return nil, twirp.RequiredArgumentError("persona.id")
+	case len(p.GetId()) > maxPersonaIDLength || !personaID.MatchString(p.GetId()):
+		return nil, twirp.InvalidArgumentError("persona.id", "must be lowercase letters, digits and dashes, e.g. travel-agent")
+	case strings.TrimSpace(p.GetSystemPrompt()) == "":
+		return nil, twirp.RequiredArgumentError("persona.system_prompt")
+	case p.Temperature != nil && (p.GetTemperature() < 0 || p.GetTemperature() > 2):
+		return nil, twirp.InvalidArgumentError("persona.temperature", "must be between 0 and 2")
+	}
+
+	persona := &model.Persona{
+		ID:           p.GetId(),
+		OwnerID:      auth.UserID(ctx),
+		Name:         strings.TrimSpace(p.GetName()),
+		SystemPrompt: strings.TrimSpace(p.GetSystemPrompt()),
+		Model:        strings.TrimSpace(p.GetModel()),
+		Temperature:  p.Temperature,
+	}
+
+	if persona.Name == "" {
+		persona.Name = persona.ID
+	}
+
+	tools, err := toolSelection("persona.", p.GetTools(), p.GetDisabledTools())
+	if err != nil {
+		return nil, err
+	}
+	persona.Tools = tools
+
+	return persona, nil
+}
+
+// toolSelection validates the tool names of a request, set in its fields tools and disabled_tools, named after
+// the prefix in errors.
+func toolSelection(prefix string, enabled, disabled []string) (model.ToolSelection, error) {
+	var err error
+	var selection model.ToolSelection
+
+	if selection.Enabled, err = toolNames(prefix+"tools", enabled); err != nil {
+		return selection, err
+	}
+
+	selection.Disabled, err = toolNames(prefix+"disabled_tools", disabled)
+	return selection, err
+}
+
+func toolNames(field string, names []string) ([]string, error) {
+	var out []string
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name == "" {
+			return nil, twirp.InvalidArgumentError(field, "must not contain empty names")
+		}
+		out = append(out, name)
+	}
+
+	return out, nil
+}
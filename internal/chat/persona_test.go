@@ -0,0 +1,178 @@
+package chat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// createPersona creates the persona through the server, and deletes it when the test ends.
+func createPersona(t *testing.T, srv *Server, ctx context.Context, p *pb.Persona) *pb.Persona {
+	t.Helper()
+
+	resp, err := srv.CreatePersona(ctx, &pb.CreatePersonaRequest{Persona: p})
+	if err != nil {
+		t.Fatalf("failed to create persona: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_, _ = srv.DeletePersona(ctx, &pb.DeletePersonaRequest{PersonaId: p.GetId()})
+	})
+
+	return resp.GetPersona()
+}
+
+func TestServer_Personas(t *testing.T) {
+	ctx := auth.WithUser(context.Background(), "alice")
+
+	t.Run("creates, lists, updates and deletes personas", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, nil)
+
+		created := createPersona(t, srv, ctx, &pb.Persona{
+			Id:           "travel-agent",
+			SystemPrompt: "  You are a travel agent.  ",
+			Temperature:  proto.Float64(0.4),
+			Tools:        []string{"get_weather"},
+		})
+
+		if created.GetName() != "travel-agent" || created.GetSystemPrompt() != "You are a travel agent." || created.GetCreatedAt() == nil {
+			t.Errorf("unexpected created persona: %v", created)
+		}
+
+		createPersona(t, srv, ctx, &pb.Persona{Id: "chef", SystemPrompt: "You are a chef."})
+
+		list, err := srv.ListPersonas(ctx, &pb.ListPersonasRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var ids []string
+		for _, p := range list.GetPersonas() {
+			ids = append(ids, p.GetId())
+		}
+		if diff := cmp.Diff([]string{"chef", "travel-agent"}, ids); diff != "" {
+			t.Errorf("listed personas mismatch (-want +got):\n%s", diff)
+		}
+
+		updated, err := srv.UpdatePersona(ctx, &pb.UpdatePersonaRequest{Persona: &pb.Persona{
+			Id:           "travel-agent",
+			Name:         "Travel agent",
+			SystemPrompt: "You are a terse travel agent.",
+		}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if updated.GetPersona().Temperature != nil || len(updated.GetPersona().GetTools()) != 0 {
+			t.Errorf("expected the update to replace every setting, got %v", updated.GetPersona())
+		}
+
+		if !updated.GetPersona().GetCreatedAt().AsTime().Equal(created.GetCreatedAt().AsTime()) {
+			t.Errorf("expected the creation time to be kept")
+		}
+
+		described, err := srv.DescribePersona(ctx, &pb.DescribePersonaRequest{PersonaId: "travel-agent"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff(updated.GetPersona(), described.GetPersona(), protocmp.Transform()); diff != "" {
+			t.Errorf("described persona mismatch (-want +got):\n%s", diff)
+		}
+
+		if _, err := srv.DeletePersona(ctx, &pb.DeletePersonaRequest{PersonaId: "travel-agent"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err = srv.DescribePersona(ctx, &pb.DescribePersonaRequest{PersonaId: "travel-agent"})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.NotFound {
+			t.Errorf("expected deleted persona to be not found, got %v", err)
+		}
+	}))
+
+	t.Run("rejects invalid personas", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, nil)
+		createPersona(t, srv, ctx, &pb.Persona{Id: "chef", SystemPrompt: "You are a chef."})
+
+		tests := []struct {
+			name    string
+			persona *pb.Persona
+			code    twirp.ErrorCode
+		}{
+			{name: "missing persona", persona: nil, code: twirp.InvalidArgument},
+			{name: "missing ID", persona: &pb.Persona{SystemPrompt: "Hi."}, code: twirp.InvalidArgument},
+			{name: "invalid ID", persona: &pb.Persona{Id: "Travel Agent", SystemPrompt: "Hi."}, code: twirp.InvalidArgument},
+			{name: "missing system prompt", persona: &pb.Persona{Id: "quiet", SystemPrompt: " "}, code: twirp.InvalidArgument},
+			{name: "temperature out of range", persona: &pb.Persona{Id: "hot", SystemPrompt: "Hi.", Temperature: proto.Float64(3)}, code: twirp.InvalidArgument},
+			{name: "empty tool name", persona: &pb.Persona{Id: "tools", SystemPrompt: "Hi.", Tools: []string{""}}, code: twirp.InvalidArgument},
+			{name: "taken ID", persona: &pb.Persona{Id: "chef", SystemPrompt: "You are another chef."}, code: twirp.AlreadyExists},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := srv.CreatePersona(ctx, &pb.CreatePersonaRequest{Persona: tt.persona})
+				if te, ok := err.(twirp.Error); !ok || te.Code() != tt.code {
+					t.Errorf("expected twirp %s error, got %v", tt.code, err)
+				}
+			})
+		}
+	}))
+
+	t.Run("conversations keep the persona they were started with", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, &MockAssistant{})
+		createPersona(t, srv, ctx, &pb.Persona{Id: "travel-agent", SystemPrompt: "You are a travel agent."})
+
+		resp, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Where should I go?", PersonaId: "travel-agent"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err = srv.UpdatePersona(ctx, &pb.UpdatePersonaRequest{Persona: &pb.Persona{Id: "travel-agent", SystemPrompt: "You are a pirate."}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		conv, err := f.Store.DescribeConversation(ctx, "alice", resp.GetConversationId())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if conv.Persona == nil || conv.Persona.SystemPrompt != "You are a travel agent." {
+			t.Errorf("expected the persona's settings at the start of the conversation, got %+v", conv.Persona)
+		}
+
+		described, err := srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: resp.GetConversationId()})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := described.GetConversation().GetPersonaId(); got != "travel-agent" {
+			t.Errorf("expected persona_id %q, got %q", "travel-agent", got)
+		}
+	}))
+
+	t.Run("starting a conversation with a missing persona is not found", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, &MockAssistant{})
+
+		_, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Hi!", PersonaId: "nobody"})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.NotFound {
+			t.Errorf("expected twirp not found error, got %v", err)
+		}
+
+		page, err := f.Store.ListConversations(ctx, "alice", model.ListQuery{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(page.Conversations) != 0 {
+			t.Errorf("expected no conversation to be created, got %d", len(page.Conversations))
+		}
+	}))
+}
@@ -0,0 +1,32 @@
+package chat
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+)
+
+// PurgeDeleted permanently removes conversations deleted more than retention ago, checking every interval until
+// the context is done. Deleted conversations are kept for a while, so they can still be recovered from the
+// database by hand.
+func PurgeDeleted(ctx context.Context, store model.ConversationStore, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := store.PurgeConversations(ctx, time.Now().Add(-retention))
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to purge deleted conversations", "error", err)
+		} else if purged > 0 {
+			slog.InfoContext(ctx, "Purged deleted conversations", "count", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -3,10 +3,13 @@ package chat
 import (
 	"context"
 	"log/slog"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/acai-travel/tech-challenge/internal/auth"
 	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/documents"
 	"github.com/acai-travel/tech-challenge/internal/pb"
 	"github.com/twitchtv/twirp"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -18,53 +21,108 @@ var _ pb.ChatService = (*Server)(nil)
 
 type Assistant interface {
 	Title(ctx context.Context, conv *model.Conversation) (string, error)
-	Reply(ctx context.Context, conv *model.Conversation) (string, error)
+	// Reply answers the last message of the conversation's active path. It returns the messages to append to
+	// it, the tools called on the way followed by the answer.
+	Reply(ctx context.Context, conv *model.Conversation) ([]*model.Message, error)
+}
+
+// StreamingAssistant is an Assistant that can report its reply while it is being generated.
+type StreamingAssistant interface {
+	Assistant
+	ReplyStream(ctx context.Context, conv *model.Conversation, onEvent func(model.Event)) ([]*model.Message, error)
 }
 
 type Server struct {
-	repo   *model.Repository
-	assist Assistant
+	repo      model.Store
+	assist    Assistant
+	documents *documents.Library // Nil when uploads are disabled.
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithDocuments enables document uploads, stored in library.
+func WithDocuments(library *documents.Library) Option {
+	return func(s *Server) {
+		s.documents = library
+	}
 }
 
-func NewServer(repo *model.Repository, assist Assistant) *Server {
-	return &Server{repo: repo, assist: assist}
+func NewServer(repo model.Store, assist Assistant, opts ...Option) *Server {
+	s := &Server{repo: repo, assist: assist}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 func (s *Server) StartConversation(ctx context.Context, req *pb.StartConversationRequest) (*pb.StartConversationResponse, error) {
+	conversation, answer, err := s.startConversation(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.StartConversationResponse{
+		ConversationId: conversation.ID.Hex(),
+		Title:          conversation.Title,
+		Reply:          answer.Content,
+		Citations:      model.CitationsProto(answer.Citations),
+	}, nil
+}
+
+// startConversation creates a conversation from the first user message, and generates its title and the
+// assistant's reply. Progress is reported to onEvent, if set.
+func (s *Server) startConversation(ctx context.Context, req *pb.StartConversationRequest, onEvent func(model.Event)) (*model.Conversation, *model.Message, error) {
 	tracer := otel.Tracer("chat-service")
 	ctx, span := tracer.Start(ctx, "StartConversation")
 	defer span.End()
 
+	message, personaID := req.GetMessage(), req.GetPersonaId()
+
 	span.SetAttributes(
-		attribute.Int("message.length", len(req.GetMessage())),
+		attribute.Int("message.length", len(message)),
+		attribute.String("persona.id", personaID),
 	)
 
 	conversation := &model.Conversation{
 		ID:        primitive.NewObjectID(),
+		OwnerID:   auth.UserID(ctx),
 		Title:     "Untitled conversation",
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
-		Messages: []*model.Message{{
-			ID:        primitive.NewObjectID(),
-			Role:      model.RoleUser,
-			Content:   req.GetMessage(),
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-		}},
 	}
+	conversation.Append(newMessage(model.RoleUser, message))
+
+	if strings.TrimSpace(message) == "" {
+		return nil, nil, twirp.RequiredArgumentError("message")
+	}
+
+	tools, err := toolSelection("", req.GetTools(), req.GetDisabledTools())
+	if err != nil {
+		return nil, nil, err
+	}
+	conversation.ToolSelection = tools
 
-	if strings.TrimSpace(req.GetMessage()) == "" {
-		return nil, twirp.RequiredArgumentError("message")
+	// The persona's settings are copied, so the conversation keeps them if the persona changes.
+	if personaID != "" {
+		persona, err := s.repo.DescribePersona(ctx, conversation.OwnerID, personaID)
+		if err != nil {
+			return nil, nil, err
+		}
+		conversation.Persona = persona
 	}
 
 	// Save conversation early with placeholder title.
 	if err := s.repo.CreateConversation(ctx, conversation); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	emit(onEvent, model.Event{Type: model.EventStarted, ConversationID: conversation.ID.Hex()})
+
 	// Run title and reply generation in parallel with timeouts.
 	titleChan := make(chan string, 1)
-	replyChan := make(chan string, 1)
+	replyChan := make(chan []*model.Message, 1)
 	errorChan := make(chan error, 2)
 
 	// Generate title concurrently with timeout.
@@ -74,7 +132,7 @@ func (s *Server) StartConversation(ctx context.Context, req *pb.StartConversatio
 		title, err := s.assist.Title(titleCtx, conversation)
 		if err != nil {
 			slog.ErrorContext(ctx, "Failed to generate conversation title", "error", err)
-			title = s.generateFallbackTitle(req.GetMessage())
+			title = s.generateFallbackTitle(message)
 		}
 		titleChan <- title
 	}()
@@ -83,7 +141,7 @@ func (s *Server) StartConversation(ctx context.Context, req *pb.StartConversatio
 	go func() {
 		replyCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 		defer cancel()
-		reply, err := s.assist.Reply(replyCtx, conversation)
+		reply, err := s.reply(replyCtx, conversation, onEvent)
 		if err != nil {
 			errorChan <- err
 			return
@@ -92,12 +150,12 @@ func (s *Server) StartConversation(ctx context.Context, req *pb.StartConversatio
 	}()
 
 	// Wait for reply (critical path).
-	var reply string
+	var messages []*model.Message
 	select {
-	case reply = <-replyChan:
+	case messages = <-replyChan:
 	case err := <-errorChan:
 		span.RecordError(err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Get title (may still be generating).
@@ -105,17 +163,11 @@ func (s *Server) StartConversation(ctx context.Context, req *pb.StartConversatio
 
 	// Update conversation with reply and final title.
 	conversation.Title = title
-	conversation.Messages = append(conversation.Messages, &model.Message{
-		ID:        primitive.NewObjectID(),
-		Role:      model.RoleAssistant,
-		Content:   reply,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	})
+	answer := appendReply(conversation, messages)
 
 	if err := s.repo.UpdateConversation(ctx, conversation); err != nil {
 		span.RecordError(err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	span.SetAttributes(
@@ -123,11 +175,41 @@ func (s *Server) StartConversation(ctx context.Context, req *pb.StartConversatio
 		attribute.String("conversation.title", conversation.Title),
 	)
 
-	return &pb.StartConversationResponse{
-		ConversationId: conversation.ID.Hex(),
-		Title:          conversation.Title,
-		Reply:          reply,
-	}, nil
+	return conversation, answer, nil
+}
+
+// reply asks the assistant to answer the conversation, streaming the answer when onEvent is set and the
+// assistant supports it.
+func (s *Server) reply(ctx context.Context, conv *model.Conversation, onEvent func(model.Event)) ([]*model.Message, error) {
+	if sa, ok := s.assist.(StreamingAssistant); ok && onEvent != nil {
+		return sa.ReplyStream(ctx, conv, onEvent)
+	}
+
+	return s.assist.Reply(ctx, conv)
+}
+
+// appendReply adds the assistant's messages at the end of the active path, and returns its answer, an empty
+// one if there are no messages.
+func appendReply(conv *model.Conversation, messages []*model.Message) *model.Message {
+	for _, m := range messages {
+		conv.Append(m)
+	}
+
+	if len(messages) == 0 {
+		return &model.Message{Role: model.RoleAssistant}
+	}
+	return messages[len(messages)-1]
+}
+
+// newMessage creates a message sent now.
+func newMessage(role model.Role, content string) *model.Message {
+	return &model.Message{
+		ID:        primitive.NewObjectID(),
+		Role:      role,
+		Content:   content,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
 }
 
 // generateFallbackTitle creates a simple title from the user message.
@@ -135,7 +217,7 @@ func (s *Server) generateFallbackTitle(message string) string {
 	// Clean and truncate the message.
 	title := strings.TrimSpace(message)
 	title = strings.ReplaceAll(title, "\n", " ")
-	
+
 	// Remove question words and common prefixes.
 	prefixes := []string{"what is", "what's", "how do", "how to", "can you", "please", "tell me"}
 	for _, prefix := range prefixes {
@@ -144,75 +226,107 @@ func (s *Server) generateFallbackTitle(message string) string {
 			break
 		}
 	}
-	
+
 	// Remove question marks and extra punctuation.
 	title = strings.Trim(title, "?!.,:;")
-	
+
 	// Limit to 50 characters.
 	if len(title) > 50 {
 		title = title[:50] + "..."
 	}
-	
+
 	// Fallback if title is empty or too short.
 	if len(strings.TrimSpace(title)) < 3 {
 		return "New conversation"
 	}
-	
+
 	return title
 }
 
 func (s *Server) ContinueConversation(ctx context.Context, req *pb.ContinueConversationRequest) (*pb.ContinueConversationResponse, error) {
-	if req.GetConversationId() == "" {
-		return nil, twirp.RequiredArgumentError("conversation_id")
+	_, answer, err := s.continueConversation(ctx, req.GetConversationId(), req.GetMessage(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ContinueConversationResponse{Reply: answer.Content, Citations: model.CitationsProto(answer.Citations)}, nil
+}
+
+// continueConversation appends a user message to an existing conversation and generates the assistant's
+// reply. Progress is reported to onEvent, if set.
+func (s *Server) continueConversation(ctx context.Context, id, message string, onEvent func(model.Event)) (*model.Conversation, *model.Message, error) {
+	if id == "" {
+		return nil, nil, twirp.RequiredArgumentError("conversation_id")
 	}
 
-	if strings.TrimSpace(req.GetMessage()) == "" {
-		return nil, twirp.RequiredArgumentError("message")
+	if strings.TrimSpace(message) == "" {
+		return nil, nil, twirp.RequiredArgumentError("message")
 	}
 
-	conversation, err := s.repo.DescribeConversation(ctx, req.GetConversationId())
+	conversation, err := s.repo.DescribeConversation(ctx, auth.UserID(ctx), id)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	emit(onEvent, model.Event{Type: model.EventStarted, ConversationID: conversation.ID.Hex(), Title: conversation.Title})
+
 	conversation.UpdatedAt = time.Now()
-	conversation.Messages = append(conversation.Messages, &model.Message{
-		ID:        primitive.NewObjectID(),
-		Role:      model.RoleUser,
-		Content:   req.GetMessage(),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	})
+	conversation.Append(newMessage(model.RoleUser, message))
 
-	reply, err := s.assist.Reply(ctx, conversation)
+	messages, err := s.reply(ctx, conversation, onEvent)
 	if err != nil {
-		return nil, twirp.InternalErrorWith(err)
+		return nil, nil, twirp.InternalErrorWith(err)
 	}
 
-	conversation.Messages = append(conversation.Messages, &model.Message{
-		ID:        primitive.NewObjectID(),
-		Role:      model.RoleAssistant,
-		Content:   reply,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	})
+	answer := appendReply(conversation, messages)
 
 	if err := s.repo.UpdateConversation(ctx, conversation); err != nil {
-		return nil, twirp.InternalErrorWith(err)
+		return nil, nil, twirp.InternalErrorWith(err)
 	}
 
-	return &pb.ContinueConversationResponse{Reply: reply}, nil
+	return conversation, answer, nil
 }
 
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
 func (s *Server) ListConversations(ctx context.Context, req *pb.ListConversationsRequest) (*pb.ListConversationsResponse, error) {
-	conversations, err := s.repo.ListConversations(ctx)
+	q := model.ListQuery{
+		PageSize:        int(req.GetPageSize()),
+		PageToken:       req.GetPageToken(),
+		OldestFirst:     req.GetOrder() == pb.ListConversationsRequest_OLDEST_FIRST,
+		IncludeArchived: req.GetIncludeArchived(),
+	}
+
+	switch {
+	case q.PageSize < 0:
+		return nil, twirp.InvalidArgumentError("page_size", "must not be negative")
+	case q.PageSize == 0:
+		q.PageSize = defaultPageSize
+	case q.PageSize > maxPageSize:
+		q.PageSize = maxPageSize
+	}
+
+	if req.UpdatedAfter != nil {
+		q.UpdatedAfter = req.GetUpdatedAfter().AsTime()
+	}
+
+	if req.UpdatedBefore != nil {
+		q.UpdatedBefore = req.GetUpdatedBefore().AsTime()
+	}
+
+	page, err := s.repo.ListConversations(ctx, auth.UserID(ctx), q)
 	if err != nil {
+		if _, ok := err.(twirp.Error); ok {
+			return nil, err
+		}
 		return nil, twirp.InternalErrorWith(err)
 	}
 
-	resp := &pb.ListConversationsResponse{}
-	for _, conv := range conversations {
-		conv.Messages = nil // Clear messages to avoid sending large data.
+	resp := &pb.ListConversationsResponse{NextPageToken: page.NextPageToken}
+	for _, conv := range page.Conversations {
 		resp.Conversations = append(resp.Conversations, conv.Proto())
 	}
 
@@ -224,7 +338,7 @@ func (s *Server) DescribeConversation(ctx context.Context, req *pb.DescribeConve
 		return nil, twirp.RequiredArgumentError("conversation_id")
 	}
 
-	conversation, err := s.repo.DescribeConversation(ctx, req.GetConversationId())
+	conversation, err := s.repo.DescribeConversation(ctx, auth.UserID(ctx), req.GetConversationId())
 	if err != nil {
 		return nil, err
 	}
@@ -235,3 +349,178 @@ func (s *Server) DescribeConversation(ctx context.Context, req *pb.DescribeConve
 
 	return &pb.DescribeConversationResponse{Conversation: conversation.Proto()}, nil
 }
+
+func (s *Server) UpdateConversation(ctx context.Context, req *pb.UpdateConversationRequest) (*pb.UpdateConversationResponse, error) {
+	if req.GetConversationId() == "" {
+		return nil, twirp.RequiredArgumentError("conversation_id")
+	}
+
+	if req.Title != nil && strings.TrimSpace(req.GetTitle()) == "" {
+		return nil, twirp.InvalidArgumentError("title", "must not be empty")
+	}
+
+	conversation, err := s.repo.DescribeConversation(ctx, auth.UserID(ctx), req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		conversation.Title = strings.TrimSpace(req.GetTitle())
+	}
+
+	if req.Archived != nil {
+		conversation.Archived = req.GetArchived()
+	}
+
+	if req.ActiveMessageId != nil {
+		id, err := primitive.ObjectIDFromHex(req.GetActiveMessageId())
+		if err != nil || !conversation.Select(id) {
+			return nil, twirp.NotFoundError("message not found")
+		}
+	}
+
+	conversation.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateConversation(ctx, conversation); err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateConversationResponse{Conversation: conversation.Proto()}, nil
+}
+
+func (s *Server) DeleteConversation(ctx context.Context, req *pb.DeleteConversationRequest) (*pb.DeleteConversationResponse, error) {
+	if req.GetConversationId() == "" {
+		return nil, twirp.RequiredArgumentError("conversation_id")
+	}
+
+	if err := s.repo.DeleteConversation(ctx, auth.UserID(ctx), req.GetConversationId()); err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteConversationResponse{}, nil
+}
+
+func (s *Server) EditMessage(ctx context.Context, req *pb.EditMessageRequest) (*pb.EditMessageResponse, error) {
+	if req.GetConversationId() == "" {
+		return nil, twirp.RequiredArgumentError("conversation_id")
+	}
+
+	if req.GetMessageId() == "" {
+		return nil, twirp.RequiredArgumentError("message_id")
+	}
+
+	if strings.TrimSpace(req.GetContent()) == "" {
+		return nil, twirp.RequiredArgumentError("content")
+	}
+
+	conversation, err := s.repo.DescribeConversation(ctx, auth.UserID(ctx), req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := findMessage(conversation, req.GetMessageId())
+	if err != nil {
+		return nil, err
+	}
+
+	if original.Role != model.RoleUser {
+		return nil, twirp.InvalidArgumentError("message_id", "must be a user message")
+	}
+
+	edited := newMessage(model.RoleUser, req.GetContent())
+	conversation.Alternative(original.ID, edited)
+
+	messages, err := s.reply(ctx, conversation, nil)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	answer := appendReply(conversation, messages)
+	conversation.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateConversation(ctx, conversation); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return &pb.EditMessageResponse{MessageId: edited.ID.Hex(), Reply: answer.Content, Citations: model.CitationsProto(answer.Citations)}, nil
+}
+
+func (s *Server) RegenerateReply(ctx context.Context, req *pb.RegenerateReplyRequest) (*pb.RegenerateReplyResponse, error) {
+	if req.GetConversationId() == "" {
+		return nil, twirp.RequiredArgumentError("conversation_id")
+	}
+
+	conversation, err := s.repo.DescribeConversation(ctx, auth.UserID(ctx), req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+
+	var original *model.Message
+	if req.GetMessageId() == "" {
+		if path := conversation.ActivePath(); len(path) > 0 {
+			original = path[len(path)-1]
+		}
+	} else if original, err = findMessage(conversation, req.GetMessageId()); err != nil {
+		return nil, err
+	}
+
+	if original == nil || original.Role != model.RoleAssistant {
+		return nil, twirp.InvalidArgumentError("message_id", "must be an assistant message")
+	}
+
+	// The reply being replaced starts after the user message, with the tools called on the way to the answer.
+	conversation.Select(original.ID)
+	path := conversation.ActivePath()
+	start := slices.IndexFunc(path, func(m *model.Message) bool { return m.ID == original.ID })
+	for start > 0 && path[start-1].Role == model.RoleTool {
+		start--
+	}
+
+	// The assistant answers the path up to the reply, as a conversation of its own.
+	history := &model.Conversation{
+		ID:            conversation.ID,
+		OwnerID:       conversation.OwnerID,
+		Title:         conversation.Title,
+		Messages:      path[:start],
+		Summary:       conversation.Summary,
+		Usage:         conversation.Usage,
+		Persona:       conversation.Persona,
+		ToolSelection: conversation.ToolSelection,
+	}
+
+	messages, err := s.reply(ctx, history, nil)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	if len(messages) == 0 {
+		return nil, twirp.InternalError("assistant returned no reply")
+	}
+
+	conversation.Summary, conversation.Usage, conversation.Tools = history.Summary, history.Usage, history.Tools
+	conversation.Alternative(path[start].ID, messages[0])
+	appendReply(conversation, messages[1:])
+	conversation.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateConversation(ctx, conversation); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	answer := messages[len(messages)-1]
+	return &pb.RegenerateReplyResponse{MessageId: answer.ID.Hex(), Reply: answer.Content, Citations: model.CitationsProto(answer.Citations)}, nil
+}
+
+// findMessage looks up a message of the conversation by its hex ID.
+func findMessage(conversation *model.Conversation, id string) (*model.Message, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, twirp.NotFoundError("invalid message ID")
+	}
+
+	m := conversation.Message(oid)
+	if m == nil {
+		return nil, twirp.NotFoundError("message not found")
+	}
+
+	return m, nil
+}
@@ -1,15 +1,24 @@
 package chat
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/acai-travel/tech-challenge/internal/auth"
 	"github.com/acai-travel/tech-challenge/internal/chat/model"
 	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
 	"github.com/acai-travel/tech-challenge/internal/pb"
 	"github.com/google/go-cmp/cmp"
 	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 )
 
@@ -31,14 +40,64 @@ func (m *MockAssistant) Title(ctx context.Context, conv *model.Conversation) (st
 	return "Test Title", nil
 }
 
-func (m *MockAssistant) Reply(ctx context.Context, conv *model.Conversation) (string, error) {
+func (m *MockAssistant) Reply(ctx context.Context, conv *model.Conversation) ([]*model.Message, error) {
 	if m.replyError != nil {
-		return "", m.replyError
+		return nil, m.replyError
 	}
 	if m.replyResponse != "" {
-		return m.replyResponse, nil
+		return []*model.Message{answer(m.replyResponse)}, nil
 	}
-	return "Test reply", nil
+	return []*model.Message{answer("Test reply")}, nil
+}
+
+func answer(content string) *model.Message {
+	return &model.Message{ID: primitive.NewObjectID(), Role: model.RoleAssistant, Content: content, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+}
+
+// EchoAssistant replies with the contents of the messages it sees, so tests can tell which branch it was given.
+type EchoAssistant struct {
+	MockAssistant
+}
+
+func (m *EchoAssistant) Reply(ctx context.Context, conv *model.Conversation) ([]*model.Message, error) {
+	var seen []string
+	for _, msg := range conv.ActivePath() {
+		seen = append(seen, msg.Content)
+	}
+	return []*model.Message{answer(strings.Join(seen, " / "))}, nil
+}
+
+// MockStreamingAssistant calls a weather tool, then streams its reply word by word.
+type MockStreamingAssistant struct {
+	MockAssistant
+}
+
+func (m *MockStreamingAssistant) ReplyStream(ctx context.Context, conv *model.Conversation, onEvent func(model.Event)) ([]*model.Message, error) {
+	messages, err := m.Reply(ctx, conv)
+	if err != nil {
+		return nil, err
+	}
+
+	call := &model.ToolCallEvent{ID: "call_1", Name: "get_weather", Arguments: `{"location":"Barcelona"}`, Result: "Sunny"}
+	onEvent(model.Event{Type: model.EventToolCallStarted, ToolCall: call})
+	onEvent(model.Event{Type: model.EventToolCallFinished, ToolCall: call})
+
+	tool := &model.Message{
+		ID:       primitive.NewObjectID(),
+		Role:     model.RoleTool,
+		Content:  call.Result,
+		ToolCall: &model.ToolCall{ID: call.ID, Name: call.Name, Arguments: call.Arguments, Duration: time.Millisecond},
+	}
+
+	reply := messages[len(messages)-1]
+	for i, word := range strings.Fields(reply.Content) {
+		if i > 0 {
+			word = " " + word
+		}
+		onEvent(model.Event{Type: model.EventDelta, Delta: word})
+	}
+
+	return []*model.Message{tool, reply}, nil
 }
 
 func TestServer_StartConversation(t *testing.T) {
@@ -49,7 +108,7 @@ func TestServer_StartConversation(t *testing.T) {
 			titleResponse: "Weather Question",
 			replyResponse: "It's sunny today!",
 		}
-		srv := NewServer(f.Repository, mockAssist)
+		srv := NewServer(f.Store, mockAssist)
 
 		resp, err := srv.StartConversation(ctx, &pb.StartConversationRequest{
 			Message: "What's the weather like?",
@@ -71,7 +130,7 @@ func TestServer_StartConversation(t *testing.T) {
 		}
 
 		// Verify conversation was saved to database
-		conv, err := f.Repository.DescribeConversation(ctx, resp.GetConversationId())
+		conv, err := f.Store.DescribeConversation(ctx, "", resp.GetConversationId())
 		if err != nil {
 			t.Fatalf("failed to retrieve saved conversation: %v", err)
 		}
@@ -100,7 +159,7 @@ func TestServer_StartConversation(t *testing.T) {
 
 	t.Run("returns error when message is empty", WithFixture(func(t *testing.T, f *Fixture) {
 		mockAssist := &MockAssistant{}
-		srv := NewServer(f.Repository, mockAssist)
+		srv := NewServer(f.Store, mockAssist)
 
 		_, err := srv.StartConversation(ctx, &pb.StartConversationRequest{
 			Message: "   ", // Empty/whitespace message
@@ -120,7 +179,7 @@ func TestServer_StartConversation(t *testing.T) {
 			titleError:    errors.New("title generation failed"),
 			replyResponse: "Test reply",
 		}
-		srv := NewServer(f.Repository, mockAssist)
+		srv := NewServer(f.Store, mockAssist)
 
 		resp, err := srv.StartConversation(ctx, &pb.StartConversationRequest{
 			Message: "What is the weather like in Barcelona?",
@@ -137,12 +196,40 @@ func TestServer_StartConversation(t *testing.T) {
 		}
 	}))
 
+	t.Run("keeps the conversation's tool selection", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, &MockAssistant{})
+
+		resp, err := srv.StartConversation(ctx, &pb.StartConversationRequest{
+			Message:       "What is 2 + 2?",
+			Tools:         []string{"calculate", "get_weather"},
+			DisabledTools: []string{" get_weather "},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		conv, err := f.Store.DescribeConversation(ctx, "", resp.GetConversationId())
+		if err != nil {
+			t.Fatalf("failed to retrieve saved conversation: %v", err)
+		}
+
+		want := model.ToolSelection{Enabled: []string{"calculate", "get_weather"}, Disabled: []string{"get_weather"}}
+		if diff := cmp.Diff(want, conv.ToolSelection); diff != "" {
+			t.Errorf("tool selection mismatch (-want +got):\n%s", diff)
+		}
+
+		_, err = srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Hi!", DisabledTools: []string{""}})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("expected twirp.InvalidArgument error for an empty tool name, got %v", err)
+		}
+	}))
+
 	t.Run("returns error when assistant reply fails", WithFixture(func(t *testing.T, f *Fixture) {
 		mockAssist := &MockAssistant{
 			titleResponse: "Test Title",
 			replyError:    errors.New("reply generation failed"),
 		}
-		srv := NewServer(f.Repository, mockAssist)
+		srv := NewServer(f.Store, mockAssist)
 
 		_, err := srv.StartConversation(ctx, &pb.StartConversationRequest{
 			Message: "Hello",
@@ -156,9 +243,9 @@ func TestServer_StartConversation(t *testing.T) {
 
 func TestServer_DescribeConversation(t *testing.T) {
 	ctx := context.Background()
-	srv := NewServer(model.New(ConnectMongo()), nil)
 
 	t.Run("describe existing conversation", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, nil)
 		c := f.CreateConversation()
 
 		out, err := srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: c.ID.Hex()})
@@ -173,6 +260,7 @@ func TestServer_DescribeConversation(t *testing.T) {
 	}))
 
 	t.Run("describe non existing conversation should return 404", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, nil)
 		_, err := srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: "08a59244257c872c5943e2a2"})
 		if err == nil {
 			t.Fatal("expected error for non-existing conversation, got nil")
@@ -182,4 +270,524 @@ func TestServer_DescribeConversation(t *testing.T) {
 			t.Fatalf("expected twirp.NotFound error, got %v", err)
 		}
 	}))
+
+	t.Run("describe conversation of another user should return 404", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, nil)
+		c := f.CreateConversation(func(c *model.Conversation) { c.OwnerID = "alice" })
+
+		_, err := srv.DescribeConversation(auth.WithUser(ctx, "bob"), &pb.DescribeConversationRequest{ConversationId: c.ID.Hex()})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.NotFound {
+			t.Fatalf("expected twirp.NotFound error, got %v", err)
+		}
+
+		if _, err := srv.DescribeConversation(auth.WithUser(ctx, "alice"), &pb.DescribeConversationRequest{ConversationId: c.ID.Hex()}); err != nil {
+			t.Fatalf("unexpected error for owner: %v", err)
+		}
+	}))
+}
+
+func TestServer_ListConversations(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("lists only the caller's conversations", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, nil)
+		alice := f.CreateConversation(func(c *model.Conversation) { c.OwnerID = "alice" })
+		f.CreateConversation(func(c *model.Conversation) { c.OwnerID = "bob" })
+
+		out, err := srv.ListConversations(auth.WithUser(ctx, "alice"), &pb.ListConversationsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var got []string
+		for _, c := range out.GetConversations() {
+			got = append(got, c.GetId())
+		}
+
+		if diff := cmp.Diff([]string{alice.ID.Hex()}, got); diff != "" {
+			t.Errorf("ListConversations() mismatch (-want +got):\n%s", diff)
+		}
+	}))
+
+	t.Run("pages through conversations without messages", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, nil)
+		for i := range 3 {
+			f.CreateConversation(func(c *model.Conversation) {
+				c.CreatedAt = c.CreatedAt.AddDate(0, 0, i)
+				c.UpdatedAt = c.CreatedAt
+			})
+		}
+
+		var pages [][]*pb.Conversation
+		req := &pb.ListConversationsRequest{PageSize: 2, Order: pb.ListConversationsRequest_OLDEST_FIRST}
+		for {
+			out, err := srv.ListConversations(ctx, req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			pages = append(pages, out.GetConversations())
+			if out.GetNextPageToken() == "" {
+				break
+			}
+			req.PageToken = out.GetNextPageToken()
+		}
+
+		if len(pages) != 2 || len(pages[0]) != 2 || len(pages[1]) != 1 {
+			t.Fatalf("expected pages of 2 and 1 conversations, got %v", pages)
+		}
+
+		if !pages[0][0].GetTimestamp().AsTime().Before(pages[1][0].GetTimestamp().AsTime()) {
+			t.Errorf("expected oldest conversations first")
+		}
+
+		for _, page := range pages {
+			for _, c := range page {
+				if len(c.GetMessages()) != 0 {
+					t.Errorf("expected conversation %s to be listed without messages", c.GetId())
+				}
+			}
+		}
+	}))
+
+	t.Run("rejects negative page size", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, nil)
+
+		_, err := srv.ListConversations(ctx, &pb.ListConversationsRequest{PageSize: -1})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Fatalf("expected twirp.InvalidArgument error, got %v", err)
+		}
+	}))
+}
+
+func TestServer_UpdateConversation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("renames a conversation", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, nil)
+		c := f.CreateConversation()
+
+		out, err := srv.UpdateConversation(ctx, &pb.UpdateConversationRequest{ConversationId: c.ID.Hex(), Title: proto.String(" Trip to Lisbon ")})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := out.GetConversation().GetTitle(); got != "Trip to Lisbon" {
+			t.Errorf("expected title %q, got %q", "Trip to Lisbon", got)
+		}
+
+		stored, err := f.Store.DescribeConversation(ctx, "", c.ID.Hex())
+		if err != nil {
+			t.Fatalf("failed to describe conversation: %v", err)
+		}
+
+		if stored.Title != "Trip to Lisbon" || len(stored.Messages) != len(c.Messages) || stored.Archived {
+			t.Errorf("expected only the title to change, got %+v", stored)
+		}
+	}))
+
+	t.Run("archived conversations are listed only on request", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, nil)
+		c := f.CreateConversation()
+
+		listed := func(includeArchived bool) bool {
+			out, err := srv.ListConversations(ctx, &pb.ListConversationsRequest{IncludeArchived: includeArchived})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, conv := range out.GetConversations() {
+				if conv.GetId() == c.ID.Hex() {
+					return true
+				}
+			}
+			return false
+		}
+
+		out, err := srv.UpdateConversation(ctx, &pb.UpdateConversationRequest{ConversationId: c.ID.Hex(), Archived: proto.Bool(true)})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !out.GetConversation().GetArchived() || out.GetConversation().GetTitle() != c.Title {
+			t.Errorf("expected archived conversation with unchanged title, got %v", out.GetConversation())
+		}
+
+		if listed(false) || !listed(true) {
+			t.Errorf("expected archived conversation to be listed only with include_archived")
+		}
+
+		if _, err := srv.UpdateConversation(ctx, &pb.UpdateConversationRequest{ConversationId: c.ID.Hex(), Archived: proto.Bool(false)}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !listed(false) {
+			t.Errorf("expected unarchived conversation to be listed")
+		}
+	}))
+
+	t.Run("rejects invalid requests", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, nil)
+		c := f.CreateConversation(func(c *model.Conversation) { c.OwnerID = "alice" })
+
+		tests := []struct {
+			name string
+			ctx  context.Context
+			req  *pb.UpdateConversationRequest
+			code twirp.ErrorCode
+		}{
+			{name: "missing ID", ctx: ctx, req: &pb.UpdateConversationRequest{Title: proto.String("Title")}, code: twirp.InvalidArgument},
+			{name: "empty title", ctx: auth.WithUser(ctx, "alice"), req: &pb.UpdateConversationRequest{ConversationId: c.ID.Hex(), Title: proto.String(" ")}, code: twirp.InvalidArgument},
+			{name: "another user's conversation", ctx: auth.WithUser(ctx, "bob"), req: &pb.UpdateConversationRequest{ConversationId: c.ID.Hex(), Title: proto.String("Mine")}, code: twirp.NotFound},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := srv.UpdateConversation(tt.ctx, tt.req)
+				if te, ok := err.(twirp.Error); !ok || te.Code() != tt.code {
+					t.Errorf("expected twirp %s error, got %v", tt.code, err)
+				}
+			})
+		}
+	}))
+}
+
+func TestServer_DeleteConversation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deleted conversation is no longer found", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, nil)
+		c := f.CreateConversation()
+
+		if _, err := srv.DeleteConversation(ctx, &pb.DeleteConversationRequest{ConversationId: c.ID.Hex()}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err := srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: c.ID.Hex()})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.NotFound {
+			t.Errorf("expected twirp.NotFound error when describing deleted conversation, got %v", err)
+		}
+
+		out, err := srv.ListConversations(ctx, &pb.ListConversationsRequest{IncludeArchived: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, conv := range out.GetConversations() {
+			if conv.GetId() == c.ID.Hex() {
+				t.Errorf("expected deleted conversation not to be listed")
+			}
+		}
+	}))
+
+	t.Run("missing conversations are not found", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, nil)
+		c := f.CreateConversation(func(c *model.Conversation) { c.OwnerID = "alice" })
+
+		if _, err := srv.DeleteConversation(auth.WithUser(ctx, "alice"), &pb.DeleteConversationRequest{ConversationId: c.ID.Hex()}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		tests := []struct {
+			name string
+			ctx  context.Context
+			id   string
+		}{
+			{name: "already deleted", ctx: auth.WithUser(ctx, "alice"), id: c.ID.Hex()},
+			{name: "another user's conversation", ctx: auth.WithUser(ctx, "bob"), id: f.CreateConversation(func(c *model.Conversation) { c.OwnerID = "alice" }).ID.Hex()},
+			{name: "non existing", ctx: ctx, id: "08a59244257c872c5943e2a2"},
+			{name: "invalid ID", ctx: ctx, id: "not-an-id"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := srv.DeleteConversation(tt.ctx, &pb.DeleteConversationRequest{ConversationId: tt.id})
+				if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.NotFound {
+					t.Errorf("expected twirp.NotFound error, got %v", err)
+				}
+			})
+		}
+	}))
+}
+
+func TestServer_Branches(t *testing.T) {
+	ctx := context.Background()
+
+	// start creates a conversation of two turns and returns it as described by the server.
+	start := func(t *testing.T, srv *Server) *pb.Conversation {
+		t.Helper()
+
+		out, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Weather in Paris?"})
+		if err != nil {
+			t.Fatalf("failed to start conversation: %v", err)
+		}
+
+		if _, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: out.GetConversationId(), Message: "And tomorrow?"}); err != nil {
+			t.Fatalf("failed to continue conversation: %v", err)
+		}
+
+		return describe(t, srv, out.GetConversationId())
+	}
+
+	t.Run("edit message branches from its parent", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, &EchoAssistant{})
+		conv := start(t, srv)
+		question := conv.GetMessages()[2]
+
+		out, err := srv.EditMessage(ctx, &pb.EditMessageRequest{ConversationId: conv.GetId(), MessageId: question.GetId(), Content: "And on Sunday?"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if want := "Weather in Paris? / Weather in Paris? / And on Sunday?"; out.GetReply() != want {
+			t.Errorf("expected the assistant to see the edited branch only, got reply %q", out.GetReply())
+		}
+
+		got := describe(t, srv, conv.GetId())
+		if diff := cmp.Diff([]string{"Weather in Paris?", "Weather in Paris?", "And on Sunday?", out.GetReply()}, messageContents(got)); diff != "" {
+			t.Errorf("active path mismatch (-want +got):\n%s", diff)
+		}
+
+		if len(got.GetForks()) != 1 {
+			t.Fatalf("expected one fork, got %d", len(got.GetForks()))
+		}
+
+		fork := got.GetForks()[0]
+		if fork.GetParentId() != conv.GetMessages()[1].GetId() || fork.GetActiveMessageId() != out.GetMessageId() {
+			t.Errorf("unexpected fork %v", fork)
+		}
+
+		if len(fork.GetBranches()) != 2 || fork.GetBranches()[0].GetContent() != "And tomorrow?" || fork.GetBranches()[1].GetContent() != "And on Sunday?" {
+			t.Errorf("expected the original and edited messages as branches, got %v", fork.GetBranches())
+		}
+	}))
+
+	t.Run("regenerate reply replaces the last reply by default", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, &EchoAssistant{})
+		conv := start(t, srv)
+
+		out, err := srv.RegenerateReply(ctx, &pb.RegenerateReplyRequest{ConversationId: conv.GetId()})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if want := "Weather in Paris? / Weather in Paris? / And tomorrow?"; out.GetReply() != want {
+			t.Errorf("expected the assistant to see the path up to the replaced reply, got %q", out.GetReply())
+		}
+
+		got := describe(t, srv, conv.GetId())
+		if n := len(got.GetMessages()); n != 4 || got.GetMessages()[3].GetId() != out.GetMessageId() {
+			t.Errorf("expected the regenerated reply to end the active path, got %v", got.GetMessages())
+		}
+
+		if len(got.GetForks()) != 1 || got.GetForks()[0].GetBranches()[0].GetId() != conv.GetMessages()[3].GetId() {
+			t.Errorf("expected a fork between the original and regenerated replies, got %v", got.GetForks())
+		}
+	}))
+
+	t.Run("regenerating an earlier reply leaves later messages on the old branch", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, &EchoAssistant{})
+		conv := start(t, srv)
+		first := conv.GetMessages()[1]
+
+		out, err := srv.RegenerateReply(ctx, &pb.RegenerateReplyRequest{ConversationId: conv.GetId(), MessageId: first.GetId()})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got := describe(t, srv, conv.GetId())
+		if diff := cmp.Diff([]string{"Weather in Paris?", out.GetReply()}, messageContents(got)); diff != "" {
+			t.Errorf("active path mismatch (-want +got):\n%s", diff)
+		}
+
+		// Switching back to the original reply restores the rest of its branch.
+		updated, err := srv.UpdateConversation(ctx, &pb.UpdateConversationRequest{ConversationId: conv.GetId(), ActiveMessageId: proto.String(first.GetId())})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff(messageContents(conv), messageContents(updated.GetConversation())); diff != "" {
+			t.Errorf("active path after switching back mismatch (-want +got):\n%s", diff)
+		}
+	}))
+
+	t.Run("rejects messages of the wrong role", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, &EchoAssistant{})
+		conv := start(t, srv)
+
+		_, err := srv.EditMessage(ctx, &pb.EditMessageRequest{ConversationId: conv.GetId(), MessageId: conv.GetMessages()[1].GetId(), Content: "Edited"})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("expected twirp.InvalidArgument error editing a reply, got %v", err)
+		}
+
+		_, err = srv.RegenerateReply(ctx, &pb.RegenerateReplyRequest{ConversationId: conv.GetId(), MessageId: conv.GetMessages()[0].GetId()})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("expected twirp.InvalidArgument error regenerating a user message, got %v", err)
+		}
+
+		_, err = srv.EditMessage(ctx, &pb.EditMessageRequest{ConversationId: conv.GetId(), MessageId: "08a59244257c872c5943e2a2", Content: "Edited"})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.NotFound {
+			t.Errorf("expected twirp.NotFound error editing a missing message, got %v", err)
+		}
+	}))
+}
+
+// describe returns the conversation as described by the server.
+func describe(t *testing.T, srv *Server, id string) *pb.Conversation {
+	t.Helper()
+
+	out, err := srv.DescribeConversation(context.Background(), &pb.DescribeConversationRequest{ConversationId: id})
+	if err != nil {
+		t.Fatalf("failed to describe conversation: %v", err)
+	}
+
+	return out.GetConversation()
+}
+
+func messageContents(conv *pb.Conversation) []string {
+	var out []string
+	for _, m := range conv.GetMessages() {
+		out = append(out, m.GetContent())
+	}
+	return out
+}
+
+func TestServer_StreamHandler(t *testing.T) {
+	// stream posts the body to the streaming handler and returns the received events in order.
+	stream := func(t *testing.T, srv *Server, body string) (int, []model.Event) {
+		t.Helper()
+
+		rec := httptest.NewRecorder()
+		srv.StreamHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/stream/conversation", strings.NewReader(body)))
+
+		var events []model.Event
+		scanner := bufio.NewScanner(rec.Body)
+		var typ string
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				typ = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				var e model.Event
+				if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &e); err != nil {
+					t.Fatalf("failed to decode event: %v", err)
+				}
+				e.Type = model.EventType(typ)
+				events = append(events, e)
+			}
+		}
+
+		return rec.Code, events
+	}
+
+	t.Run("streams deltas, tool calls and saved event for a new conversation", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, &MockStreamingAssistant{MockAssistant{
+			titleResponse: "Weather Question",
+			replyResponse: "It's sunny today!",
+		}})
+
+		code, events := stream(t, srv, `{"message": "What's the weather like?"}`)
+		if code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", code)
+		}
+
+		var types []model.EventType
+		var reply string
+		for _, e := range events {
+			types = append(types, e.Type)
+			reply += e.Delta
+		}
+
+		want := []model.EventType{
+			model.EventStarted,
+			model.EventToolCallStarted,
+			model.EventToolCallFinished,
+			model.EventDelta,
+			model.EventDelta,
+			model.EventDelta,
+			model.EventSaved,
+		}
+		if diff := cmp.Diff(want, types); diff != "" {
+			t.Fatalf("event types mismatch (-want +got):\n%s", diff)
+		}
+
+		if reply != "It's sunny today!" {
+			t.Errorf("expected streamed reply %q, got %q", "It's sunny today!", reply)
+		}
+
+		saved := events[len(events)-1]
+		if saved.Title != "Weather Question" || saved.Reply != "It's sunny today!" {
+			t.Errorf("unexpected saved event: %+v", saved)
+		}
+
+		conv, err := f.Store.DescribeConversation(context.Background(), "", saved.ConversationID)
+		if err != nil {
+			t.Fatalf("failed to retrieve saved conversation: %v", err)
+		}
+
+		if len(conv.Messages) != 3 || conv.Messages[2].Content != "It's sunny today!" {
+			t.Fatalf("expected the tool call and streamed reply to be saved, got %+v", conv.Messages)
+		}
+
+		if call := conv.Messages[1].ToolCall; conv.Messages[1].Role != model.RoleTool || call == nil || call.Name != "get_weather" {
+			t.Errorf("expected the tool call to be saved before the reply, got %+v", conv.Messages[1])
+		}
+	}))
+
+	t.Run("continues an existing conversation", WithFixture(func(t *testing.T, f *Fixture) {
+		c := f.CreateConversation()
+		srv := NewServer(f.Store, &MockStreamingAssistant{MockAssistant{replyResponse: "Still sunny."}})
+
+		code, events := stream(t, srv, `{"conversation_id": "`+c.ID.Hex()+`", "message": "And tomorrow?"}`)
+		if code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", code)
+		}
+
+		if first := events[0]; first.Type != model.EventStarted || first.ConversationID != c.ID.Hex() {
+			t.Errorf("expected started event for %s, got %+v", c.ID.Hex(), first)
+		}
+
+		if last := events[len(events)-1]; last.Type != model.EventSaved || last.Reply != "Still sunny." {
+			t.Errorf("expected saved event with reply, got %+v", last)
+		}
+	}))
+
+	t.Run("rejects empty message before streaming", func(t *testing.T) {
+		srv := NewServer(nil, &MockStreamingAssistant{})
+
+		code, events := stream(t, srv, `{"message": "  "}`)
+		if code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", code)
+		}
+
+		if len(events) != 0 {
+			t.Errorf("expected no events, got %+v", events)
+		}
+	})
+
+	t.Run("rejects a persona for an existing conversation", func(t *testing.T) {
+		srv := NewServer(nil, &MockStreamingAssistant{})
+
+		code, events := stream(t, srv, `{"conversation_id": "68a5aa7b14ba62ef8448c917", "persona_id": "chef", "message": "Hi!"}`)
+		if code != http.StatusBadRequest || len(events) != 0 {
+			t.Errorf("expected status 400 and no events, got %d and %+v", code, events)
+		}
+	})
+
+	t.Run("reports reply failure as error event", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(f.Store, &MockStreamingAssistant{MockAssistant{replyError: errors.New("boom")}})
+
+		_, events := stream(t, srv, `{"message": "Hello"}`)
+		if last := events[len(events)-1]; last.Type != model.EventError || last.Error != "internal error" {
+			t.Errorf("expected error event without the cause, got %+v", last)
+		}
+	}))
+
+	t.Run("reports client errors as error event", WithFixture(func(t *testing.T, f *Fixture) {
+		err := twirp.NewError(twirp.ResourceExhausted, "too many tool calls")
+		srv := NewServer(f.Store, &MockStreamingAssistant{MockAssistant{replyError: err}})
+
+		_, events := stream(t, srv, `{"message": "Hello"}`)
+		if last := events[len(events)-1]; last.Type != model.EventError || last.Error != "too many tool calls" {
+			t.Errorf("expected error event with the message, got %+v", last)
+		}
+	}))
 }
@@ -0,0 +1,144 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+)
+
+// StreamRequest is the JSON body accepted by the streaming endpoint. Leave ConversationID empty to start a
+// new conversation, with the persona and tool selection of the remaining fields, or set it to continue an
+// existing one.
+type StreamRequest struct {
+	ConversationID string   `json:"conversation_id,omitempty"`
+	PersonaID      string   `json:"persona_id,omitempty"`
+	Tools          []string `json:"tools,omitempty"`
+	DisabledTools  []string `json:"disabled_tools,omitempty"`
+	Message        string   `json:"message"`
+}
+
+// StreamHandler returns an HTTP handler that answers a user message with server-sent events: a "started"
+// event once the conversation is known, "delta" events with reply tokens, "tool_call_started" and
+// "tool_call_finished" events around tool calls, and a final "saved" event once the conversation is stored, with
+// the citations of the reply. Failures after the stream has started are reported with an "error" event.
+func (s *Server) StreamHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			_ = twirp.WriteError(w, twirp.NewError(twirp.BadRoute, "streaming endpoint only supports POST"))
+			return
+		}
+
+		var req StreamRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			_ = twirp.WriteError(w, twirp.NewError(twirp.Malformed, "failed to parse request body: "+err.Error()))
+			return
+		}
+
+		if req.ConversationID != "" && (req.PersonaID != "" || len(req.Tools) > 0 || len(req.DisabledTools) > 0) {
+			_ = twirp.WriteError(w, twirp.NewError(twirp.InvalidArgument, "persona_id, tools and disabled_tools can only be set when starting a conversation"))
+			return
+		}
+
+		stream := &eventStream{w: w}
+
+		var (
+			conv   *model.Conversation
+			answer *model.Message
+			err    error
+		)
+
+		if req.ConversationID == "" {
+			conv, answer, err = s.startConversation(r.Context(), &pb.StartConversationRequest{
+				Message:       req.Message,
+				PersonaId:     req.PersonaID,
+				Tools:         req.Tools,
+				DisabledTools: req.DisabledTools,
+			}, stream.Send)
+		} else {
+			conv, answer, err = s.continueConversation(r.Context(), req.ConversationID, req.Message, stream.Send)
+		}
+
+		if err != nil {
+			stream.Fail(r.Context(), err)
+			return
+		}
+
+		stream.Send(model.Event{
+			Type:           model.EventSaved,
+			ConversationID: conv.ID.Hex(),
+			Title:          conv.Title,
+			Reply:          answer.Content,
+			Citations:      answer.Citations,
+		})
+	})
+}
+
+// eventStream writes events to the client as server-sent events. Headers are only written with the first
+// event, so errors raised before anything was streamed can still be reported with a proper status code.
+type eventStream struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	started bool
+}
+
+// Send writes the event to the client and flushes it immediately.
+func (s *eventStream) Send(event model.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.started {
+		s.w.Header().Set("Content-Type", "text/event-stream")
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		slog.Error("Failed to encode stream event", "type", event.Type, "error", err)
+		return
+	}
+
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+		return
+	}
+
+	_ = http.NewResponseController(s.w).Flush()
+}
+
+// Fail reports the error, either as a regular Twirp error response or, if events were already sent, as an
+// error event with the same message. Internal errors are logged, and only reported as an internal error, so
+// storage and model provider errors do not reach clients.
+func (s *eventStream) Fail(ctx context.Context, err error) {
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) || twerr.Code() == twirp.Internal {
+		slog.ErrorContext(ctx, "Failed to stream reply", "error", err)
+		twerr = twirp.InternalError("internal error")
+	}
+
+	s.mu.Lock()
+	started := s.started
+	s.mu.Unlock()
+
+	if !started {
+		_ = twirp.WriteError(s.w, twerr)
+		return
+	}
+
+	s.Send(model.Event{Type: model.EventError, Error: twerr.Msg()})
+}
+
+// emit reports the event to onEvent, if set.
+func emit(onEvent func(model.Event), event model.Event) {
+	if onEvent != nil {
+		onEvent(event)
+	}
+}
@@ -11,14 +11,14 @@ import (
 )
 
 type Fixture struct {
-	*model.Repository
+	Store  model.Store
 	test   *testing.T
 	defers []func()
 }
 
 func WithFixture(runner func(t *testing.T, f *Fixture)) func(t *testing.T) {
 	return func(t *testing.T) {
-		f := &Fixture{Repository: model.New(ConnectMongo()), test: t}
+		f := &Fixture{Store: NewStore(t), test: t}
 		defer f.Teardown()
 		runner(t, f)
 	}
@@ -45,14 +45,13 @@ func (f *Fixture) CreateConversation(mods ...func(*model.Conversation)) *model.C
 
 	ctx := context.Background()
 
-	if err := f.Repository.CreateConversation(ctx, c); err != nil {
+	if err := f.Store.CreateConversation(ctx, c); err != nil {
 		f.test.Fatalf("failed to create conversation: %v", err)
 	}
 
 	f.defers = append(f.defers, func() {
-		if err := f.Repository.DeleteConversation(ctx, c.ID.Hex()); err != nil {
-			f.test.Logf("failed to cleanup conversation %s: %v", c.ID.Hex(), err)
-		}
+		// Tests may have deleted the conversation already, the purge below removes it either way.
+		_ = f.Store.DeleteConversation(ctx, c.OwnerID, c.ID.Hex())
 	})
 
 	return c
@@ -62,4 +61,8 @@ func (f *Fixture) Teardown() {
 	for _, d := range f.defers {
 		d()
 	}
+
+	if _, err := f.Store.PurgeConversations(context.Background(), time.Now()); err != nil {
+		f.test.Logf("failed to purge deleted conversations: %v", err)
+	}
 }
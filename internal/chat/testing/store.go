@@ -0,0 +1,33 @@
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/chat/model"
+)
+
+// NewStore returns the store tests run against, selected with the TEST_STORAGE_BACKEND
+// environment variable: "memory" (default, hermetic), "bolt" (temporary database file) or "mongo" (requires
+// a running MongoDB, see ConnectMongo).
+func NewStore(t *testing.T) model.Store {
+	switch backend := os.Getenv("TEST_STORAGE_BACKEND"); backend {
+	case "", "memory":
+		return model.NewInMemoryStore()
+	case "bolt":
+		store, err := model.OpenBoltStore(filepath.Join(t.TempDir(), "acai.db"))
+		if err != nil {
+			t.Fatalf("failed to open bolt store: %v", err)
+		}
+		t.Cleanup(func() {
+			_ = store.Close()
+		})
+		return store
+	case "mongo":
+		return model.New(ConnectMongo())
+	default:
+		t.Fatalf("unknown TEST_STORAGE_BACKEND %q", backend)
+		return nil
+	}
+}
@@ -0,0 +1,309 @@
+// Package decimal provides exact decimal arithmetic, for calculations and amounts of money that must not suffer
+// from float rounding. Values are rationals: sums, products and quotients are exact, and only formatting rounds.
+package decimal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DisplayPlaces is the number of decimal places String rounds to, for values without a finite decimal expansion.
+const DisplayPlaces = 20
+
+// maxExponent bounds the exponent of parsed numbers, e.g. 1e1000, so parsing cannot allocate huge numbers.
+const maxExponent = 1000
+
+// ErrDivisionByZero is returned when dividing by zero.
+var ErrDivisionByZero = errors.New("division by zero")
+
+// Decimal is an exact number. Its zero value is 0. Decimals are immutable, operations return new values.
+type Decimal struct {
+	r *big.Rat // Nil for 0.
+}
+
+var number = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+// Parse parses a decimal number, e.g. "12", "-0.5", ".25" or "1.5e3".
+func Parse(s string) (Decimal, error) {
+	if !number.MatchString(s) {
+		return Decimal{}, fmt.Errorf("invalid number %q", s)
+	}
+
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		if exp, err := strconv.Atoi(s[i+1:]); err != nil || exp > maxExponent || exp < -maxExponent {
+			return Decimal{}, fmt.Errorf("exponent of %q out of range", s)
+		}
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid number %q", s)
+	}
+
+	return Decimal{r: r}, nil
+}
+
+// MustParse is like Parse, but panics on invalid numbers. It is meant for constants.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// New returns the integer n.
+func New(n int64) Decimal {
+	return Decimal{r: new(big.Rat).SetInt64(n)}
+}
+
+// FromRat returns the value of r, which is copied.
+func FromRat(r *big.Rat) Decimal {
+	return Decimal{r: new(big.Rat).Set(r)}
+}
+
+func (d Decimal) rat() *big.Rat {
+	if d.r == nil {
+		return new(big.Rat)
+	}
+	return d.r
+}
+
+// Rat returns the value as a rational.
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).Set(d.rat())
+}
+
+func (d Decimal) Add(e Decimal) Decimal {
+	return Decimal{r: new(big.Rat).Add(d.rat(), e.rat())}
+}
+
+func (d Decimal) Sub(e Decimal) Decimal {
+	return Decimal{r: new(big.Rat).Sub(d.rat(), e.rat())}
+}
+
+func (d Decimal) Mul(e Decimal) Decimal {
+	return Decimal{r: new(big.Rat).Mul(d.rat(), e.rat())}
+}
+
+// Quo returns d / e.
+func (d Decimal) Quo(e Decimal) (Decimal, error) {
+	if e.Sign() == 0 {
+		return Decimal{}, ErrDivisionByZero
+	}
+	return Decimal{r: new(big.Rat).Quo(d.rat(), e.rat())}, nil
+}
+
+// Mod returns the remainder of d / e truncated to an integer, with the sign of d, like Go's % operator.
+func (d Decimal) Mod(e Decimal) (Decimal, error) {
+	q, err := d.Quo(e)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return d.Sub(q.Trunc().Mul(e)), nil
+}
+
+// Pow returns d raised to the integer power n.
+func (d Decimal) Pow(n int64) (Decimal, error) {
+	r := d.rat()
+	if n < 0 {
+		if r.Sign() == 0 {
+			return Decimal{}, ErrDivisionByZero
+		}
+		r = new(big.Rat).Inv(r)
+		n = -n
+	}
+
+	exp := big.NewInt(n)
+	num := new(big.Int).Exp(r.Num(), exp, nil)
+	den := new(big.Int).Exp(r.Denom(), exp, nil)
+
+	return Decimal{r: new(big.Rat).SetFrac(num, den)}, nil
+}
+
+// Sqrt returns the square root of d, and whether it is exact. Inexact roots are rounded to DisplayPlaces.
+func (d Decimal) Sqrt() (Decimal, bool, error) {
+	if d.Sign() < 0 {
+		return Decimal{}, false, errors.New("square root of a negative number")
+	}
+
+	f := new(big.Float).SetPrec(256).SetRat(d.rat())
+	root, _ := f.Sqrt(f).Rat(nil)
+
+	if new(big.Rat).Mul(root, root).Cmp(d.rat()) == 0 {
+		return Decimal{r: root}, true, nil
+	}
+	return Decimal{r: root}.Round(DisplayPlaces), false, nil
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{r: new(big.Rat).Neg(d.rat())}
+}
+
+func (d Decimal) Abs() Decimal {
+	return Decimal{r: new(big.Rat).Abs(d.rat())}
+}
+
+// Cmp compares d and e, returning -1, 0 or +1.
+func (d Decimal) Cmp(e Decimal) int {
+	return d.rat().Cmp(e.rat())
+}
+
+// Sign returns -1, 0 or +1.
+func (d Decimal) Sign() int {
+	return d.rat().Sign()
+}
+
+// IsInt tells whether d is an integer.
+func (d Decimal) IsInt() bool {
+	return d.rat().IsInt()
+}
+
+// Int64 returns d as an integer, and whether it is one that fits.
+func (d Decimal) Int64() (int64, bool) {
+	if !d.IsInt() || !d.rat().Num().IsInt64() {
+		return 0, false
+	}
+	return d.rat().Num().Int64(), true
+}
+
+// Floor returns the greatest integer less than or equal to d.
+func (d Decimal) Floor() Decimal {
+	// Euclidean division by the positive denominator rounds towards negative infinity.
+	q := new(big.Int).Div(d.rat().Num(), d.rat().Denom())
+	return Decimal{r: new(big.Rat).SetInt(q)}
+}
+
+// Ceil returns the least integer greater than or equal to d.
+func (d Decimal) Ceil() Decimal {
+	return d.Neg().Floor().Neg()
+}
+
+// Trunc returns the integer part of d.
+func (d Decimal) Trunc() Decimal {
+	q := new(big.Int).Quo(d.rat().Num(), d.rat().Denom())
+	return Decimal{r: new(big.Rat).SetInt(q)}
+}
+
+// Round rounds d to the given number of decimal places, half away from zero. Negative places round to tens,
+// hundreds, etc.
+func (d Decimal) Round(places int) Decimal {
+	scale := pow10(places)
+	scaled := new(big.Rat).Mul(d.rat(), scale)
+
+	q, r := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if r.Abs(r).Lsh(r, 1).Cmp(scaled.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(scaled.Sign())))
+	}
+
+	return Decimal{r: new(big.Rat).Quo(new(big.Rat).SetInt(q), scale)}
+}
+
+// pow10 returns 10^n.
+func pow10(n int) *big.Rat {
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(n))), nil)
+	if n < 0 {
+		return new(big.Rat).SetFrac(big.NewInt(1), p)
+	}
+	return new(big.Rat).SetInt(p)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// places returns the number of decimal places of d's decimal expansion, and false if it does not terminate.
+func (d Decimal) places() (int, bool) {
+	den := new(big.Int).Set(d.rat().Denom())
+	var twos, fives int
+
+	two, five, mod := big.NewInt(2), big.NewInt(5), new(big.Int)
+	for mod.Mod(den, two).Sign() == 0 {
+		den.Quo(den, two)
+		twos++
+	}
+	for mod.Mod(den, five).Sign() == 0 {
+		den.Quo(den, five)
+		fives++
+	}
+
+	return max(twos, fives), den.IsInt64() && den.Int64() == 1
+}
+
+// Exact tells whether String returns d exactly, that is whether its decimal expansion terminates.
+func (d Decimal) Exact() bool {
+	_, ok := d.places()
+	return ok
+}
+
+// String returns d in decimal notation, exactly when its expansion terminates, rounded to DisplayPlaces
+// otherwise. Trailing zeros are omitted.
+func (d Decimal) String() string {
+	places, ok := d.places()
+	if !ok {
+		places = DisplayPlaces
+	}
+
+	s := d.StringFixed(places)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+
+	return s
+}
+
+// StringFixed returns d rounded to the given number of decimal places, with exactly that many places, e.g.
+// "12.50" for 12.5 with 2 places.
+func (d Decimal) StringFixed(places int) string {
+	rounded := d.Round(places)
+	places = max(places, 0)
+
+	scaled := new(big.Rat).Mul(rounded.rat(), pow10(places))
+	digits := new(big.Int).Abs(scaled.Num()).String()
+
+	if len(digits) <= places {
+		digits = strings.Repeat("0", places-len(digits)+1) + digits
+	}
+
+	s := digits
+	if places > 0 {
+		s = digits[:len(digits)-places] + "." + digits[len(digits)-places:]
+	}
+	if rounded.Sign() < 0 {
+		s = "-" + s
+	}
+
+	return s
+}
+
+// MarshalJSON encodes d as a JSON number, exactly when its expansion terminates.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number, or a string holding one, without going through a float.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	s := string(bytes.Trim(data, `"`))
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	*d = v
+	return nil
+}
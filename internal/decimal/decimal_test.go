@@ -0,0 +1,135 @@
+package decimal
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "12", want: "12"},
+		{in: "-0.50", want: "-0.5"},
+		{in: ".25", want: "0.25"},
+		{in: "1.5e3", want: "1500"},
+		{in: "1E-3", want: "0.001"},
+		{in: "+7.", want: "7"},
+		{in: "1/3", wantErr: true},
+		{in: "0x10", wantErr: true},
+		{in: "1e100000", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) = %s, want an error", tt.in, got)
+				}
+				return
+			}
+
+			if err != nil || got.String() != tt.want {
+				t.Errorf("Parse(%q) = %s, %v, want %s", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	d := MustParse
+
+	if got := d("0.1").Add(d("0.2")); got.Cmp(d("0.3")) != 0 {
+		t.Errorf("0.1 + 0.2 = %s, want exactly 0.3", got)
+	}
+
+	if got := d("19.99").Mul(New(3)); got.String() != "59.97" {
+		t.Errorf("19.99 * 3 = %s, want 59.97", got)
+	}
+
+	third, err := New(1).Quo(New(3))
+	if err != nil || third.Exact() || third.String() != "0.33333333333333333333" {
+		t.Errorf("1 / 3 = %s (exact %v), %v, want 0.33333333333333333333 rounded", third, third.Exact(), err)
+	}
+	if got := third.Mul(New(3)); got.String() != "1" {
+		t.Errorf("1 / 3 * 3 = %s, want exactly 1", got)
+	}
+
+	if _, err := New(1).Quo(Decimal{}); err != ErrDivisionByZero {
+		t.Errorf("1 / 0 error = %v, want ErrDivisionByZero", err)
+	}
+
+	if got, _ := New(-7).Mod(New(3)); got.String() != "-1" {
+		t.Errorf("-7 mod 3 = %s, want -1", got)
+	}
+
+	if got, _ := d("1.5").Pow(-2); got.String() != "0.44444444444444444444" {
+		t.Errorf("1.5 ^ -2 = %s", got)
+	}
+
+	if got, exact, _ := d("2.25").Sqrt(); !exact || got.String() != "1.5" {
+		t.Errorf("sqrt(2.25) = %s (exact %v), want exactly 1.5", got, exact)
+	}
+	if got, exact, _ := New(2).Sqrt(); exact || got.String() != "1.4142135623730950488" {
+		t.Errorf("sqrt(2) = %s (exact %v), want an approximation", got, exact)
+	}
+}
+
+func TestRounding(t *testing.T) {
+	tests := []struct {
+		in     string
+		places int
+		round  string
+		fixed  string
+	}{
+		{in: "2.345", places: 2, round: "2.35", fixed: "2.35"},
+		{in: "-2.345", places: 2, round: "-2.35", fixed: "-2.35"},
+		{in: "2.5", places: 0, round: "3", fixed: "3"},
+		{in: "12.5", places: 2, round: "12.5", fixed: "12.50"},
+		{in: "0.004", places: 2, round: "0", fixed: "0.00"},
+		{in: "-0.004", places: 2, round: "0", fixed: "0.00"},
+		{in: "1250", places: -2, round: "1300", fixed: "1300"},
+	}
+
+	for _, tt := range tests {
+		d := MustParse(tt.in)
+		if got := d.Round(tt.places).String(); got != tt.round {
+			t.Errorf("Round(%s, %d) = %s, want %s", tt.in, tt.places, got, tt.round)
+		}
+		if got := d.StringFixed(tt.places); got != tt.fixed {
+			t.Errorf("StringFixed(%s, %d) = %s, want %s", tt.in, tt.places, got, tt.fixed)
+		}
+	}
+
+	for in, want := range map[string][2]string{"2.5": {"2", "3"}, "-2.5": {"-3", "-2"}, "4": {"4", "4"}} {
+		d := MustParse(in)
+		if got := [2]string{d.Floor().String(), d.Ceil().String()}; got != want {
+			t.Errorf("floor, ceil(%s) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var v struct {
+		Amount Decimal `json:"amount"`
+		Price  Decimal `json:"price"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"amount": 0.1000000000000000055511151231257827, "price": "19.99"}`), &v); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil || string(data) != `{"amount":0.1000000000000000055511151231257827,"price":19.99}` {
+		t.Errorf("Marshal() = %s, %v", data, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"amount": "ten"}`), &v); err == nil {
+		t.Error("Unmarshal() of an invalid number succeeded")
+	}
+}
@@ -0,0 +1,157 @@
+// Package documents keeps the documents users upload for the assistant, split into passages indexed by their
+// embeddings, so the passages relevant to a question can be found and quoted.
+package documents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Document is an uploaded document. Like conversations, documents belong to an owner, and are never searched for
+// anyone else.
+type Document struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	OwnerID        string             `bson:"owner_id"`
+	ConversationID string             `bson:"conversation_id,omitempty"` // Empty for every conversation of the owner.
+	Name           string             `bson:"name"`
+	ContentType    string             `bson:"content_type"`
+	Size           int                `bson:"size"`   // In bytes.
+	Chunks         int                `bson:"chunks"` // Number of passages it was split into.
+	CreatedAt      time.Time          `bson:"created_at"`
+}
+
+func (d *Document) Proto() *pb.Document {
+	return &pb.Document{
+		Id:             d.ID.Hex(),
+		Name:           d.Name,
+		ContentType:    d.ContentType,
+		Size:           int64(d.Size),
+		Chunks:         int32(d.Chunks),
+		ConversationId: d.ConversationID,
+		CreatedAt:      timestamppb.New(d.CreatedAt),
+	}
+}
+
+// Chunk is a passage of a document, with its embedding. It repeats the owner and conversation of its document, so
+// searches only need the chunks.
+type Chunk struct {
+	DocumentID     primitive.ObjectID `bson:"document_id"`
+	DocumentName   string             `bson:"document_name"`
+	OwnerID        string             `bson:"owner_id"`
+	ConversationID string             `bson:"conversation_id,omitempty"`
+	Index          int                `bson:"index"` // Position in the document, from 0.
+	Text           string             `bson:"text"`
+	Vector         []float32          `bson:"vector"`
+}
+
+// visibleIn reports whether the chunk may be searched in a conversation of the owner.
+func (c *Chunk) visibleIn(ownerID, conversationID string) bool {
+	return c.OwnerID == ownerID && (c.ConversationID == "" || c.ConversationID == conversationID)
+}
+
+// Match is a chunk found by a search, with its similarity to the query, from -1 to 1.
+type Match struct {
+	Chunk
+	Score float64
+}
+
+// Query searches the chunks of an owner's documents, the ones of every conversation and the ones of
+// ConversationID, for the ones closest to Vector.
+type Query struct {
+	OwnerID        string
+	ConversationID string
+	Vector         []float32
+	Limit          int
+}
+
+// Index stores documents and searches their chunks by similarity.
+type Index interface {
+	// Add stores the document with its chunks.
+	Add(ctx context.Context, doc *Document, chunks []Chunk) error
+	// Search returns the q.Limit chunks visible to the query closest to its vector, the closest first.
+	Search(ctx context.Context, q Query) ([]Match, error)
+}
+
+var (
+	_ Index = (*MemoryIndex)(nil)
+	_ Index = (*MongoIndex)(nil)
+)
+
+// Library splits documents into chunks, embeds them and stores them in an index, then searches them.
+type Library struct {
+	embedder  Embedder
+	index     Index
+	chunkSize int
+	overlap   int
+}
+
+// Default chunking: passages of about a paragraph or two, overlapping so a sentence cut at a boundary is still
+// found whole in one of them.
+const (
+	DefaultChunkSize = 1200
+	DefaultOverlap   = 200
+)
+
+// embedBatch is the number of chunks embedded per request.
+const embedBatch = 64
+
+// NewLibrary creates a library embedding with embedder and storing in index.
+func NewLibrary(embedder Embedder, index Index) *Library {
+	return &Library{embedder: embedder, index: index, chunkSize: DefaultChunkSize, overlap: DefaultOverlap}
+}
+
+// Add splits the text of the document into chunks, embeds them and stores them. It sets doc.Chunks.
+func (l *Library) Add(ctx context.Context, doc *Document, text string) error {
+	pieces := Split(text, l.chunkSize, l.overlap)
+	if len(pieces) == 0 {
+		return errors.New("document has no text")
+	}
+
+	chunks := make([]Chunk, len(pieces))
+	for start := 0; start < len(pieces); start += embedBatch {
+		batch := pieces[start:min(start+embedBatch, len(pieces))]
+
+		vectors, err := l.embedder.Embed(ctx, batch)
+		if err != nil {
+			return fmt.Errorf("failed to embed document: %w", err)
+		}
+		if len(vectors) != len(batch) {
+			return fmt.Errorf("failed to embed document: got %d embeddings for %d chunks", len(vectors), len(batch))
+		}
+
+		for i, vector := range vectors {
+			chunks[start+i] = Chunk{
+				DocumentID:     doc.ID,
+				DocumentName:   doc.Name,
+				OwnerID:        doc.OwnerID,
+				ConversationID: doc.ConversationID,
+				Index:          start + i,
+				Text:           batch[i],
+				Vector:         vector,
+			}
+		}
+	}
+
+	doc.Chunks = len(chunks)
+	return l.index.Add(ctx, doc, chunks)
+}
+
+// Search returns the limit chunks of the owner's documents closest to the query, among the ones of every
+// conversation and the ones of conversationID.
+func (l *Library) Search(ctx context.Context, ownerID, conversationID, query string, limit int) ([]Match, error) {
+	vectors, err := l.embedder.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("failed to embed query: got %d embeddings", len(vectors))
+	}
+
+	return l.index.Search(ctx, Query{OwnerID: ownerID, ConversationID: conversationID, Vector: vectors[0], Limit: limit})
+}
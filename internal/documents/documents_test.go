@@ -0,0 +1,259 @@
+package documents_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/acai-travel/tech-challenge/internal/chat/testing"
+	"github.com/acai-travel/tech-challenge/internal/documents"
+	"github.com/google/go-cmp/cmp"
+	"github.com/openai/openai-go/v2/option"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSplit(t *testing.T) {
+	t.Run("packs paragraphs", func(t *testing.T) {
+		text := "Day 1: Barcelona.\r\n\r\nDay 2: Girona.\n\nDay 3: Figueres, the Dalí museum."
+
+		got := documents.Split(text, 40, 0)
+		want := []string{"Day 1: Barcelona.\n\nDay 2: Girona.", "Day 3: Figueres, the Dalí museum."}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Split() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("cuts long paragraphs along words, with overlap", func(t *testing.T) {
+		text := "one two three four five six seven eight nine ten"
+
+		got := documents.Split(text, 20, 10)
+		want := []string{"one two three four", "four five six seven", "six seven eight nine", "nine ten"}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Split() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("keeps chunks within size", func(t *testing.T) {
+		text := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 200)
+
+		chunks := documents.Split(text, documents.DefaultChunkSize, documents.DefaultOverlap)
+		if len(chunks) < 8 {
+			t.Errorf("Split() = %d chunks, want at least 8", len(chunks))
+		}
+		for i, chunk := range chunks {
+			if len(chunk) > documents.DefaultChunkSize {
+				t.Errorf("chunk %d is %d bytes long", i, len(chunk))
+			}
+		}
+	})
+
+	t.Run("no text", func(t *testing.T) {
+		if got := documents.Split(" \n\n \n", 100, 10); len(got) != 0 {
+			t.Errorf("Split() = %q, want no chunks", got)
+		}
+	})
+}
+
+func TestHashingEmbedder(t *testing.T) {
+	embedder := &documents.HashingEmbedder{}
+
+	vectors, err := embedder.Embed(context.Background(), []string{
+		"Checked baggage allowance: two bags of 23 kg.",
+		"How many kg of checked baggage can I bring?",
+		"The hotel breakfast is served from 7 to 10.",
+		"",
+	})
+	if err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+
+	for i, vector := range vectors[:3] {
+		if len(vector) != documents.DefaultHashingDimensions {
+			t.Errorf("vector %d has %d dimensions", i, len(vector))
+		}
+
+		var norm float64
+		for _, v := range vector {
+			norm += float64(v) * float64(v)
+		}
+		if math.Abs(norm-1) > 1e-5 {
+			t.Errorf("vector %d has norm %f, want 1", i, norm)
+		}
+	}
+
+	if related, unrelated := dot(vectors[0], vectors[1]), dot(vectors[0], vectors[2]); related <= unrelated {
+		t.Errorf("related texts are less similar (%f) than unrelated ones (%f)", related, unrelated)
+	}
+
+	again, _ := embedder.Embed(context.Background(), []string{"Checked baggage allowance: two bags of 23 kg."})
+	if diff := cmp.Diff(vectors[0], again[0]); diff != "" {
+		t.Errorf("Embed() is not deterministic (-first +second):\n%s", diff)
+	}
+}
+
+func dot(a, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
+
+// indexes returns every Index implementation the contract is checked against. MongoDB is only included when
+// TEST_STORAGE_BACKEND=mongo, since it needs a running database.
+func indexes() map[string]documents.Index {
+	all := map[string]documents.Index{"memory": documents.NewMemoryIndex()}
+
+	if os.Getenv("TEST_STORAGE_BACKEND") == "mongo" {
+		index := documents.NewMongoIndex(ConnectMongo())
+		if err := index.CreateIndexes(context.Background()); err != nil {
+			panic(err)
+		}
+		all["mongo"] = index
+	}
+
+	return all
+}
+
+func TestOpenAIEmbedder(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, body)
+	}))
+	defer srv.Close()
+
+	embedder := documents.NewOpenAIEmbedder("", option.WithBaseURL(srv.URL), option.WithAPIKey("test-key"), option.WithMaxRetries(0))
+
+	// Embeddings may come back in any order.
+	body = `{"object": "list", "model": "text-embedding-3-small", "data": [
+		{"object": "embedding", "index": 1, "embedding": [0, 1]},
+		{"object": "embedding", "index": 0, "embedding": [1, 0]}
+	]}`
+	vectors, err := embedder.Embed(context.Background(), []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+	if diff := cmp.Diff([][]float32{{1, 0}, {0, 1}}, vectors); diff != "" {
+		t.Errorf("Embed() mismatch (-want +got):\n%s", diff)
+	}
+
+	body = `{"object": "list", "model": "text-embedding-3-small", "data": [{"object": "embedding", "index": 0, "embedding": [1, 0]}]}`
+	if _, err := embedder.Embed(context.Background(), []string{"a", "b"}); err == nil {
+		t.Error("Embed() with a missing embedding succeeded")
+	}
+}
+
+func TestLibrary(t *testing.T) {
+	ctx := context.Background()
+
+	for name, index := range indexes() {
+		t.Run(name, func(t *testing.T) {
+			library := documents.NewLibrary(&documents.HashingEmbedder{}, index)
+
+			// Owners are unique to the run, as the database may hold documents of earlier ones.
+			alice, bob := "alice-"+primitive.NewObjectID().Hex(), "bob-"+primitive.NewObjectID().Hex()
+
+			add := func(owner, conversation, name, text string) *documents.Document {
+				doc := &documents.Document{ID: primitive.NewObjectID(), OwnerID: owner, ConversationID: conversation, Name: name, CreatedAt: time.Now()}
+				if err := library.Add(ctx, doc, text); err != nil {
+					t.Fatalf("Add() error = %v", err)
+				}
+				return doc
+			}
+
+			policy := add(alice, "", "policy.md", "Checked baggage allowance: two bags of 23 kg each.\n\nCabin baggage: one bag of 8 kg.")
+			add(alice, "trip-to-rome", "itinerary.txt", "Flight to Rome on May 3rd, hotel near the Pantheon, checked baggage included.")
+			add(bob, "", "bob.txt", "Checked baggage allowance: one bag of 20 kg.")
+
+			if policy.Chunks != 1 {
+				t.Errorf("policy has %d chunks, want 1", policy.Chunks)
+			}
+
+			search := func(conversation string) []string {
+				t.Helper()
+
+				matches, err := library.Search(ctx, alice, conversation, "How much checked baggage can I bring?", 5)
+				if err != nil {
+					t.Fatalf("Search() error = %v", err)
+				}
+
+				var names []string
+				for _, m := range matches {
+					names = append(names, m.DocumentName)
+				}
+				return names
+			}
+
+			if diff := cmp.Diff([]string{"policy.md", "itinerary.txt"}, search("trip-to-rome")); diff != "" {
+				t.Errorf("Search() in the conversation mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff([]string{"policy.md"}, search("another-trip")); diff != "" {
+				t.Errorf("Search() in another conversation mismatch (-want +got):\n%s", diff)
+			}
+
+			if err := library.Add(ctx, &documents.Document{ID: primitive.NewObjectID(), OwnerID: alice}, "\n\n"); err == nil {
+				t.Error("Add() of an empty document succeeded")
+			}
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		content     string
+		want        string
+		wantType    string
+		wantErr     error
+	}{
+		{name: "plain text", contentType: "text/plain", content: "Gate B32", want: "Gate B32", wantType: "text/plain"},
+		{name: "markdown with charset", contentType: "text/markdown; charset=utf-8", content: "# Trip", want: "# Trip", wantType: "text/markdown; charset=utf-8"},
+		{name: "detected", content: "\uFEFFHotel Arts", want: "Hotel Arts", wantType: "text/plain; charset=utf-8"},
+		{name: "json", contentType: "application/json", content: `{"pnr": "X7K2P"}`, want: `{"pnr": "X7K2P"}`, wantType: "application/json"},
+		{name: "image", contentType: "image/png", content: "\x89PNG\r\n\x1a\n", wantErr: documents.ErrUnsupportedType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotType, err := documents.Text(tt.contentType, []byte(tt.content))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Text() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want || gotType != tt.wantType {
+				t.Errorf("Text() = %q, %q, want %q, %q", got, gotType, tt.want, tt.wantType)
+			}
+		})
+	}
+
+	if _, _, err := documents.Text("text/plain", []byte{0xff, 0xfe}); err == nil {
+		t.Error("Text() of invalid UTF-8 succeeded")
+	}
+
+	t.Run("pdf", func(t *testing.T) {
+		content, err := os.ReadFile("pdf/testdata/booking.pdf")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, gotType, err := documents.Text("", content)
+		if err != nil {
+			t.Fatalf("Text() error = %v", err)
+		}
+		if !strings.Contains(got, "\n\nChecked baggage allowance: one bag of 23 kg per passenger.") || gotType != "application/pdf" {
+			t.Errorf("Text() = %q, %q", got, gotType)
+		}
+
+		if _, _, err := documents.Text("application/pdf", []byte("%PDF-1.7\n")); err == nil {
+			t.Error("Text() of a PDF without pages succeeded")
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package documents
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/openai/openai-go/v2"
+	"github.com/openai/openai-go/v2/option"
+)
+
+// Embedder turns texts into vectors, closer for texts of closer meaning. Every vector of an embedder has the
+// same length.
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+var (
+	_ Embedder = (*HashingEmbedder)(nil)
+	_ Embedder = (*OpenAIEmbedder)(nil)
+)
+
+// DefaultHashingDimensions is the length of the vectors of HashingEmbedders without one.
+const DefaultHashingDimensions = 512
+
+// HashingEmbedder embeds texts locally and deterministically, by hashing their words and word pairs into a
+// vector of counts. Texts sharing words end up close, regardless of meaning, which is enough for tests and
+// offline use but no match for a model.
+type HashingEmbedder struct {
+	Dimensions int
+}
+
+func (e *HashingEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	dimensions := e.Dimensions
+	if dimensions <= 0 {
+		dimensions = DefaultHashingDimensions
+	}
+
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vector := make([]float32, dimensions)
+
+		words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		})
+		for j, word := range words {
+			add(vector, word, 1)
+			if j > 0 {
+				add(vector, words[j-1]+" "+word, 0.5)
+			}
+		}
+
+		vectors[i] = normalize(vector)
+	}
+
+	return vectors, nil
+}
+
+// add adds weight to the dimension feature hashes to, with a sign from the hash too, so collisions tend to
+// cancel out rather than add up.
+func add(vector []float32, feature string, weight float32) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(feature))
+	sum := h.Sum64()
+
+	if sum>>63 == 1 {
+		weight = -weight
+	}
+	vector[sum%uint64(len(vector))] += weight
+}
+
+// normalize scales the vector to a length of 1, so similarities are dot products. Zero vectors are left as is.
+func normalize(vector []float32) []float32 {
+	var norm float64
+	for _, v := range vector {
+		norm += float64(v) * float64(v)
+	}
+	if norm == 0 {
+		return vector
+	}
+
+	scale := float32(1 / math.Sqrt(norm))
+	for i := range vector {
+		vector[i] *= scale
+	}
+	return vector
+}
+
+// cosine returns the cosine similarity of two vectors, 0 when either is zero or their lengths differ.
+func cosine(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+
+	return dot / math.Sqrt(na*nb)
+}
+
+// DefaultEmbeddingModel is the model of OpenAIEmbedders without one.
+const DefaultEmbeddingModel = "text-embedding-3-small"
+
+// OpenAIEmbedder embeds texts with the OpenAI embeddings API, or any server implementing it.
+type OpenAIEmbedder struct {
+	cli   openai.Client
+	model string
+}
+
+// NewOpenAIEmbedder creates an embedder using model, DefaultEmbeddingModel when empty. Without options the client
+// is configured from the OPENAI_API_KEY and OPENAI_BASE_URL environment variables.
+func NewOpenAIEmbedder(model string, opts ...option.RequestOption) *OpenAIEmbedder {
+	if model == "" {
+		model = DefaultEmbeddingModel
+	}
+	return &OpenAIEmbedder{cli: openai.NewClient(opts...), model: model}
+}
+
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	resp, err := e.cli.Embeddings.New(ctx, openai.EmbeddingNewParams{
+		Model: e.model,
+		Input: openai.EmbeddingNewParamsInputUnion{OfArrayOfStrings: texts},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, data := range resp.Data {
+		if data.Index < 0 || int(data.Index) >= len(texts) {
+			return nil, fmt.Errorf("embedding index %d out of range", data.Index)
+		}
+
+		vector := make([]float32, len(data.Embedding))
+		for i, v := range data.Embedding {
+			vector[i] = float32(v)
+		}
+		vectors[data.Index] = vector
+	}
+
+	// A text left without an embedding could never be found.
+	for i, vector := range vectors {
+		if vector == nil {
+			return nil, fmt.Errorf("no embedding returned for text %d", i)
+		}
+	}
+
+	return vectors, nil
+}
@@ -0,0 +1,144 @@
+package documents
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// rank sorts matches by score, the best first, and keeps the limit best ones, all of them when limit is 0.
+func rank(matches []Match, limit int) []Match {
+	slices.SortStableFunc(matches, func(a, b Match) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Index, b.Index))
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// MemoryIndex keeps documents in process memory, and searches them exhaustively. It is meant for tests and
+// ephemeral deployments, everything is lost when the process exits.
+type MemoryIndex struct {
+	mu        sync.RWMutex
+	documents []Document
+	chunks    []Chunk
+}
+
+// NewMemoryIndex creates an empty in-memory index.
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{}
+}
+
+func (x *MemoryIndex) Add(ctx context.Context, doc *Document, chunks []Chunk) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.documents = append(x.documents, *doc)
+	x.chunks = append(x.chunks, chunks...)
+	return nil
+}
+
+func (x *MemoryIndex) Search(ctx context.Context, q Query) ([]Match, error) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	var matches []Match
+	for _, chunk := range x.chunks {
+		if chunk.visibleIn(q.OwnerID, q.ConversationID) {
+			matches = append(matches, Match{Chunk: chunk, Score: cosine(q.Vector, chunk.Vector)})
+		}
+	}
+
+	return rank(matches, q.Limit), nil
+}
+
+const (
+	documentCollection = "documents"
+	chunkCollection    = "document_chunks"
+)
+
+// MongoIndex stores documents and their chunks in MongoDB. Searches load the vectors of the owner's chunks and
+// compare them in process, which needs no vector search support from the server and is fast enough for the
+// documents of a user.
+type MongoIndex struct {
+	conn *mongo.Database
+}
+
+// NewMongoIndex creates an index in the database.
+func NewMongoIndex(conn *mongo.Database) *MongoIndex {
+	return &MongoIndex{conn: conn}
+}
+
+// CreateIndexes creates the index searches filter chunks with, by owner and conversation. Existing indexes are
+// left as they are, so it can run on every start.
+func (x *MongoIndex) CreateIndexes(ctx context.Context) error {
+	_, err := x.conn.Collection(chunkCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "owner_id", Value: 1}, {Key: "conversation_id", Value: 1}},
+	})
+	return err
+}
+
+// Add stores the chunks, then the document. Chunks stored before a failure are removed, so a document is never
+// left half searchable.
+func (x *MongoIndex) Add(ctx context.Context, doc *Document, chunks []Chunk) error {
+	docs := make([]any, len(chunks))
+	for i := range chunks {
+		docs[i] = chunks[i]
+	}
+
+	_, err := x.conn.Collection(chunkCollection).InsertMany(ctx, docs)
+	if err == nil {
+		_, err = x.conn.Collection(documentCollection).InsertOne(ctx, doc)
+	}
+
+	if err != nil {
+		// The removal is not canceled with the request, which may be what the insertion failed on.
+		_, _ = x.conn.Collection(chunkCollection).DeleteMany(context.WithoutCancel(ctx), bson.M{"document_id": doc.ID})
+		return err
+	}
+
+	return nil
+}
+
+func (x *MongoIndex) Search(ctx context.Context, q Query) ([]Match, error) {
+	filter := bson.M{
+		"owner_id": q.OwnerID,
+		"$or": bson.A{
+			bson.M{"conversation_id": bson.M{"$exists": false}},
+			bson.M{"conversation_id": q.ConversationID},
+		},
+	}
+
+	cursor, err := x.conn.Collection(chunkCollection).Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 0}))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = cursor.Close(ctx)
+	}()
+
+	var matches []Match
+	for cursor.Next(ctx) {
+		var chunk Chunk
+
+		if err := cursor.Decode(&chunk); err != nil {
+			return nil, err
+		}
+
+		matches = append(matches, Match{Chunk: chunk, Score: cosine(q.Vector, chunk.Vector)})
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return rank(matches, q.Limit), nil
+}
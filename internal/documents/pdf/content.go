@@ -0,0 +1,372 @@
+package pdf
+
+import (
+	"errors"
+	"io"
+	"math"
+	"strings"
+)
+
+// maxFormDepth bounds how deep form XObjects, content drawn by other content, are followed.
+const maxFormDepth = 10
+
+// matrix is a transformation matrix [a b c d e f], as in PDF.
+type matrix [6]float64
+
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+// mul returns m × n, the transformation m followed by n.
+func (m matrix) mul(n matrix) matrix {
+	return matrix{
+		m[0]*n[0] + m[1]*n[2],
+		m[0]*n[1] + m[1]*n[3],
+		m[2]*n[0] + m[3]*n[2],
+		m[2]*n[1] + m[3]*n[3],
+		m[4]*n[0] + m[5]*n[2] + n[4],
+		m[4]*n[1] + m[5]*n[3] + n[5],
+	}
+}
+
+// state is the part of the graphics state that matters for text.
+type state struct {
+	ctm                                              matrix
+	font                                             *font
+	size, charSpace, wordSpace, scale, leading, rise float64
+}
+
+// defaultFont shows text when no font was selected, which is invalid but seen.
+var defaultFont = &font{encoding: &winAnsiEncoding}
+
+// extractor writes the text shown by content streams in the order it is shown, with spaces and line breaks where
+// the positions of the glyphs leave gaps.
+type extractor struct {
+	doc   *document
+	fonts map[ref]*font
+	out   strings.Builder
+
+	state
+	saved    []state
+	tm, tlm  matrix // Text matrix, and text line matrix.
+	forms    map[ref]bool
+	depth    int
+	newlines int // Line breaks written since the last glyph, to write no more than needed.
+
+	// Where the last glyph ended, in device space, and the height of its font, if any was shown yet.
+	shown        bool
+	lastX, lastY float64
+	lastHeight   float64
+}
+
+func newExtractor(doc *document) *extractor {
+	return &extractor{doc: doc, fonts: map[ref]*font{}, forms: map[ref]bool{}}
+}
+
+// page runs the content of a page.
+func (e *extractor) page(content []byte, resources any) error {
+	e.state = state{ctm: identity, scale: 1}
+	e.saved = nil
+	e.tm, e.tlm = identity, identity
+
+	if err := e.run(content, resources); err != nil {
+		return err
+	}
+
+	e.newline(2)
+	return nil
+}
+
+// run interprets a content stream, drawing with the resources.
+func (e *extractor) run(content []byte, resources any) error {
+	p := &parser{data: content}
+
+	var operands []any
+	for {
+		v, err := p.object()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		op, ok := v.(keyword)
+		if !ok {
+			operands = append(operands, v)
+			continue
+		}
+
+		if op == "ID" {
+			skipInlineImage(p)
+		} else {
+			e.operator(op, operands, resources)
+		}
+		operands = operands[:0]
+	}
+}
+
+// skipInlineImage skips the data of an inline image, after its ID operator, up to its EI operator.
+func skipInlineImage(p *parser) {
+	start := p.pos + 1
+	for i := start; i+2 <= len(p.data); i++ {
+		if p.data[i] == 'E' && p.data[i+1] == 'I' && isSpace(p.data[i-1]) && (i+2 == len(p.data) || isSpace(p.data[i+2])) {
+			p.pos = i + 2
+			return
+		}
+	}
+	p.pos = len(p.data)
+}
+
+// numbers returns the last n operands, when they are numbers.
+func numbers(operands []any, n int) ([]float64, bool) {
+	if len(operands) < n {
+		return nil, false
+	}
+
+	out := make([]float64, n)
+	for i, v := range operands[len(operands)-n:] {
+		f, ok := v.(float64)
+		if !ok {
+			return nil, false
+		}
+		out[i] = f
+	}
+	return out, true
+}
+
+func (e *extractor) operator(op keyword, operands []any, resources any) {
+	switch op {
+	case "q":
+		e.saved = append(e.saved, e.state)
+	case "Q":
+		if len(e.saved) > 0 {
+			e.state = e.saved[len(e.saved)-1]
+			e.saved = e.saved[:len(e.saved)-1]
+		}
+	case "cm":
+		if m, ok := numbers(operands, 6); ok {
+			e.ctm = matrix(m).mul(e.ctm)
+		}
+	case "BT":
+		e.tm, e.tlm = identity, identity
+	case "Tf":
+		if len(operands) >= 2 {
+			if n, ok := operands[len(operands)-2].(name); ok {
+				e.font = e.fontResource(resources, n)
+			}
+			e.size, _ = operands[len(operands)-1].(float64)
+		}
+	case "Tc", "Tw", "Tz", "TL", "Ts":
+		n, ok := numbers(operands, 1)
+		if !ok {
+			return
+		}
+
+		switch op {
+		case "Tc":
+			e.charSpace = n[0]
+		case "Tw":
+			e.wordSpace = n[0]
+		case "Tz":
+			e.scale = n[0] / 100
+		case "TL":
+			e.leading = n[0]
+		case "Ts":
+			e.rise = n[0]
+		}
+	case "Td", "TD":
+		if n, ok := numbers(operands, 2); ok {
+			if op == "TD" {
+				e.leading = -n[1]
+			}
+			e.moveLine(n[0], n[1])
+		}
+	case "Tm":
+		if m, ok := numbers(operands, 6); ok {
+			e.tm, e.tlm = matrix(m), matrix(m)
+		}
+	case "T*":
+		e.moveLine(0, -e.leading)
+	case "Tj", "'", `"`:
+		if len(operands) == 0 {
+			return
+		}
+
+		if op == `"` {
+			if n, ok := numbers(operands[:len(operands)-1], 2); ok {
+				e.wordSpace, e.charSpace = n[0], n[1]
+			}
+		}
+		if op != "Tj" {
+			e.moveLine(0, -e.leading)
+		}
+
+		if s, ok := operands[len(operands)-1].(str); ok {
+			e.show(s)
+		}
+	case "TJ":
+		if len(operands) == 0 {
+			return
+		}
+
+		items, _ := operands[len(operands)-1].(array)
+		for _, item := range items {
+			switch item := item.(type) {
+			case str:
+				e.show(item)
+			case float64:
+				// Adjustments are in thousandths of the font size, moving the next glyphs back.
+				e.tm = matrix{1, 0, 0, 1, -item / 1000 * e.size * e.scale, 0}.mul(e.tm)
+			}
+		}
+	case "Do":
+		if len(operands) > 0 {
+			if n, ok := operands[len(operands)-1].(name); ok {
+				e.form(resources, n)
+			}
+		}
+	}
+}
+
+func (e *extractor) moveLine(tx, ty float64) {
+	e.tlm = matrix{1, 0, 0, 1, tx, ty}.mul(e.tlm)
+	e.tm = e.tlm
+}
+
+// fontResource returns the font of the resources with the name, loading it the first time it is used.
+func (e *extractor) fontResource(resources any, n name) *font {
+	v := e.doc.dict(e.doc.dict(resources)["Font"])[n]
+	if v == nil {
+		return nil
+	}
+
+	r, ok := v.(ref)
+	if !ok {
+		return e.doc.font(v)
+	}
+
+	if f, ok := e.fonts[r]; ok {
+		return f
+	}
+	f := e.doc.font(v)
+	e.fonts[r] = f
+	return f
+}
+
+// form runs the content of the form XObject of the resources with the name, if it is one.
+func (e *extractor) form(resources any, n name) {
+	v := e.doc.dict(e.doc.dict(resources)["XObject"])[n]
+	s, ok := e.doc.resolve(v).(*stream)
+	if !ok || e.doc.name(s.dict["Subtype"]) != "Form" || e.depth >= maxFormDepth {
+		return
+	}
+
+	// Forms drawing themselves would never end.
+	if r, ok := v.(ref); ok {
+		if e.forms[r] {
+			return
+		}
+		e.forms[r] = true
+		defer delete(e.forms, r)
+	}
+
+	content, err := e.doc.decode(s)
+	if err != nil {
+		return
+	}
+
+	formResources := s.dict["Resources"]
+	if formResources == nil {
+		formResources = resources
+	}
+
+	saved, tm, tlm := e.state, e.tm, e.tlm
+	if m := e.doc.array(s.dict["Matrix"]); len(m) == 6 {
+		var fm matrix
+		for i := range fm {
+			fm[i] = e.doc.number(m[i])
+		}
+		e.ctm = fm.mul(e.ctm)
+	}
+
+	e.depth++
+	// Errors in a form leave out its text only.
+	_ = e.run(content, formResources)
+	e.depth--
+
+	e.state, e.tm, e.tlm = saved, tm, tlm
+}
+
+// show writes the text of the string, and moves the text matrix past its glyphs.
+func (e *extractor) show(s str) {
+	f := e.font
+	if f == nil {
+		f = defaultFont
+	}
+
+	for _, g := range f.decode(s) {
+		start := matrix{e.size * e.scale, 0, 0, e.size, 0, e.rise}.mul(e.tm).mul(e.ctm)
+
+		advance := g.width/1000*e.size + e.charSpace
+		if g.space {
+			advance += e.wordSpace
+		}
+		e.tm = matrix{1, 0, 0, 1, advance * e.scale, 0}.mul(e.tm)
+
+		if g.text == "" {
+			continue
+		}
+
+		end := matrix{1, 0, 0, 1, 0, e.rise}.mul(e.tm).mul(e.ctm)
+		e.write(g.text, start[4], start[5], end[4], math.Hypot(start[2], start[3]))
+	}
+}
+
+// write writes the text of a glyph drawn from x to endX at y, in a font of the height, after a line break or a
+// space when it is not next to the previous one.
+func (e *extractor) write(text string, x, y, endX, height float64) {
+	if e.shown {
+		lineHeight := max(height, e.lastHeight)
+		switch dy := math.Abs(y - e.lastY); {
+		case dy > 1.8*lineHeight:
+			e.newline(2)
+		case dy > 0.5*lineHeight:
+			e.newline(1)
+		case x > e.lastX+0.15*height || x < e.lastX-height:
+			// Gaps, and moves back, e.g. to another column, separate words.
+			if e.newlines == 0 && !strings.HasSuffix(e.out.String(), " ") && !strings.HasPrefix(text, " ") {
+				e.out.WriteByte(' ')
+			}
+		}
+	}
+
+	// Line breaks are only written before text, so that pages do not end with them.
+	if e.out.Len() > 0 {
+		e.out.WriteString(strings.Repeat("\n", e.newlines))
+	}
+	e.newlines = 0
+
+	e.out.WriteString(text)
+	e.shown = true
+	e.lastX, e.lastY, e.lastHeight = endX, y, height
+}
+
+// newline ends the line, with n line breaks, unless there are already as many.
+func (e *extractor) newline(n int) {
+	e.newlines = max(e.newlines, n)
+}
+
+// text returns the text written, with lines trimmed and ligatures spelled out.
+func (e *extractor) text() string {
+	lines := strings.Split(ligatures.Replace(e.out.String()), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+
+	text := strings.Join(lines, "\n")
+	for strings.Contains(text, "\n\n\n") {
+		text = strings.ReplaceAll(text, "\n\n\n", "\n\n")
+	}
+	return strings.TrimSpace(text)
+}
+
+var ligatures = strings.NewReplacer("ﬀ", "ff", "ﬁ", "fi", "ﬂ", "fl", "ﬃ", "ffi", "ﬄ", "ffl", "\u00AD", "")
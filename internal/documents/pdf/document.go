@@ -0,0 +1,312 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/ascii85"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// maxStreamSize bounds the decoded size of a stream, so small files cannot expand into huge ones.
+const maxStreamSize = 64 << 20
+
+// ErrEncrypted is returned for encrypted PDFs, their text cannot be read without decrypting them.
+var ErrEncrypted = errors.New("encrypted PDFs are not supported")
+
+// objectStart matches the start of an indirect object definition, e.g. "12 0 obj".
+var objectStart = regexp.MustCompile(`(\d+)\s+\d+\s+obj\b`)
+
+// document holds the objects of a PDF file.
+//
+// Objects are found by scanning the file rather than through its cross-reference table, which is often broken
+// and, since PDF 1.5, may be compressed. Later definitions replace earlier ones, as incremental updates do.
+type document struct {
+	objects map[int]any
+	trailer dict
+}
+
+func load(data []byte) (*document, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("%PDF-")) {
+		return nil, errors.New("not a PDF file")
+	}
+
+	doc := &document{objects: map[int]any{}, trailer: dict{}}
+
+	for pos := 0; pos < len(data); {
+		loc := objectStart.FindSubmatchIndex(data[pos:])
+		if loc == nil {
+			break
+		}
+
+		num, _ := strconv.Atoi(string(data[pos+loc[2] : pos+loc[3]]))
+		p := &parser{data: data, pos: pos + loc[1]}
+		v, err := p.indirect()
+		if err != nil {
+			// Not an object after all, e.g. a match in binary data: scanning goes on after it.
+			pos += loc[1]
+			continue
+		}
+
+		doc.objects[num] = v
+		pos = p.pos
+
+		// Cross-reference streams double as trailers.
+		if s, ok := v.(*stream); ok && s.dict["Type"] == name("XRef") {
+			doc.addTrailer(s.dict)
+		}
+	}
+
+	for _, i := range trailers.FindAllIndex(data, -1) {
+		p := &parser{data: data, pos: i[1] - len("<<")}
+		if v, err := p.object(); err == nil {
+			if d, ok := v.(dict); ok {
+				doc.addTrailer(d)
+			}
+		}
+	}
+
+	if doc.trailer["Encrypt"] != nil {
+		return nil, ErrEncrypted
+	}
+
+	doc.loadObjectStreams()
+	return doc, nil
+}
+
+// trailers matches the start of trailers, e.g. "trailer <<".
+var trailers = regexp.MustCompile(`trailer\s*<<`)
+
+// addTrailer merges the entries of a trailer, the ones of later ones winning.
+func (d *document) addTrailer(t dict) {
+	for k, v := range t {
+		d.trailer[k] = v
+	}
+}
+
+// loadObjectStreams adds the objects compressed in object streams, the ones also defined directly excepted.
+// Malformed object streams are skipped, like malformed objects.
+func (d *document) loadObjectStreams() {
+	var nums []int
+	for num, v := range d.objects {
+		if s, ok := v.(*stream); ok && s.dict["Type"] == name("ObjStm") {
+			nums = append(nums, num)
+		}
+	}
+	slices.Sort(nums)
+
+	for _, num := range nums {
+		s := d.objects[num].(*stream)
+		data, err := d.decode(s)
+		if err != nil {
+			continue
+		}
+
+		n, first := d.int(s.dict["N"]), d.int(s.dict["First"])
+		if first < 0 || first > len(data) {
+			continue
+		}
+
+		// The header lists the number and offset of each object, from first on.
+		header := &parser{data: data[:first]}
+		for range n {
+			num, _ := header.object()
+			offset, _ := header.object()
+
+			num0, ok1 := num.(float64)
+			offset0, ok2 := offset.(float64)
+			if !ok1 || !ok2 || offset0 < 0 || offset0 > float64(len(data)-first) {
+				break
+			}
+			if _, ok := d.objects[int(num0)]; ok {
+				continue
+			}
+
+			p := &parser{data: data, pos: first + int(offset0)}
+			if v, err := p.object(); err == nil {
+				d.objects[int(num0)] = v
+			}
+		}
+	}
+}
+
+// resolve returns the object v refers to, or v when it is not a reference.
+func (d *document) resolve(v any) any {
+	// References to references are legal, but cycles of them must not hang.
+	for range 32 {
+		r, ok := v.(ref)
+		if !ok {
+			return v
+		}
+		v = d.objects[r.num]
+	}
+	return nil
+}
+
+func (d *document) dict(v any) dict {
+	switch v := d.resolve(v).(type) {
+	case dict:
+		return v
+	case *stream:
+		return v.dict
+	}
+	return nil
+}
+
+func (d *document) array(v any) array {
+	a, _ := d.resolve(v).(array)
+	return a
+}
+
+func (d *document) number(v any) float64 {
+	n, _ := d.resolve(v).(float64)
+	return n
+}
+
+func (d *document) int(v any) int {
+	return int(d.number(v))
+}
+
+func (d *document) name(v any) name {
+	n, _ := d.resolve(v).(name)
+	return n
+}
+
+// decode returns the decoded data of the stream.
+func (d *document) decode(s *stream) ([]byte, error) {
+	filters := d.array(s.dict["Filter"])
+	if f := d.name(s.dict["Filter"]); f != "" {
+		filters = array{f}
+	}
+
+	params := d.array(s.dict["DecodeParms"])
+	if p := d.dict(s.dict["DecodeParms"]); p != nil {
+		params = array{p}
+	}
+
+	data := s.data
+	for i, f := range filters {
+		var p dict
+		if i < len(params) {
+			p = d.dict(params[i])
+		}
+
+		if predictor := d.int(p["Predictor"]); predictor > 1 {
+			return nil, fmt.Errorf("unsupported predictor %d", predictor)
+		}
+
+		var r io.Reader
+		switch f := d.name(f); f {
+		case "FlateDecode", "Fl":
+			zr, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decompress stream: %w", err)
+			}
+			r = zr
+		case "ASCIIHexDecode", "AHx":
+			data = []byte((&parser{data: data}).hex())
+			continue
+		case "ASCII85Decode", "A85":
+			data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte("<~"))
+			if end := bytes.Index(data, []byte("~>")); end >= 0 {
+				data = data[:end]
+			}
+			r = ascii85.NewDecoder(bytes.NewReader(data))
+		default:
+			return nil, fmt.Errorf("unsupported filter %s", f)
+		}
+
+		out, err := io.ReadAll(io.LimitReader(r, maxStreamSize+1))
+		// Truncated streams are common, what could be decoded of them is kept.
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("failed to decode stream: %w", err)
+		}
+		if len(out) > maxStreamSize {
+			return nil, errors.New("stream is too large")
+		}
+		data = out
+	}
+
+	return data, nil
+}
+
+// pages returns the page dictionaries in order, with the resources they inherit from the page tree filled in.
+func (d *document) pages() []dict {
+	root := d.dict(d.trailer["Root"])
+	if root == nil {
+		// Without a trailer, e.g. in a truncated file, the catalog is looked up.
+		for _, v := range d.objects {
+			if c, ok := v.(dict); ok && c["Type"] == name("Catalog") {
+				root = c
+				break
+			}
+		}
+	}
+
+	var pages []dict
+	visited := map[ref]bool{}
+
+	var walk func(node any, resources any)
+	walk = func(node any, resources any) {
+		if r, ok := node.(ref); ok {
+			if visited[r] {
+				return
+			}
+			visited[r] = true
+		}
+
+		n := d.dict(node)
+		if n == nil {
+			return
+		}
+		if res, ok := n["Resources"]; ok {
+			resources = res
+		}
+
+		if kids, ok := n["Kids"]; ok {
+			for _, kid := range d.array(kids) {
+				walk(kid, resources)
+			}
+			return
+		}
+
+		page := dict{}
+		for k, v := range n {
+			page[k] = v
+		}
+		page["Resources"] = resources
+		pages = append(pages, page)
+	}
+
+	walk(root["Pages"], nil)
+	return pages
+}
+
+// contents returns the decoded content streams of the page, one after the other.
+func (d *document) contents(page dict) ([]byte, error) {
+	streams := d.array(page["Contents"])
+	if streams == nil {
+		streams = array{page["Contents"]}
+	}
+
+	var out []byte
+	for _, v := range streams {
+		s, ok := d.resolve(v).(*stream)
+		if !ok {
+			continue
+		}
+
+		data, err := d.decode(s)
+		if err != nil {
+			return nil, err
+		}
+
+		// Pages may split their content in several streams, but only between tokens.
+		out = append(append(out, data...), '\n')
+	}
+	return out, nil
+}
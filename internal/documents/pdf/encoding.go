@@ -0,0 +1,76 @@
+package pdf
+
+import (
+	"strings"
+	"unicode"
+)
+
+// The text of the codes of the standard encodings of simple fonts, "" for the undefined ones.
+var (
+	winAnsiEncoding = newEncoding("€\uFFFD‚ƒ„…†‡ˆ‰Š‹Œ\uFFFDŽ\uFFFD\uFFFD‘’“”•–—˜™š›œ\uFFFDžŸ\u00A0¡¢£¤¥¦§¨©ª«¬\u00AD®¯°±²³´µ¶·¸¹º»¼½¾¿"+
+		"ÀÁÂÃÄÅÆÇÈÉÊËÌÍÎÏÐÑÒÓÔÕÖ×ØÙÚÛÜÝÞßàáâãäåæçèéêëìíîïðñòóôõö÷øùúûüýþÿ", map[byte]string{0xA0: " ", 0xAD: "-"})
+	macRomanEncoding = newEncoding("ÄÅÇÉÑÖÜáàâäãåçéèêëíìîïñóòôöõúùûü†°¢£§•¶ß®©™´¨≠ÆØ∞±≤≥¥µ∂∑∏π∫ªºΩæø"+
+		"¿¡¬√ƒ≈∆«»…\u00A0ÀÃÕŒœ–—“”‘’÷◊ÿŸ⁄€‹›ﬁﬂ‡·‚„‰ÂÊÁËÈÍÎÏÌÓÔ\uFFFDÒÚÛÙıˆ˜¯˘˙˚¸˝˛ˇ", map[byte]string{0xCA: " "})
+	standardEncoding = newEncoding("", map[byte]string{
+		0x27: "’", 0x60: "‘", 0xA1: "¡", 0xA2: "¢", 0xA3: "£", 0xA4: "⁄", 0xA5: "¥", 0xA6: "ƒ", 0xA7: "§", 0xA8: "¤",
+		0xA9: "'", 0xAA: "“", 0xAB: "«", 0xAC: "‹", 0xAD: "›", 0xAE: "fi", 0xAF: "fl", 0xB1: "–", 0xB2: "†", 0xB3: "‡",
+		0xB4: "·", 0xB6: "¶", 0xB7: "•", 0xB8: "‚", 0xB9: "„", 0xBA: "”", 0xBB: "»", 0xBC: "…", 0xBD: "‰", 0xBF: "¿",
+		0xC1: "`", 0xC2: "´", 0xC3: "ˆ", 0xC4: "˜", 0xC5: "¯", 0xC6: "˘", 0xC7: "˙", 0xC8: "¨", 0xCA: "˚", 0xCB: "¸",
+		0xCD: "˝", 0xCE: "˛", 0xCF: "ˇ", 0xD0: "—", 0xE1: "Æ", 0xE3: "ª", 0xE8: "Ł", 0xE9: "Ø", 0xEA: "Œ", 0xEB: "º",
+		0xF1: "æ", 0xF5: "ı", 0xF8: "ł", 0xF9: "ø", 0xFA: "œ", 0xFB: "ß",
+	})
+)
+
+// newEncoding returns the encoding with printable ASCII codes, the given codes from 128 on, U+FFFD for undefined
+// ones, and the given changes.
+func newEncoding(high string, changes map[byte]string) [256]string {
+	var e [256]string
+	for c := ' '; c <= '~'; c++ {
+		e[c] = string(c)
+	}
+
+	c := 128
+	for _, r := range high {
+		if r != unicode.ReplacementChar {
+			e[c] = string(r)
+		}
+		c++
+	}
+
+	for c, text := range changes {
+		e[c] = text
+	}
+	return e
+}
+
+// glyphNames maps the names of common glyphs to their text: the ones of WinAnsiEncoding, from space on, "." for
+// the undefined codes, and a few more.
+var glyphNames = func() map[string]string {
+	names := map[string]string{
+		"quoteright": "’", "quoteleft": "‘", "fi": "fi", "fl": "fl", "ff": "ff", "ffi": "ffi", "ffl": "ffl",
+		"dotlessi": "ı", "Lslash": "Ł", "lslash": "ł", "minus": "−", "fraction": "⁄", "hyphen": "-",
+		"nbspace": " ", "space": " ",
+	}
+
+	for i, name := range strings.Fields("space exclam quotedbl numbersign dollar percent ampersand quotesingle parenleft parenright asterisk " +
+		"plus comma hyphen period slash zero one two three four five six seven eight nine colon semicolon " +
+		"less equal greater question at A B C D E F G H I J K L M N O P Q R S T U V W X Y Z bracketleft " +
+		"backslash bracketright asciicircum underscore grave a b c d e f g h i j k l m n o p q r s t u v w x " +
+		"y z braceleft bar braceright asciitilde . Euro . quotesinglbase florin quotedblbase ellipsis dagger " +
+		"daggerdbl circumflex perthousand Scaron guilsinglleft OE . Zcaron . . quoteleft quoteright " +
+		"quotedblleft quotedblright bullet endash emdash tilde trademark scaron guilsinglright oe . zcaron " +
+		"Ydieresis nbspace exclamdown cent sterling currency yen brokenbar section dieresis copyright " +
+		"ordfeminine guillemotleft logicalnot hyphen registered macron degree plusminus twosuperior " +
+		"threesuperior acute mu paragraph periodcentered cedilla onesuperior ordmasculine guillemotright " +
+		"onequarter onehalf threequarters questiondown Agrave Aacute Acircumflex Atilde Adieresis Aring AE " +
+		"Ccedilla Egrave Eacute Ecircumflex Edieresis Igrave Iacute Icircumflex Idieresis Eth Ntilde Ograve " +
+		"Oacute Ocircumflex Otilde Odieresis multiply Oslash Ugrave Uacute Ucircumflex Udieresis Yacute " +
+		"Thorn germandbls agrave aacute acircumflex atilde adieresis aring ae ccedilla egrave eacute " +
+		"ecircumflex edieresis igrave iacute icircumflex idieresis eth ntilde ograve oacute ocircumflex " +
+		"otilde odieresis divide oslash ugrave uacute ucircumflex udieresis yacute thorn ydieresis ") {
+		if _, ok := names[name]; !ok && name != "." {
+			names[name] = winAnsiEncoding[' '+i]
+		}
+	}
+	return names
+}()
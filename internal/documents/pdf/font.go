@@ -0,0 +1,381 @@
+package pdf
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// font decodes the strings shown in a font into text, and measures them.
+type font struct {
+	// toUnicode maps codes to text, when the font has a ToUnicode CMap.
+	toUnicode *cmap
+	// encoding maps the codes of simple fonts to text.
+	encoding *[256]string
+	// codes splits strings into codes. Simple fonts have one-byte codes, composite ones mostly two-byte codes.
+	codes *cmap
+
+	widths       map[int]float64 // In thousandths of the font size, by code or CID.
+	defaultWidth float64
+}
+
+// glyph is a code of a string, and what it shows.
+type glyph struct {
+	text  string
+	width float64 // In thousandths of the font size, 0 when unknown.
+	space bool    // Whether it is the one-byte code 32, which word spacing applies to.
+}
+
+// font loads the font of the font dictionary. What cannot be read of it is left out, e.g. a malformed ToUnicode
+// CMap falls back on the encoding.
+func (d *document) font(v any) *font {
+	fd := d.dict(v)
+	f := &font{widths: map[int]float64{}}
+
+	if s, ok := d.resolve(fd["ToUnicode"]).(*stream); ok {
+		if data, err := d.decode(s); err == nil {
+			f.toUnicode = parseCMap(data)
+		}
+	}
+
+	if d.name(fd["Subtype"]) == "Type0" {
+		f.codes = &cmap{ranges: []codeRange{{lo: []byte{0, 0}, hi: []byte{0xff, 0xff}}}}
+		if s, ok := d.resolve(fd["Encoding"]).(*stream); ok {
+			if data, err := d.decode(s); err == nil {
+				if c := parseCMap(data); len(c.ranges) > 0 {
+					f.codes = c
+				}
+			}
+		}
+
+		descendants := d.array(fd["DescendantFonts"])
+		if len(descendants) == 0 {
+			return f
+		}
+
+		cid := d.dict(descendants[0])
+		f.defaultWidth = 1000
+		if dw, ok := d.resolve(cid["DW"]).(float64); ok {
+			f.defaultWidth = dw
+		}
+
+		// W lists widths either as "first [w1 w2 ...]" or as "first last w".
+		w := d.array(cid["W"])
+		for i := 0; i+1 < len(w); {
+			first := d.int(w[i])
+			if ws := d.array(w[i+1]); ws != nil {
+				for j, width := range ws {
+					f.widths[first+j] = d.number(width)
+				}
+				i += 2
+				continue
+			}
+
+			if i+2 >= len(w) {
+				break
+			}
+			last, width := d.int(w[i+1]), d.number(w[i+2])
+			for c := first; c <= last && c-first < 1<<16; c++ {
+				f.widths[c] = width
+			}
+			i += 3
+		}
+
+		return f
+	}
+
+	f.encoding = d.encoding(fd)
+
+	first := d.int(fd["FirstChar"])
+	for i, width := range d.array(fd["Widths"]) {
+		f.widths[first+i] = d.number(width)
+	}
+	if desc := d.dict(fd["FontDescriptor"]); desc != nil {
+		f.defaultWidth = d.number(desc["MissingWidth"])
+	}
+
+	return f
+}
+
+// encoding returns the encoding of a simple font, its base encoding with its differences applied.
+func (d *document) encoding(fd dict) *[256]string {
+	enc := fd["Encoding"]
+	base := d.name(enc)
+
+	var differences array
+	if e := d.dict(enc); e != nil {
+		base = d.name(e["BaseEncoding"])
+		differences = d.array(e["Differences"])
+	}
+
+	var out [256]string
+	switch base {
+	case "MacRomanEncoding":
+		out = macRomanEncoding
+	case "StandardEncoding":
+		out = standardEncoding
+	default:
+		// WinAnsiEncoding, and what most fonts without an encoding use in practice.
+		out = winAnsiEncoding
+	}
+
+	// Differences list codes, each followed by the names of the glyphs of it and the next codes.
+	code := 0
+	for _, v := range differences {
+		switch v := d.resolve(v).(type) {
+		case float64:
+			code = int(v)
+		case name:
+			if code >= 0 && code < 256 {
+				out[code] = glyphText(string(v))
+			}
+			code++
+		}
+	}
+
+	return &out
+}
+
+// decode splits the string into codes, and returns what they show.
+func (f *font) decode(s str) []glyph {
+	var glyphs []glyph
+	for len(s) > 0 {
+		n := 1
+		if f.codes != nil {
+			n = f.codes.codeLength([]byte(s))
+		}
+		n = min(n, len(s))
+
+		code := 0
+		for i := range n {
+			code = code<<8 | int(s[i])
+		}
+
+		g := glyph{width: f.defaultWidth, space: n == 1 && code == ' '}
+		if w, ok := f.widths[code]; ok {
+			g.width = w
+		}
+
+		ok := false
+		if f.toUnicode != nil {
+			g.text, ok = f.toUnicode.lookup(s[:n])
+		}
+		if !ok && f.encoding != nil && n == 1 {
+			g.text = f.encoding[code]
+		}
+
+		glyphs = append(glyphs, g)
+		s = s[n:]
+	}
+	return glyphs
+}
+
+// cmap is the part of a CMap that matters for text: the lengths of codes and the text they map to.
+type cmap struct {
+	ranges []codeRange
+	chars  map[string]string
+	blocks []charBlock
+}
+
+// codeRange is a codespace range, the codes between lo and hi byte by byte.
+type codeRange struct {
+	lo, hi []byte
+}
+
+// charBlock maps consecutive codes, from lo to hi, to consecutive characters, or to the strings of to.
+type charBlock struct {
+	lo, hi uint32
+	length int
+	first  []uint16 // UTF-16 of the text of lo, the last unit incremented for the next codes.
+	to     []string
+}
+
+// codeLength returns the length of the code the string starts with, per the codespace ranges.
+func (c *cmap) codeLength(s []byte) int {
+	for n := 1; n <= 4 && n <= len(s); n++ {
+		for _, r := range c.ranges {
+			if len(r.lo) != n {
+				continue
+			}
+
+			in := true
+			for i := range n {
+				in = in && s[i] >= r.lo[i] && s[i] <= r.hi[i]
+			}
+			if in {
+				return n
+			}
+		}
+	}
+
+	// Codes outside of every range are read as long as the shortest ones.
+	n := 4
+	for _, r := range c.ranges {
+		n = min(n, len(r.lo))
+	}
+	if len(c.ranges) == 0 {
+		n = 1
+	}
+	return n
+}
+
+// lookup returns the text of the code.
+func (c *cmap) lookup(code str) (string, bool) {
+	if text, ok := c.chars[string(code)]; ok {
+		return text, true
+	}
+
+	v := codeValue(code)
+	for _, b := range c.blocks {
+		if b.length != len(code) || v < b.lo || v > b.hi {
+			continue
+		}
+
+		offset := v - b.lo
+		if b.to != nil {
+			if int(offset) < len(b.to) {
+				return b.to[offset], true
+			}
+			return "", false
+		}
+
+		units := append([]uint16(nil), b.first...)
+		units[len(units)-1] += uint16(offset)
+		return string(utf16.Decode(units)), true
+	}
+
+	return "", false
+}
+
+// parseCMap parses the codespace ranges and the text mappings of a CMap, ignoring everything else in it.
+func parseCMap(data []byte) *cmap {
+	c := &cmap{chars: map[string]string{}}
+	p := &parser{data: data}
+
+	// Operands are collected until the keyword ending a section of them.
+	var operands []any
+	for {
+		v, err := p.object()
+		if err != nil {
+			return c
+		}
+
+		k, ok := v.(keyword)
+		if !ok {
+			operands = append(operands, v)
+			continue
+		}
+
+		switch k {
+		case "endcodespacerange":
+			for i := 0; i+1 < len(operands); i += 2 {
+				lo, ok1 := operands[i].(str)
+				hi, ok2 := operands[i+1].(str)
+				if ok1 && ok2 && len(lo) == len(hi) && len(lo) > 0 {
+					c.ranges = append(c.ranges, codeRange{lo: []byte(lo), hi: []byte(hi)})
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				if code, ok := operands[i].(str); ok {
+					c.chars[string(code)] = cmapText(operands[i+1])
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				lo, ok1 := operands[i].(str)
+				hi, ok2 := operands[i+1].(str)
+				if !ok1 || !ok2 || len(lo) != len(hi) || len(lo) == 0 || len(lo) > 4 {
+					continue
+				}
+
+				b := charBlock{lo: codeValue(lo), hi: codeValue(hi), length: len(lo)}
+				switch to := operands[i+2].(type) {
+				case str:
+					b.first = utf16Units(to)
+					if len(b.first) == 0 {
+						continue
+					}
+				case array:
+					for _, v := range to {
+						b.to = append(b.to, cmapText(v))
+					}
+				default:
+					continue
+				}
+				c.blocks = append(c.blocks, b)
+			}
+		}
+
+		if strings.HasPrefix(string(k), "begin") || strings.HasPrefix(string(k), "end") {
+			operands = nil
+		}
+	}
+}
+
+func codeValue(s str) uint32 {
+	v := uint32(0)
+	for i := range len(s) {
+		v = v<<8 | uint32(s[i])
+	}
+	return v
+}
+
+func utf16Units(s str) []uint16 {
+	units := make([]uint16, 0, len(s)/2)
+	for i := 0; i+1 < len(s); i += 2 {
+		units = append(units, uint16(s[i])<<8|uint16(s[i+1]))
+	}
+	return units
+}
+
+// cmapText returns the text a CMap maps a code to, UTF-16 encoded or a glyph name.
+func cmapText(v any) string {
+	switch v := v.(type) {
+	case str:
+		return string(utf16.Decode(utf16Units(v)))
+	case name:
+		return glyphText(string(v))
+	}
+	return ""
+}
+
+// glyphText returns the text of a glyph name, e.g. "eacute", "uni00E9" or "f_i", or "" when unknown.
+func glyphText(glyph string) string {
+	// Suffixes name variants of a glyph, e.g. "a.sc" for a small capital.
+	glyph, _, _ = strings.Cut(glyph, ".")
+	if parts := strings.Split(glyph, "_"); len(parts) > 1 {
+		var b strings.Builder
+		for _, part := range parts {
+			b.WriteString(glyphText(part))
+		}
+		return b.String()
+	}
+
+	if text, ok := glyphNames[glyph]; ok {
+		return text
+	}
+	if len(glyph) == 1 {
+		return glyph
+	}
+
+	if hex, ok := strings.CutPrefix(glyph, "uni"); ok && len(hex) > 0 && len(hex)%4 == 0 {
+		var units []uint16
+		for i := 0; i < len(hex); i += 4 {
+			u, err := strconv.ParseUint(hex[i:i+4], 16, 16)
+			if err != nil {
+				return ""
+			}
+			units = append(units, uint16(u))
+		}
+		return string(utf16.Decode(units))
+	}
+
+	if hex, ok := strings.CutPrefix(glyph, "u"); ok && len(hex) >= 4 && len(hex) <= 6 {
+		if r, err := strconv.ParseUint(hex, 16, 32); err == nil {
+			return string(rune(r))
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,365 @@
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Objects are parsed into these types, besides nil, bool, float64 for every number, and *stream.
+type (
+	name    string
+	str     string // Raw bytes, decoded by the font showing them.
+	array   []any
+	dict    map[name]any
+	keyword string // E.g. a content stream operator.
+	ref     struct{ num, gen int }
+)
+
+// stream is a stream object, its data still encoded.
+type stream struct {
+	dict dict
+	data []byte
+}
+
+// maxNesting bounds how deep arrays and dictionaries nest, so malformed files cannot exhaust the stack.
+const maxNesting = 100
+
+var errNesting = errors.New("objects are nested too deep")
+
+// parser reads objects from PDF syntax, e.g. the body of a file or a content stream.
+type parser struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+func isSpace(c byte) bool {
+	switch c {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+func isDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+// skipSpace skips whitespace and comments.
+func (p *parser) skipSpace() {
+	for p.pos < len(p.data) {
+		switch c := p.data[p.pos]; {
+		case isSpace(c):
+			p.pos++
+		case c == '%':
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' && p.data[p.pos] != '\r' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// regular returns the run of regular characters at the current position, e.g. a number or a keyword.
+func (p *parser) regular() []byte {
+	start := p.pos
+	for p.pos < len(p.data) && !isSpace(p.data[p.pos]) && !isDelimiter(p.data[p.pos]) {
+		p.pos++
+	}
+	return p.data[start:p.pos]
+}
+
+// object parses the next object, or returns io.EOF at the end of the data. Indirect references are parsed as
+// ref, without resolving them, and closing delimiters as keywords.
+func (p *parser) object() (any, error) {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return nil, io.EOF
+	}
+
+	switch c := p.data[p.pos]; c {
+	case '/':
+		p.pos++
+		return p.name(), nil
+	case '(':
+		p.pos++
+		return p.literal(), nil
+	case '<':
+		if p.pos+1 < len(p.data) && p.data[p.pos+1] == '<' {
+			p.pos += 2
+			return p.dict()
+		}
+		p.pos++
+		return p.hex(), nil
+	case '[':
+		p.pos++
+		return p.array()
+	case ')', '>', ']', '{', '}':
+		p.pos++
+		if c == '>' && p.pos < len(p.data) && p.data[p.pos] == '>' {
+			p.pos++
+			return keyword(">>"), nil
+		}
+		return keyword(c), nil
+	}
+
+	token := p.regular()
+	switch string(token) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+
+	if !isNumber(token) {
+		return keyword(token), nil
+	}
+	n, err := strconv.ParseFloat(string(token), 64)
+	if err != nil {
+		return keyword(token), nil
+	}
+
+	// An integer is the start of a reference when followed by another one and R.
+	if num, err := strconv.Atoi(string(token)); err == nil {
+		start := p.pos
+		p.skipSpace()
+		if gen, err := strconv.Atoi(string(p.regular())); err == nil {
+			p.skipSpace()
+			if string(p.regular()) == "R" {
+				return ref{num, gen}, nil
+			}
+		}
+		p.pos = start
+	}
+
+	return n, nil
+}
+
+// isNumber reports whether the token is a number in PDF syntax: digits, with a sign and a period optionally.
+func isNumber(token []byte) bool {
+	token = bytes.TrimLeft(token, "+-")
+	rest := bytes.Trim(token, "0123456789")
+	return len(token) > len(rest) && (len(rest) == 0 || string(rest) == ".")
+}
+
+// name parses a name, after its slash, with its #xx escapes decoded.
+func (p *parser) name() name {
+	token := p.regular()
+	if bytes.IndexByte(token, '#') < 0 {
+		return name(token)
+	}
+
+	out := make([]byte, 0, len(token))
+	for i := 0; i < len(token); i++ {
+		if token[i] == '#' && i+2 < len(token) {
+			if b, err := strconv.ParseUint(string(token[i+1:i+3]), 16, 8); err == nil {
+				out = append(out, byte(b))
+				i += 2
+				continue
+			}
+		}
+		out = append(out, token[i])
+	}
+	return name(out)
+}
+
+// literal parses a literal string, after its opening parenthesis.
+func (p *parser) literal() str {
+	var out []byte
+	for nesting := 0; p.pos < len(p.data); {
+		c := p.data[p.pos]
+		p.pos++
+
+		switch c {
+		case '(':
+			nesting++
+		case ')':
+			if nesting == 0 {
+				return str(out)
+			}
+			nesting--
+		case '\r':
+			// End of lines are read as line feeds, whatever they are made of.
+			if p.pos < len(p.data) && p.data[p.pos] == '\n' {
+				p.pos++
+			}
+			c = '\n'
+		case '\\':
+			if p.pos >= len(p.data) {
+				continue
+			}
+			c = p.data[p.pos]
+			p.pos++
+
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r', '\n':
+				// A backslash at the end of a line continues the string on the next one.
+				if c == '\r' && p.pos < len(p.data) && p.data[p.pos] == '\n' {
+					p.pos++
+				}
+				continue
+			case '0', '1', '2', '3', '4', '5', '6', '7':
+				n := c - '0'
+				for i := 0; i < 2 && p.pos < len(p.data) && p.data[p.pos] >= '0' && p.data[p.pos] <= '7'; i++ {
+					n = n<<3 | (p.data[p.pos] - '0')
+					p.pos++
+				}
+				c = n
+			}
+		}
+
+		out = append(out, c)
+	}
+	return str(out)
+}
+
+// hex parses a hexadecimal string, after its opening angle bracket.
+func (p *parser) hex() str {
+	var out []byte
+	var digits int
+	for p.pos < len(p.data) && p.data[p.pos] != '>' {
+		c := p.data[p.pos]
+		p.pos++
+
+		var v byte
+		switch {
+		case c >= '0' && c <= '9':
+			v = c - '0'
+		case c >= 'a' && c <= 'f':
+			v = c - 'a' + 10
+		case c >= 'A' && c <= 'F':
+			v = c - 'A' + 10
+		default:
+			continue
+		}
+
+		if digits%2 == 0 {
+			out = append(out, v<<4)
+		} else {
+			out[len(out)-1] |= v
+		}
+		digits++
+	}
+
+	if p.pos < len(p.data) {
+		p.pos++
+	}
+	return str(out)
+}
+
+func (p *parser) array() (array, error) {
+	if p.depth++; p.depth > maxNesting {
+		return nil, errNesting
+	}
+	defer func() { p.depth-- }()
+
+	var out array
+	for {
+		v, err := p.object()
+		if err != nil {
+			return nil, err
+		}
+		if v == keyword("]") {
+			return out, nil
+		}
+		out = append(out, v)
+	}
+}
+
+func (p *parser) dict() (dict, error) {
+	if p.depth++; p.depth > maxNesting {
+		return nil, errNesting
+	}
+	defer func() { p.depth-- }()
+
+	out := dict{}
+	for {
+		k, err := p.object()
+		if err != nil {
+			return nil, err
+		}
+		if k == keyword(">>") {
+			return out, nil
+		}
+
+		key, ok := k.(name)
+		if !ok {
+			return nil, fmt.Errorf("dictionary key %v is not a name", k)
+		}
+
+		v, err := p.object()
+		if err != nil {
+			return nil, err
+		}
+		out[key] = v
+	}
+}
+
+// indirect parses the object of an indirect object definition, after its obj keyword, with the data of streams.
+func (p *parser) indirect() (any, error) {
+	v, err := p.object()
+	if err != nil {
+		return nil, err
+	}
+
+	d, ok := v.(dict)
+	if !ok {
+		return v, nil
+	}
+
+	p.skipSpace()
+	if !bytes.HasPrefix(p.data[p.pos:], []byte("stream")) {
+		return d, nil
+	}
+
+	p.pos += len("stream")
+	if p.pos < len(p.data) && p.data[p.pos] == '\r' {
+		p.pos++
+	}
+	if p.pos < len(p.data) && p.data[p.pos] == '\n' {
+		p.pos++
+	}
+
+	// The length is trusted when the stream ends there. It cannot be resolved yet when it is a reference.
+	if n, ok := d["Length"].(float64); ok && n >= 0 && n <= float64(len(p.data)-p.pos) {
+		end := p.pos + int(n)
+		rest := bytes.TrimLeft(p.data[end:min(end+32, len(p.data))], "\r\n \t")
+		if bytes.HasPrefix(rest, []byte("endstream")) {
+			s := &stream{dict: d, data: p.data[p.pos:end]}
+			p.pos = end
+			return s, nil
+		}
+	}
+
+	end := bytes.Index(p.data[p.pos:], []byte("endstream"))
+	if end < 0 {
+		return nil, errors.New("stream without endstream")
+	}
+
+	data := p.data[p.pos : p.pos+end]
+	data = bytes.TrimSuffix(data, []byte("\n"))
+	data = bytes.TrimSuffix(data, []byte("\r"))
+
+	p.pos += end
+	return &stream{dict: d, data: data}, nil
+}
@@ -0,0 +1,38 @@
+// Package pdf extracts the text of PDF files, for documents to be searched by their text.
+//
+// Text is read the way it is drawn, page by page, so it comes out in reading order for the many documents drawing
+// it in that order, e.g. bookings, tickets or invoices. Text drawn as images, e.g. in scanned pages, and text in
+// fonts without a mapping to Unicode cannot be read.
+package pdf
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Text returns the text of the PDF, pages separated by blank lines.
+func Text(data []byte) (string, error) {
+	doc, err := load(data)
+	if err != nil {
+		return "", err
+	}
+
+	pages := doc.pages()
+	if len(pages) == 0 {
+		return "", errors.New("no pages found")
+	}
+
+	e := newExtractor(doc)
+	for i, page := range pages {
+		content, err := doc.contents(page)
+		if err != nil {
+			return "", fmt.Errorf("failed to read page %d: %w", i+1, err)
+		}
+
+		if err := e.page(content, page["Resources"]); err != nil {
+			return "", fmt.Errorf("failed to read page %d: %w", i+1, err)
+		}
+	}
+
+	return e.text(), nil
+}
@@ -0,0 +1,176 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestText_Fixture(t *testing.T) {
+	// A booking confirmation laid out line by line in Helvetica, then in Courier with words spaced by kerning. Its
+	// pages and fonts are in a compressed object stream, with a cross-reference stream.
+	data, err := os.ReadFile("testdata/booking.pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Text(data)
+	if err != nil {
+		t.Fatalf("Text() error = %v", err)
+	}
+
+	want := `Booking confirmation
+
+Booking reference X7K2P
+Passenger Alice Martin
+Flight VY 1234, Barcelona (BCN) to Rome (FCO)
+Departure 3 May 2026, 07:15
+Hotel Hotel Café Roma, Via del Corso 12
+
+Baggage
+
+Checked baggage allowance: one bag of 23 kg per passenger. Cabin
+baggage is limited to one bag of 10 kg, which must fit under the seat
+in front of you. Oversized items, such as bicycles or skis, must be
+booked at least 48 hours before departure.
+
+Questions? Call +34 930 00 00 00, quoting your booking reference.
+
+Cancellation policy
+
+Free cancellation until 48 hours before check-in. Later
+cancellations are charged the first night. No-shows are charged
+the full stay. Refunds are paid back to the card used for the
+booking within 14 days.
+
+Page 2 of 2`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Text() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestText(t *testing.T) {
+	helvetica := "<< /Font << /F1 << /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >> >> >>"
+
+	tests := []struct {
+		name    string
+		pdf     []byte
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "lines and words",
+			pdf: onePage(helvetica, `BT /F1 12 Tf 14 TL 72 700 Td (Gate) Tj 40 0 Td (B32) Tj T* (Boarding 06:45) Tj
+				0 -40 Td (Caf\351 \(open\) \\ 50\045 off) Tj ET`),
+			want: "Gate B32\nBoarding 06:45\n\nCafé (open) \\ 50% off",
+		},
+		{
+			name: "composite font",
+			pdf: onePage("<< /Font << /F1 5 0 R >> >>",
+				`BT /F1 10 Tf 72 700 Td <00100011> Tj [<0012> -3000 <0013>] TJ ( ) Tj <00300031 0020> Tj ET`,
+				`<< /Type /Font /Subtype /Type0 /BaseFont /Arial /Encoding /Identity-H /ToUnicode 6 0 R
+					/DescendantFonts [<< /Type /Font /Subtype /CIDFontType2 /BaseFont /Arial /DW 1000
+					/W [16 [500 500 500 500] 48 49 600] >>] >>`,
+				streamObject("", `/CIDInit /ProcSet findresource begin 12 dict begin begincmap
+					1 begincodespacerange <0000> <FFFF> endcodespacerange
+					1 beginbfchar <0020> <00E9> endbfchar
+					2 beginbfrange <0010> <0013> <0041> <0030> <0031> [<00660069> <004F006B>] endbfrange
+					endcmap CMapName currentdict /CMap defineresource pop end end`),
+			),
+			want: "ABC D fiOké",
+		},
+		{
+			name: "glyph names",
+			pdf: onePage("<< /Font << /F1 5 0 R >> >>", `BT /F1 12 Tf 72 700 Td (\001t\351 \002 \003\004) Tj ET`,
+				`<< /Type /Font /Subtype /Type1 /BaseFont /Times-Roman
+					/Encoding << /BaseEncoding /WinAnsiEncoding /Differences [1 /Eacute /uni20AC /f_f /T.sc] >> >>`),
+			want: "Été € ffT",
+		},
+		{
+			name: "forms and inline images",
+			pdf: onePage("<< /Font << /F1 5 0 R >> /XObject << /Fm1 6 0 R >> >>",
+				"q BI /W 2 /H 2 /CS /G /BPC 8 ID \x00EI\xff) EI Q BT /F1 12 Tf 72 700 Td (Before) Tj ET /Fm1 Do",
+				"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+				streamObject("/Type /XObject /Subtype /Form /BBox [0 0 200 20] /Matrix [1 0 0 1 72 600] /Resources << /Font << /F1 5 0 R >> >>",
+					"BT /F1 12 Tf 0 0 Td (Inside a form) Tj ET"),
+			),
+			want: "Before\n\nInside a form",
+		},
+		{
+			name:    "no pages",
+			pdf:     build("", "<< /Type /Catalog /Pages 2 0 R >>", "<< /Type /Pages /Kids [] /Count 0 >>"),
+			wantErr: true,
+		},
+		{
+			name:    "not a PDF",
+			pdf:     []byte("Booking reference X7K2P"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Text(tt.pdf)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Text() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Text() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("encrypted", func(t *testing.T) {
+		pdf := build("/Encrypt << /Filter /Standard /V 2 /R 3 >>", "<< /Type /Catalog >>")
+		if _, err := Text(pdf); !errors.Is(err, ErrEncrypted) {
+			t.Errorf("Text() error = %v, want %v", err, ErrEncrypted)
+		}
+	})
+}
+
+// onePage returns a PDF of one page drawing the content with the resources, and the more objects, numbered from 5.
+func onePage(resources, content string, objects ...string) []byte {
+	return build("", append([]string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Contents 4 0 R /Resources " + resources + " >>",
+		streamObject("", content),
+	}, objects...)...)
+}
+
+// build returns a PDF of the objects, numbered from 1, the first one being the catalog, with the more entries of
+// the trailer.
+func build(trailer string, objects ...string) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.7\n")
+
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R %s >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, trailer, xref)
+
+	return b.Bytes()
+}
+
+// streamObject returns a stream object of the dictionary entries and the content, compressed.
+func streamObject(entries, content string) string {
+	var b bytes.Buffer
+	w := zlib.NewWriter(&b)
+	_, _ = w.Write([]byte(content))
+	_ = w.Close()
+
+	return fmt.Sprintf("<< %s /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", entries, b.Len(), b.String())
+}
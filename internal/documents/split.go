@@ -0,0 +1,98 @@
+package documents
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Split cuts text into chunks of at most size bytes, along paragraphs when they fit and words otherwise. Each
+// chunk but the first starts with the last overlap bytes, or so, of the previous one, cut at a word boundary.
+// Words longer than size make chunks of their own.
+func Split(text string, size, overlap int) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	overlap = min(overlap, size/2)
+
+	// Pieces are paragraphs, or the words of the ones too long to fit in a chunk.
+	type piece struct {
+		text      string
+		paragraph int
+	}
+
+	var pieces []piece
+	for i, paragraph := range strings.Split(text, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if len(paragraph) <= size {
+			pieces = append(pieces, piece{paragraph, i})
+			continue
+		}
+
+		for _, word := range strings.Fields(paragraph) {
+			pieces = append(pieces, piece{word, i})
+		}
+	}
+
+	var (
+		chunks []string
+		chunk  strings.Builder
+		fresh  bool // Whether chunk holds more than the overlap of the previous one.
+	)
+
+	for i, p := range pieces {
+		if p.text == "" {
+			continue
+		}
+
+		// Words of a paragraph are joined by spaces, paragraphs by blank lines.
+		separator := "\n\n"
+		if i > 0 && pieces[i-1].paragraph == p.paragraph {
+			separator = " "
+		}
+
+		if chunk.Len() > 0 && chunk.Len()+len(separator)+len(p.text) > size {
+			if fresh {
+				text := chunk.String()
+				chunks = append(chunks, text)
+				chunk.Reset()
+				chunk.WriteString(tail(text, overlap))
+				fresh = false
+			}
+
+			// The overlap may not leave room for the piece either.
+			if chunk.Len()+len(separator)+len(p.text) > size {
+				chunk.Reset()
+			}
+		}
+
+		if chunk.Len() > 0 {
+			chunk.WriteString(separator)
+		}
+		chunk.WriteString(p.text)
+		fresh = true
+	}
+
+	if fresh {
+		chunks = append(chunks, chunk.String())
+	}
+
+	return chunks
+}
+
+// tail returns the end of s, at most n bytes long, starting at a word.
+func tail(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	if len(s) <= n {
+		return s
+	}
+
+	start := len(s) - n
+	for start < len(s) && !utf8.RuneStart(s[start]) {
+		start++
+	}
+
+	if i := strings.IndexAny(s[start:], " \n"); i >= 0 {
+		return strings.TrimSpace(s[start+i:])
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package documents
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/acai-travel/tech-challenge/internal/documents/pdf"
+)
+
+// ErrUnsupportedType is returned for content that is neither text nor a PDF, e.g. images.
+var ErrUnsupportedType = errors.New("unsupported content type, only text documents and PDFs can be uploaded")
+
+// textTypes are the content types of text documents outside the text/ family.
+var textTypes = []string{"application/json", "application/xml", "application/yaml", "application/x-yaml"}
+
+// Text returns the text of the content, and its content type, the given one or the one detected when empty.
+// Text documents are supported in UTF-8, a byte order mark dropped, and PDFs with their text extracted.
+func Text(contentType string, content []byte) (string, string, error) {
+	if contentType == "" {
+		contentType = http.DetectContentType(content)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", "", ErrUnsupportedType
+	}
+
+	if mediaType == "application/pdf" {
+		text, err := pdf.Text(content)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read PDF: %w", err)
+		}
+		return text, contentType, nil
+	}
+
+	if !strings.HasPrefix(mediaType, "text/") && !slices.Contains(textTypes, mediaType) {
+		return "", "", ErrUnsupportedType
+	}
+
+	if charset := params["charset"]; charset != "" && !strings.EqualFold(charset, "utf-8") && !strings.EqualFold(charset, "us-ascii") {
+		return "", "", errors.New("unsupported charset " + charset + ", only UTF-8 is supported")
+	}
+
+	if !utf8.Valid(content) {
+		return "", "", errors.New("content is not valid UTF-8")
+	}
+
+	return strings.TrimPrefix(string(content), "\uFEFF"), contentType, nil
+}
@@ -0,0 +1,43 @@
+package httpx
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+	"github.com/twitchtv/twirp"
+)
+
+// Authenticate rejects requests without valid credentials and stores the caller's user ID in the request
+// context. Credentials are read from an "Authorization: Bearer <token>" header, where the token is an API
+// key or a JWT, or from an "X-API-Key" header.
+func Authenticate(keys *auth.KeySet) func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := r.Header.Get("X-API-Key")
+			if h := r.Header.Get("Authorization"); h != "" {
+				scheme, value, _ := strings.Cut(h, " ")
+				if !strings.EqualFold(scheme, "Bearer") {
+					_ = twirp.WriteError(w, twirp.NewError(twirp.Unauthenticated, "unsupported authorization scheme"))
+					return
+				}
+				token = strings.TrimSpace(value)
+			}
+
+			if token == "" {
+				_ = twirp.WriteError(w, twirp.NewError(twirp.Unauthenticated, "missing credentials"))
+				return
+			}
+
+			userID, err := keys.Verify(token)
+			if err != nil {
+				slog.InfoContext(r.Context(), "HTTP request rejected", "http_path", r.URL.Path, "error", err)
+				_ = twirp.WriteError(w, twirp.NewError(twirp.Unauthenticated, "invalid credentials"))
+				return
+			}
+
+			handler.ServeHTTP(w, r.WithContext(auth.WithUser(r.Context(), userID)))
+		})
+	}
+}
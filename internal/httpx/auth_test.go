@@ -0,0 +1,53 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/acai-travel/tech-challenge/internal/auth"
+)
+
+func TestAuthenticate(t *testing.T) {
+	keys := &auth.KeySet{APIKeys: []auth.APIKey{{UserID: "alice", SHA256: auth.HashAPIKey("alice-key")}}}
+
+	var user string
+	handler := Authenticate(keys)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user = auth.UserID(r.Context())
+	}))
+
+	tests := []struct {
+		name     string
+		header   string
+		value    string
+		wantCode int
+		wantUser string
+	}{
+		{name: "bearer API key", header: "Authorization", value: "Bearer alice-key", wantCode: http.StatusOK, wantUser: "alice"},
+		{name: "X-API-Key header", header: "X-API-Key", value: "alice-key", wantCode: http.StatusOK, wantUser: "alice"},
+		{name: "missing credentials", wantCode: http.StatusUnauthorized},
+		{name: "invalid key", header: "Authorization", value: "Bearer nope", wantCode: http.StatusUnauthorized},
+		{name: "unsupported scheme", header: "Authorization", value: "Basic YWxpY2U6cGFzcw==", wantCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user = ""
+			req := httptest.NewRequest(http.MethodPost, "/twirp/acai.chat.ChatService/ListConversations", nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("expected status %d, got %d", tt.wantCode, rec.Code)
+			}
+
+			if user != tt.wantUser {
+				t.Errorf("expected user %q, got %q", tt.wantUser, user)
+			}
+		})
+	}
+}
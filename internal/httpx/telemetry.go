@@ -17,6 +17,11 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap exposes the underlying writer to http.ResponseController, so handlers can still flush.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // TelemetryMiddleware creates HTTP middleware that records request metrics.
 func TelemetryMiddleware(metrics *telemetry.Metrics) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -34,4 +39,4 @@ func TelemetryMiddleware(metrics *telemetry.Metrics) func(http.Handler) http.Han
 			metrics.RecordRequest(r.Method, r.URL.Path, ww.statusCode, duration)
 		})
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,93 @@
+// Package llm provides a provider-agnostic client layer for chat completion models. The assistant talks to
+// models exclusively through the Provider interface and the types in this package, so switching vendors
+// only requires a new adapter.
+package llm
+
+import "context"
+
+// Role identifies the author of a message.
+type Role string
+
+const (
+	RoleSystem    Role = "system"
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+	RoleTool      Role = "tool"
+)
+
+// Message is a single message exchanged with the model.
+type Message struct {
+	Role    Role
+	Content string
+
+	// ToolCalls holds the tools the model asked to run, only set on assistant messages.
+	ToolCalls []ToolCall
+
+	// ToolCallID references the call a tool message answers, only set on tool messages.
+	ToolCallID string
+}
+
+// ToolCall is a request from the model to run a tool.
+type ToolCall struct {
+	ID        string
+	Name      string
+	Arguments string
+}
+
+// Tool describes a tool the model may call. Parameters must marshal to a JSON schema object.
+type Tool struct {
+	Name        string
+	Description string
+	Parameters  any
+}
+
+// Request is a chat completion request.
+type Request struct {
+	Model    string
+	Messages []Message
+	Tools    []Tool
+
+	// Temperature overrides the provider's default sampling temperature when set.
+	Temperature *float64
+}
+
+// Usage reports the number of tokens consumed by a completion.
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// Completion is the model's answer to a Request.
+type Completion struct {
+	Message Message
+	Usage   Usage
+}
+
+// Provider generates chat completions.
+type Provider interface {
+	// Complete returns the model's next message for the request.
+	Complete(ctx context.Context, req Request) (*Completion, error)
+
+	// Stream behaves like Complete, but calls onDelta with each chunk of content as it is generated.
+	Stream(ctx context.Context, req Request, onDelta func(string)) (*Completion, error)
+}
+
+// SystemMessage creates a system message.
+func SystemMessage(content string) Message {
+	return Message{Role: RoleSystem, Content: content}
+}
+
+// UserMessage creates a user message.
+func UserMessage(content string) Message {
+	return Message{Role: RoleUser, Content: content}
+}
+
+// AssistantMessage creates an assistant message.
+func AssistantMessage(content string) Message {
+	return Message{Role: RoleAssistant, Content: content}
+}
+
+// ToolMessage creates a message with the result of the given tool call.
+func ToolMessage(content, toolCallID string) Message {
+	return Message{Role: RoleTool, Content: content, ToolCallID: toolCallID}
+}
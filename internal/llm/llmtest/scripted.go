@@ -0,0 +1,104 @@
+// Package llmtest provides a deterministic llm.Provider for tests.
+package llmtest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/acai-travel/tech-challenge/internal/llm"
+)
+
+var _ llm.Provider = (*Scripted)(nil)
+
+// Step is a single scripted model turn: the provider answers the next request with Reply, or fails with Err.
+type Step struct {
+	Reply llm.Message
+	Err   error
+}
+
+// Reply is a step answering with plain text.
+func Reply(content string) Step {
+	return Step{Reply: llm.AssistantMessage(content)}
+}
+
+// CallTools is a step asking to run the given tools.
+func CallTools(calls ...llm.ToolCall) Step {
+	return Step{Reply: llm.Message{Role: llm.RoleAssistant, ToolCalls: calls}}
+}
+
+// Fail is a step failing with err.
+func Fail(err error) Step {
+	return Step{Err: err}
+}
+
+// Scripted is a Provider answering requests with a fixed sequence of steps, and recording every request it
+// received so tests can assert on what was sent to the model. Requests beyond the end of the script fail.
+type Scripted struct {
+	mu       sync.Mutex
+	steps    []Step
+	requests []llm.Request
+}
+
+// NewScripted creates a provider playing the given steps in order.
+func NewScripted(steps ...Step) *Scripted {
+	return &Scripted{steps: steps}
+}
+
+// Requests returns the requests received so far.
+func (s *Scripted) Requests() []llm.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]llm.Request(nil), s.requests...)
+}
+
+// Remaining returns the number of steps not played yet.
+func (s *Scripted) Remaining() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.steps)
+}
+
+func (s *Scripted) Complete(ctx context.Context, req llm.Request) (*llm.Completion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, req)
+
+	if len(s.steps) == 0 {
+		return nil, fmt.Errorf("llmtest: unexpected request #%d, script exhausted", len(s.requests))
+	}
+
+	step := s.steps[0]
+	s.steps = s.steps[1:]
+
+	if step.Err != nil {
+		return nil, step.Err
+	}
+
+	reply := step.Reply
+	if reply.Role == "" {
+		reply.Role = llm.RoleAssistant
+	}
+
+	return &llm.Completion{Message: reply}, nil
+}
+
+// Stream plays the next step like Complete, reporting its content word by word.
+func (s *Scripted) Stream(ctx context.Context, req llm.Request, onDelta func(string)) (*llm.Completion, error) {
+	out, err := s.Complete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, word := range strings.SplitAfter(out.Message.Content, " ") {
+		if word != "" {
+			onDelta(word)
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,349 @@
+package llmtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Script drives a fake chat completions server. Each step answers one request, in order. It is usually
+// loaded from a JSON file with LoadScript:
+//
+//	{
+//	  "steps": [
+//	    {"expect": {"last_role": "user"}, "response": {"tool_calls": [{"id": "call_1", "name": "calculate", "arguments": "{}"}]}},
+//	    {"expect": {"last_role": "tool"}, "response": {"content": "Done."}}
+//	  ]
+//	}
+type Script struct {
+	Steps []ScriptStep `json:"steps"`
+}
+
+// ScriptStep is a canned response, optionally checked against expectations on the request and repeated.
+type ScriptStep struct {
+	Expect   *Expectation   `json:"expect,omitempty"`
+	Response ScriptResponse `json:"response"`
+
+	// Repeat plays the step this many times, defaults to once.
+	Repeat int `json:"repeat,omitempty"`
+}
+
+// Expectation describes what a request must look like. Empty fields are not checked.
+type Expectation struct {
+	Model               string   `json:"model,omitempty"`
+	LastRole            string   `json:"last_role,omitempty"`
+	LastContentContains string   `json:"last_content_contains,omitempty"`
+	Tools               []string `json:"tools,omitempty"`
+}
+
+// ScriptResponse is the assistant message returned for a step. Status, if set, makes the server fail the
+// request with that HTTP status instead.
+type ScriptResponse struct {
+	Content   string           `json:"content,omitempty"`
+	ToolCalls []ScriptToolCall `json:"tool_calls,omitempty"`
+	Status    int              `json:"status,omitempty"`
+}
+
+// ScriptToolCall is a tool call returned by the model.
+type ScriptToolCall struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// LoadScript reads a script from a JSON file.
+func LoadScript(t testing.TB, path string) Script {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read script: %v", err)
+	}
+
+	var script Script
+	if err := json.Unmarshal(data, &script); err != nil {
+		t.Fatalf("failed to parse script %s: %v", path, err)
+	}
+
+	return script
+}
+
+// RecordedRequest is a normalized view of a chat completion request received by the fake server.
+type RecordedRequest struct {
+	Model    string            `json:"model"`
+	Stream   bool              `json:"stream,omitempty"`
+	Messages []RecordedMessage `json:"messages"`
+	Tools    []string          `json:"tools,omitempty"`
+}
+
+// RecordedMessage is a message of a RecordedRequest.
+type RecordedMessage struct {
+	Role       string           `json:"role"`
+	Content    string           `json:"content,omitempty"`
+	ToolCalls  []ScriptToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string           `json:"tool_call_id,omitempty"`
+}
+
+// Server is a fake OpenAI chat completions server playing a Script. Point an OpenAI client at URL (e.g. with
+// option.WithBaseURL) to use it. Any request that does not match the script fails the test.
+type Server struct {
+	URL string
+
+	t        testing.TB
+	mu       sync.Mutex
+	steps    []ScriptStep
+	requests []RecordedRequest
+}
+
+// NewServer starts a fake server playing the script. It is closed when the test ends.
+func NewServer(t testing.TB, script Script) *Server {
+	s := &Server{t: t}
+
+	for _, step := range script.Steps {
+		for range max(step.Repeat, 1) {
+			s.steps = append(s.steps, step)
+		}
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(srv.Close)
+
+	s.URL = srv.URL
+	return s
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]RecordedRequest(nil), s.requests...)
+}
+
+// Remaining returns the number of steps not played yet.
+func (s *Server) Remaining() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.steps)
+}
+
+type chatRequest struct {
+	Model    string `json:"model"`
+	Stream   bool   `json:"stream"`
+	Messages []struct {
+		Role       string          `json:"role"`
+		Content    json.RawMessage `json:"content"`
+		ToolCallID string          `json:"tool_call_id"`
+		ToolCalls  []struct {
+			ID       string `json:"id"`
+			Function struct {
+				Name      string `json:"name"`
+				Arguments string `json:"arguments"`
+			} `json:"function"`
+		} `json:"tool_calls"`
+	} `json:"messages"`
+	Tools []struct {
+		Function struct {
+			Name string `json:"name"`
+		} `json:"function"`
+	} `json:"tools"`
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/chat/completions") {
+		s.fail(w, http.StatusNotFound, "unexpected request %s %s", r.Method, r.URL.Path)
+		return
+	}
+
+	var body chatRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		s.fail(w, http.StatusBadRequest, "failed to decode request: %v", err)
+		return
+	}
+
+	req := record(body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	n := len(s.requests)
+
+	if len(s.steps) == 0 {
+		s.mu.Unlock()
+		s.fail(w, http.StatusBadRequest, "unexpected request #%d, script exhausted", n)
+		return
+	}
+
+	step := s.steps[0]
+	s.steps = s.steps[1:]
+	s.mu.Unlock()
+
+	if err := step.Expect.check(req); err != nil {
+		s.fail(w, http.StatusBadRequest, "request #%d: %v", n, err)
+		return
+	}
+
+	if step.Response.Status != 0 {
+		w.WriteHeader(step.Response.Status)
+		_, _ = fmt.Fprintf(w, `{"error": {"message": "scripted failure", "type": "server_error"}}`)
+		return
+	}
+
+	id := fmt.Sprintf("chatcmpl-%d", n)
+	if body.Stream {
+		writeStream(w, id, req.Model, step.Response)
+		return
+	}
+
+	writeCompletion(w, id, req.Model, step.Response)
+}
+
+func (s *Server) fail(w http.ResponseWriter, status int, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	s.t.Errorf("llmtest: %s", msg)
+
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]string{"message": msg, "type": "invalid_request_error"}})
+}
+
+func record(body chatRequest) RecordedRequest {
+	req := RecordedRequest{Model: body.Model, Stream: body.Stream}
+
+	for _, m := range body.Messages {
+		msg := RecordedMessage{Role: m.Role, ToolCallID: m.ToolCallID}
+
+		// Content is either a string or an array of text parts.
+		var text string
+		var parts []struct {
+			Text string `json:"text"`
+		}
+		if json.Unmarshal(m.Content, &text) == nil {
+			msg.Content = text
+		} else if json.Unmarshal(m.Content, &parts) == nil {
+			for _, p := range parts {
+				msg.Content += p.Text
+			}
+		}
+
+		for _, call := range m.ToolCalls {
+			msg.ToolCalls = append(msg.ToolCalls, ScriptToolCall{ID: call.ID, Name: call.Function.Name, Arguments: call.Function.Arguments})
+		}
+
+		req.Messages = append(req.Messages, msg)
+	}
+
+	for _, tool := range body.Tools {
+		req.Tools = append(req.Tools, tool.Function.Name)
+	}
+
+	return req
+}
+
+func (e *Expectation) check(req RecordedRequest) error {
+	if e == nil {
+		return nil
+	}
+
+	if e.Model != "" && req.Model != e.Model {
+		return fmt.Errorf("expected model %q, got %q", e.Model, req.Model)
+	}
+
+	if len(req.Messages) == 0 {
+		return fmt.Errorf("request has no messages")
+	}
+
+	last := req.Messages[len(req.Messages)-1]
+	if e.LastRole != "" && last.Role != e.LastRole {
+		return fmt.Errorf("expected last message role %q, got %q", e.LastRole, last.Role)
+	}
+
+	if e.LastContentContains != "" && !strings.Contains(last.Content, e.LastContentContains) {
+		return fmt.Errorf("expected last message to contain %q, got %q", e.LastContentContains, last.Content)
+	}
+
+	if e.Tools != nil && strings.Join(e.Tools, ",") != strings.Join(req.Tools, ",") {
+		return fmt.Errorf("expected tools %v, got %v", e.Tools, req.Tools)
+	}
+
+	return nil
+}
+
+func finishReason(resp ScriptResponse) string {
+	if len(resp.ToolCalls) > 0 {
+		return "tool_calls"
+	}
+	return "stop"
+}
+
+func toolCalls(resp ScriptResponse, withIndex bool) []map[string]any {
+	var calls []map[string]any
+	for i, call := range resp.ToolCalls {
+		c := map[string]any{
+			"id":       call.ID,
+			"type":     "function",
+			"function": map[string]any{"name": call.Name, "arguments": call.Arguments},
+		}
+		if withIndex {
+			c["index"] = i
+		}
+		calls = append(calls, c)
+	}
+	return calls
+}
+
+func writeCompletion(w http.ResponseWriter, id, model string, resp ScriptResponse) {
+	message := map[string]any{"role": "assistant", "content": resp.Content}
+	if calls := toolCalls(resp, false); calls != nil {
+		message["tool_calls"] = calls
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"id":      id,
+		"object":  "chat.completion",
+		"created": 0,
+		"model":   model,
+		"choices": []any{map[string]any{
+			"index":         0,
+			"message":       message,
+			"finish_reason": finishReason(resp),
+		}},
+		"usage": map[string]any{"prompt_tokens": 0, "completion_tokens": 0, "total_tokens": 0},
+	})
+}
+
+// writeStream sends the response as server-sent chunks: the content word by word, then each tool call.
+func writeStream(w http.ResponseWriter, id, model string, resp ScriptResponse) {
+	w.Header().Set("Content-Type", "text/event-stream")
+
+	send := func(delta map[string]any, finish any) {
+		data, _ := json.Marshal(map[string]any{
+			"id":      id,
+			"object":  "chat.completion.chunk",
+			"created": 0,
+			"model":   model,
+			"choices": []any{map[string]any{"index": 0, "delta": delta, "finish_reason": finish}},
+		})
+		_, _ = fmt.Fprintf(w, "data: %s\n\n", data)
+	}
+
+	send(map[string]any{"role": "assistant"}, nil)
+
+	for _, word := range strings.SplitAfter(resp.Content, " ") {
+		if word != "" {
+			send(map[string]any{"content": word}, nil)
+		}
+	}
+
+	for _, call := range toolCalls(resp, true) {
+		send(map[string]any{"tool_calls": []any{call}}, nil)
+	}
+
+	send(map[string]any{}, finishReason(resp))
+	_, _ = fmt.Fprint(w, "data: [DONE]\n\n")
+}
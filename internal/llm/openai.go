@@ -0,0 +1,158 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/openai/openai-go/v2"
+	"github.com/openai/openai-go/v2/option"
+)
+
+var _ Provider = (*OpenAI)(nil)
+
+// OpenAI is a Provider backed by the OpenAI chat completions API, or any server implementing it.
+type OpenAI struct {
+	cli openai.Client
+}
+
+// NewOpenAI creates a provider for the OpenAI API. Without options the client is configured from the
+// OPENAI_API_KEY and OPENAI_BASE_URL environment variables.
+func NewOpenAI(opts ...option.RequestOption) *OpenAI {
+	return &OpenAI{cli: openai.NewClient(opts...)}
+}
+
+// NewOpenAICompatible creates a provider for a server exposing an OpenAI-compatible chat completions API at
+// baseURL, such as a local model server. The API key may be empty if the server does not require one.
+func NewOpenAICompatible(baseURL, apiKey string, opts ...option.RequestOption) *OpenAI {
+	return NewOpenAI(append([]option.RequestOption{option.WithBaseURL(baseURL), option.WithAPIKey(apiKey)}, opts...)...)
+}
+
+func (p *OpenAI) Complete(ctx context.Context, req Request) (*Completion, error) {
+	params, err := toOpenAIParams(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.cli.Chat.Completions.New(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromOpenAICompletion(resp)
+}
+
+func (p *OpenAI) Stream(ctx context.Context, req Request, onDelta func(string)) (*Completion, error) {
+	params, err := toOpenAIParams(req)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := p.cli.Chat.Completions.NewStreaming(ctx, params)
+	defer func() {
+		_ = stream.Close()
+	}()
+
+	var acc openai.ChatCompletionAccumulator
+	for stream.Next() {
+		chunk := stream.Current()
+		if !acc.AddChunk(chunk) {
+			return nil, errors.New("unexpected chunk in OpenAI completion stream")
+		}
+
+		if len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
+			onDelta(chunk.Choices[0].Delta.Content)
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+
+	return fromOpenAICompletion(&acc.ChatCompletion)
+}
+
+func toOpenAIParams(req Request) (openai.ChatCompletionNewParams, error) {
+	params := openai.ChatCompletionNewParams{Model: req.Model}
+
+	if req.Temperature != nil {
+		params.Temperature = openai.Float(*req.Temperature)
+	}
+
+	for _, m := range req.Messages {
+		switch m.Role {
+		case RoleSystem:
+			params.Messages = append(params.Messages, openai.SystemMessage(m.Content))
+		case RoleUser:
+			params.Messages = append(params.Messages, openai.UserMessage(m.Content))
+		case RoleAssistant:
+			msg := openai.ChatCompletionAssistantMessageParam{}
+			if m.Content != "" {
+				msg.Content.OfString = openai.String(m.Content)
+			}
+			for _, call := range m.ToolCalls {
+				msg.ToolCalls = append(msg.ToolCalls, openai.ChatCompletionMessageToolCallUnionParam{
+					OfFunction: &openai.ChatCompletionMessageFunctionToolCallParam{
+						ID: call.ID,
+						Function: openai.ChatCompletionMessageFunctionToolCallFunctionParam{
+							Name:      call.Name,
+							Arguments: call.Arguments,
+						},
+					},
+				})
+			}
+			params.Messages = append(params.Messages, openai.ChatCompletionMessageParamUnion{OfAssistant: &msg})
+		case RoleTool:
+			params.Messages = append(params.Messages, openai.ToolMessage(m.Content, m.ToolCallID))
+		default:
+			return params, fmt.Errorf("unsupported message role %q", m.Role)
+		}
+	}
+
+	for _, tool := range req.Tools {
+		var parameters openai.FunctionParameters
+		if tool.Parameters != nil {
+			data, err := json.Marshal(tool.Parameters)
+			if err != nil {
+				return params, fmt.Errorf("failed to encode parameters of tool %s: %w", tool.Name, err)
+			}
+			if err := json.Unmarshal(data, &parameters); err != nil {
+				return params, fmt.Errorf("parameters of tool %s are not a JSON object: %w", tool.Name, err)
+			}
+		}
+
+		params.Tools = append(params.Tools, openai.ChatCompletionFunctionTool(openai.FunctionDefinitionParam{
+			Name:        tool.Name,
+			Description: openai.String(tool.Description),
+			Parameters:  parameters,
+		}))
+	}
+
+	return params, nil
+}
+
+func fromOpenAICompletion(resp *openai.ChatCompletion) (*Completion, error) {
+	if len(resp.Choices) == 0 {
+		return nil, errors.New("no choices returned by OpenAI")
+	}
+
+	msg := resp.Choices[0].Message
+	out := &Completion{
+		Message: Message{Role: RoleAssistant, Content: msg.Content},
+		Usage: Usage{
+			PromptTokens:     int(resp.Usage.PromptTokens),
+			CompletionTokens: int(resp.Usage.CompletionTokens),
+		},
+	}
+
+	for _, call := range msg.ToolCalls {
+		out.Message.ToolCalls = append(out.Message.ToolCalls, ToolCall{
+			ID:        call.ID,
+			Name:      call.Function.Name,
+			Arguments: call.Function.Arguments,
+		})
+	}
+
+	return out, nil
+}
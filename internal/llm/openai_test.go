@@ -0,0 +1,74 @@
+package llm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestToOpenAIParams(t *testing.T) {
+	temperature := 0.2
+	params, err := toOpenAIParams(Request{
+		Model: "gpt-4.1",
+		Messages: []Message{
+			SystemMessage("Be brief."),
+			UserMessage("Weather in Barcelona?"),
+			{Role: RoleAssistant, ToolCalls: []ToolCall{{ID: "call_1", Name: "get_weather", Arguments: `{"location":"Barcelona"}`}}},
+			ToolMessage("Sunny", "call_1"),
+		},
+		Tools: []Tool{{
+			Name:        "get_weather",
+			Description: "Get the weather",
+			Parameters:  map[string]any{"type": "object", "properties": map[string]any{}},
+		}},
+		Temperature: &temperature,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("failed to encode params: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("failed to decode params: %v", err)
+	}
+
+	want := map[string]any{
+		"model":       "gpt-4.1",
+		"temperature": 0.2,
+		"messages": []any{
+			map[string]any{"role": "system", "content": "Be brief."},
+			map[string]any{"role": "user", "content": "Weather in Barcelona?"},
+			map[string]any{"role": "assistant", "tool_calls": []any{map[string]any{
+				"id":       "call_1",
+				"type":     "function",
+				"function": map[string]any{"name": "get_weather", "arguments": `{"location":"Barcelona"}`},
+			}}},
+			map[string]any{"role": "tool", "content": "Sunny", "tool_call_id": "call_1"},
+		},
+		"tools": []any{map[string]any{
+			"type": "function",
+			"function": map[string]any{
+				"name":        "get_weather",
+				"description": "Get the weather",
+				"parameters":  map[string]any{"type": "object", "properties": map[string]any{}},
+			},
+		}},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("toOpenAIParams() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestToOpenAIParams_UnknownRole(t *testing.T) {
+	if _, err := toOpenAIParams(Request{Messages: []Message{{Role: "narrator"}}}); err == nil {
+		t.Error("expected error for unknown role, got nil")
+	}
+}
@@ -0,0 +1,78 @@
+package llm
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+)
+
+// DefaultContextWindow is the context window assumed for models not listed in modelLimits.
+const DefaultContextWindow = 8_192
+
+// modelLimits describes the known model families, matched by the longest name prefix. charsPerToken is the
+// average number of bytes of English text per token of the model's tokenizer.
+var modelLimits = []struct {
+	prefix        string
+	contextWindow int
+	charsPerToken float64
+}{
+	{prefix: "gpt-4.1", contextWindow: 1_047_576, charsPerToken: 4},
+	{prefix: "gpt-4o", contextWindow: 128_000, charsPerToken: 4},
+	{prefix: "o1", contextWindow: 200_000, charsPerToken: 4},
+	{prefix: "o3", contextWindow: 200_000, charsPerToken: 4},
+	{prefix: "o4", contextWindow: 200_000, charsPerToken: 4},
+	{prefix: "gpt-4-turbo", contextWindow: 128_000, charsPerToken: 3.7},
+	{prefix: "gpt-4", contextWindow: 8_192, charsPerToken: 3.7},
+	{prefix: "gpt-3.5-turbo", contextWindow: 16_385, charsPerToken: 3.7},
+}
+
+const (
+	// Tokens added by the chat format to every message, and to prime the reply.
+	tokensPerMessage = 4
+	tokensPerReply   = 3
+
+	// Used for unknown models, slightly pessimistic so budgets are not exceeded.
+	defaultCharsPerToken = 3.5
+)
+
+func limitsOf(model string) (contextWindow int, charsPerToken float64) {
+	contextWindow, charsPerToken = DefaultContextWindow, defaultCharsPerToken
+
+	longest := 0
+	for _, m := range modelLimits {
+		if strings.HasPrefix(model, m.prefix) && len(m.prefix) > longest {
+			contextWindow, charsPerToken, longest = m.contextWindow, m.charsPerToken, len(m.prefix)
+		}
+	}
+
+	return contextWindow, charsPerToken
+}
+
+// ContextWindow returns the maximum number of tokens the model accepts, prompt and completion combined.
+func ContextWindow(model string) int {
+	window, _ := limitsOf(model)
+	return window
+}
+
+// EstimateTokens approximates the number of prompt tokens the request takes, including its tools. It does not
+// run the model's tokenizer, the estimate is meant for budgeting rather than billing.
+func EstimateTokens(req Request) int {
+	_, charsPerToken := limitsOf(req.Model)
+
+	chars := 0
+	for _, m := range req.Messages {
+		chars += len(m.Content)
+		for _, call := range m.ToolCalls {
+			chars += len(call.Name) + len(call.Arguments)
+		}
+	}
+
+	for _, tool := range req.Tools {
+		chars += len(tool.Name) + len(tool.Description)
+		if data, err := json.Marshal(tool.Parameters); err == nil {
+			chars += len(data)
+		}
+	}
+
+	return int(math.Ceil(float64(chars)/charsPerToken)) + len(req.Messages)*tokensPerMessage + tokensPerReply
+}
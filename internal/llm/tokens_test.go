@@ -0,0 +1,67 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestContextWindow(t *testing.T) {
+	tests := []struct {
+		model string
+		want  int
+	}{
+		{model: "gpt-4.1", want: 1_047_576},
+		{model: "gpt-4.1-mini", want: 1_047_576},
+		{model: "gpt-4o-mini", want: 128_000},
+		{model: "gpt-4-turbo-preview", want: 128_000},
+		{model: "gpt-4-0613", want: 8_192},
+		{model: "o1", want: 200_000},
+		{model: "llama3", want: DefaultContextWindow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			if got := ContextWindow(tt.model); got != tt.want {
+				t.Errorf("ContextWindow(%q) = %d, want %d", tt.model, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	text := strings.Repeat("abcd", 100)
+
+	t.Run("counts content and message overhead", func(t *testing.T) {
+		got := EstimateTokens(Request{Model: "gpt-4o", Messages: []Message{SystemMessage(text), UserMessage(text)}})
+
+		// 800 bytes at 4 bytes per token, 4 tokens per message and 3 to prime the reply.
+		if want := 200 + 2*4 + 3; got != want {
+			t.Errorf("EstimateTokens() = %d, want %d", got, want)
+		}
+	})
+
+	t.Run("unknown models are estimated pessimistically", func(t *testing.T) {
+		known := EstimateTokens(Request{Model: "gpt-4o", Messages: []Message{UserMessage(text)}})
+		unknown := EstimateTokens(Request{Model: "llama3", Messages: []Message{UserMessage(text)}})
+
+		if unknown <= known {
+			t.Errorf("expected more tokens for an unknown model, got %d <= %d", unknown, known)
+		}
+	})
+
+	t.Run("counts tool calls and tools", func(t *testing.T) {
+		base := Request{Model: "gpt-4o", Messages: []Message{UserMessage("What is 2 + 2?")}}
+		withCall := base
+		withCall.Messages = append(withCall.Messages, Message{Role: RoleAssistant, ToolCalls: []ToolCall{{ID: "call_1", Name: "calculate", Arguments: `{"expression":"2 + 2"}`}}})
+		withTools := base
+		withTools.Tools = []Tool{{Name: "calculate", Description: "Evaluate an expression", Parameters: map[string]any{"type": "object"}}}
+
+		if EstimateTokens(withCall) <= EstimateTokens(base)+tokensPerMessage {
+			t.Error("expected tool calls to add to the estimate")
+		}
+
+		if EstimateTokens(withTools) <= EstimateTokens(base) {
+			t.Error("expected tools to add to the estimate")
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+)
+
+// clientInfo is how the client introduces itself to servers.
+var clientInfo = Implementation{Name: "acai-assistant", Version: "1.0.0"}
+
+// maxToolPages bounds the number of pages of tools listed from a server.
+const maxToolPages = 100
+
+// Client is a connection to an MCP server. It is safe for concurrent use.
+type Client struct {
+	name   string
+	t      transport
+	lastID atomic.Int64
+
+	// Server is how the server introduced itself.
+	Server Implementation
+}
+
+// DialStdio runs the command as an MCP server, with env added to our environment, and connects to it over its
+// standard input and output. The server is named name in errors and logs.
+func DialStdio(ctx context.Context, name, command string, args []string, env map[string]string) (*Client, error) {
+	t, err := newStdioTransport(command, args, env)
+	if err != nil {
+		return nil, fmt.Errorf("mcp server %s: %w", name, err)
+	}
+
+	return connect(ctx, name, t)
+}
+
+// DialHTTP connects to the MCP server at url over the streamable HTTP transport, sending headers, e.g. for
+// authentication, with every request.
+func DialHTTP(ctx context.Context, name, url string, headers map[string]string) (*Client, error) {
+	return connect(ctx, name, newHTTPTransport(url, headers))
+}
+
+// connect initializes the session with the server.
+func connect(ctx context.Context, name string, t transport) (*Client, error) {
+	c := &Client{name: name, t: t}
+
+	var result initializeResult
+	params := initializeParams{ProtocolVersion: protocolVersion, Capabilities: map[string]any{}, ClientInfo: clientInfo}
+	if err := c.call(ctx, "initialize", params, &result); err != nil {
+		_ = t.close()
+		return nil, err
+	}
+
+	if err := t.notify(ctx, request{JSONRPC: "2.0", Method: "notifications/initialized"}); err != nil {
+		_ = t.close()
+		return nil, fmt.Errorf("mcp server %s: %w", name, err)
+	}
+
+	c.Server = result.ServerInfo
+	return c, nil
+}
+
+// Name returns the name the server was dialed with.
+func (c *Client) Name() string {
+	return c.name
+}
+
+// call sends a request, and decodes its result into result.
+func (c *Client) call(ctx context.Context, method string, params, result any) error {
+	id := c.lastID.Add(1)
+
+	msg, err := c.t.call(ctx, request{JSONRPC: "2.0", ID: &id, Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("mcp server %s: %s: %w", c.name, method, err)
+	}
+
+	if msg.Error != nil {
+		return fmt.Errorf("mcp server %s: %s: %w", c.name, method, msg.Error)
+	}
+
+	if err := json.Unmarshal(msg.Result, result); err != nil {
+		return fmt.Errorf("mcp server %s: %s: invalid result: %w", c.name, method, err)
+	}
+
+	return nil
+}
+
+// ListTools returns the tools of the server. Listing stops at a cursor the server already returned, and fails
+// after maxToolPages pages, so a server paging forever cannot keep it going.
+func (c *Client) ListTools(ctx context.Context) ([]ToolInfo, error) {
+	var tools []ToolInfo
+	var cursor string
+	seen := map[string]bool{}
+
+	for range maxToolPages {
+		var result listToolsResult
+		if err := c.call(ctx, "tools/list", listToolsParams{Cursor: cursor}, &result); err != nil {
+			return nil, err
+		}
+
+		tools = append(tools, result.Tools...)
+		if cursor = result.NextCursor; cursor == "" || seen[cursor] {
+			return tools, nil
+		}
+		seen[cursor] = true
+	}
+
+	return nil, fmt.Errorf("mcp server %s: tools/list: more than %d pages of tools", c.name, maxToolPages)
+}
+
+// CallTool calls the named tool with the JSON object arguments.
+func (c *Client) CallTool(ctx context.Context, name string, arguments json.RawMessage) (*CallToolResult, error) {
+	var result CallToolResult
+	if err := c.call(ctx, "tools/call", callToolParams{Name: name, Arguments: arguments}, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Close ends the connection. Servers run by DialStdio are stopped.
+func (c *Client) Close() error {
+	return c.t.close()
+}
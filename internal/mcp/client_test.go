@@ -0,0 +1,308 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/tools"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	buildOnce   sync.Once
+	buildDir    string
+	buildBinary string
+	buildErr    error
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if buildDir != "" {
+		_ = os.RemoveAll(buildDir)
+	}
+	os.Exit(code)
+}
+
+// testServer builds cmd/mcp-testserver, once per test run, and returns the path of the binary.
+func testServer(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found, cannot build the test server")
+	}
+
+	buildOnce.Do(func() {
+		buildDir, buildErr = os.MkdirTemp("", "mcp-testserver")
+		if buildErr != nil {
+			return
+		}
+
+		buildBinary = filepath.Join(buildDir, "mcp-testserver")
+		out, err := exec.Command("go", "build", "-o", buildBinary, "github.com/acai-travel/tech-challenge/cmd/mcp-testserver").CombinedOutput()
+		if err != nil {
+			buildErr = err
+			t.Log(string(out))
+		}
+	})
+
+	if buildErr != nil {
+		t.Fatalf("failed to build the test server: %v", buildErr)
+	}
+
+	return buildBinary
+}
+
+// listen runs the test server over HTTP, and returns its endpoint URL.
+func listen(t *testing.T) string {
+	cmd := exec.Command(testServer(t), "-listen", "127.0.0.1:0")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	url, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Fatalf("failed to read the test server URL: %v", err)
+	}
+
+	return strings.TrimSpace(url)
+}
+
+func TestClient(t *testing.T) {
+	transports := map[string]func(t *testing.T) ServerConfig{
+		"stdio": func(t *testing.T) ServerConfig { return ServerConfig{Command: testServer(t)} },
+		"http":  func(t *testing.T) ServerConfig { return ServerConfig{URL: listen(t)} },
+	}
+
+	for name, config := range transports {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			client, err := config(t).Dial(ctx, "test")
+			if err != nil {
+				t.Fatalf("Dial() error = %v", err)
+			}
+			defer client.Close()
+
+			if client.Server.Name != "mcp-testserver" {
+				t.Errorf("unexpected server info: %+v", client.Server)
+			}
+
+			serverTools, err := client.Tools(ctx)
+			if err != nil {
+				t.Fatalf("Tools() error = %v", err)
+			}
+
+			byName := map[string]*Tool{}
+			for _, tool := range serverTools {
+				byName[tool.Name()] = tool
+			}
+
+			// The broken tool, whose schema is not an object, is skipped.
+			if len(byName) != 3 || byName["echo"] == nil || byName["add"] == nil || byName["fail"] == nil {
+				t.Fatalf("unexpected tools: %v", byName)
+			}
+
+			wantSchema := &tools.Schema{
+				Type: "object",
+				Properties: map[string]*tools.Schema{
+					"a": {Type: "number"},
+					"b": {Type: "number"},
+				},
+				Required: []string{"a", "b"},
+			}
+			if diff := cmp.Diff(wantSchema, byName["add"].Parameters()); diff != "" {
+				t.Errorf("add schema mismatch (-want +got):\n%s", diff)
+			}
+
+			if got := byName["fail"].Description(); got != "Always fails" {
+				t.Errorf("expected the title as description, got %q", got)
+			}
+
+			if got := byName["echo"].CacheTTL(); got != tools.DefaultCacheTTL {
+				t.Errorf("expected read-only tools to be cached, got TTL %s", got)
+			}
+			if got := byName["add"].CacheTTL(); got != 0 {
+				t.Errorf("expected other tools not to be cached, got TTL %s", got)
+			}
+
+			if result, err := byName["echo"].Execute(ctx, `{"text": "hello"}`); err != nil || result != "hello" {
+				t.Errorf("echo = %q, %v, want hello", result, err)
+			}
+
+			if result, err := byName["add"].Execute(ctx, `{"a": 2, "b": 3}`); err != nil || result != "5" {
+				t.Errorf("add = %q, %v, want 5", result, err)
+			}
+
+			if _, err := byName["fail"].Execute(ctx, ``); err == nil || err.Error() != "something went wrong" {
+				t.Errorf("fail error = %v, want the tool's error", err)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	defer func(timeout time.Duration) { connectTimeout = timeout }(connectTimeout)
+	connectTimeout = 2 * time.Second
+
+	registry := &tools.Registry{}
+	registry.Register(&tools.CalculatorTool{})
+	registry.Register(&echoTool{})
+
+	clients := Register(ctx, registry, &Config{Servers: map[string]ServerConfig{
+		"test": {Command: testServer(t)},
+		"down": {Command: filepath.Join(t.TempDir(), "missing")},
+		"mute": {Command: "sh", Args: []string{"-c", "cat > /dev/null"}},
+	}})
+	for _, client := range clients {
+		defer client.Close()
+	}
+
+	if len(clients) != 1 || clients[0].Name() != "test" {
+		t.Fatalf("expected a client for the answering server only, got %d", len(clients))
+	}
+
+	if diff := cmp.Diff([]string{"add", "calculate", "echo", "fail"}, registry.Names()); diff != "" {
+		t.Errorf("registered tools mismatch (-want +got):\n%s", diff)
+	}
+
+	// The built-in echo tool is kept.
+	if result, err := registry.Execute(ctx, "echo", `{"text": "hi"}`); err != nil || result != "built-in" {
+		t.Errorf("echo = %q, %v, want the built-in tool", result, err)
+	}
+
+	if result, err := registry.Execute(ctx, "add", `{"a": 1, "b": 1}`); err != nil || result != "2" {
+		t.Errorf("add = %q, %v, want 2", result, err)
+	}
+}
+
+// pagingTransport answers tools/list with a page of one tool, and the cursor next returns for the cursor asked.
+type pagingTransport struct {
+	next  func(cursor string) string
+	calls int
+}
+
+func (p *pagingTransport) call(_ context.Context, req request) (*message, error) {
+	p.calls++
+
+	cursor := req.Params.(listToolsParams).Cursor
+	result, err := json.Marshal(listToolsResult{Tools: []ToolInfo{{Name: "page" + cursor}}, NextCursor: p.next(cursor)})
+	return &message{Result: result}, err
+}
+
+func (p *pagingTransport) notify(context.Context, request) error { return nil }
+func (p *pagingTransport) close() error                          { return nil }
+
+func TestClient_ListToolsPaging(t *testing.T) {
+	tests := []struct {
+		name      string
+		next      func(cursor string) string
+		wantTools int
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "pages", next: func(cursor string) string { return map[string]string{"": "2", "2": "3"}[cursor] }, wantTools: 3, wantCalls: 3},
+		{name: "repeated cursor", next: func(string) string { return "again" }, wantTools: 2, wantCalls: 2},
+		{name: "endless pages", next: func(cursor string) string { return cursor + "x" }, wantCalls: maxToolPages, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &pagingTransport{next: tt.next}
+			client := &Client{name: "test", t: transport}
+
+			infos, err := client.ListTools(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListTools() error = %v, want error %v", err, tt.wantErr)
+			}
+			if len(infos) != tt.wantTools || transport.calls != tt.wantCalls {
+				t.Errorf("got %d tools in %d calls, want %d in %d", len(infos), transport.calls, tt.wantTools, tt.wantCalls)
+			}
+		})
+	}
+}
+
+type echoTool struct{}
+
+func (echoTool) Name() string                                    { return "echo" }
+func (echoTool) Description() string                             { return "Built-in echo" }
+func (echoTool) Parameters() *tools.Schema                       { return &tools.Schema{Type: "object"} }
+func (echoTool) Execute(context.Context, string) (string, error) { return "built-in", nil }
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *Config
+		wantErr string
+	}{
+		{
+			name:    "servers",
+			content: `{"mcpServers": {"files": {"command": "mcp-files", "args": ["--root", "/srv"]}, "search": {"url": "https://search.example.com/mcp", "headers": {"Authorization": "Bearer x"}}}}`,
+			want: &Config{Servers: map[string]ServerConfig{
+				"files":  {Command: "mcp-files", Args: []string{"--root", "/srv"}},
+				"search": {URL: "https://search.example.com/mcp", Headers: map[string]string{"Authorization": "Bearer x"}},
+			}},
+		},
+		{
+			name:    "command and url",
+			content: `{"mcpServers": {"both": {"command": "mcp-files", "url": "https://search.example.com/mcp"}}}`,
+			wantErr: "exactly one of command and url must be set",
+		},
+		{
+			name:    "neither command nor url",
+			content: `{"mcpServers": {"none": {}}}`,
+			wantErr: "exactly one of command and url must be set",
+		},
+		{
+			name:    "invalid JSON",
+			content: `{"mcpServers": `,
+			wantErr: "failed to parse MCP config",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "mcp.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := LoadConfig(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadConfig() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("LoadConfig() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
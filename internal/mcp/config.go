@@ -0,0 +1,123 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/tools"
+)
+
+// connectTimeout bounds connecting to a server and listing its tools, so a server that never answers does not keep
+// the assistant from starting.
+var connectTimeout = 10 * time.Second
+
+// Config lists the MCP servers to connect to, keyed by name. It is read from a JSON file in the format used by
+// most MCP clients:
+//
+//	{"mcpServers": {
+//	  "files": {"command": "mcp-files", "args": ["--root", "/srv"], "env": {"LOG_LEVEL": "warn"}},
+//	  "search": {"url": "https://search.example.com/mcp", "headers": {"Authorization": "Bearer ..."}}
+//	}}
+type Config struct {
+	Servers map[string]ServerConfig `json:"mcpServers"`
+}
+
+// ServerConfig tells how to reach an MCP server: either the command running it, over stdio, or its URL, over
+// streamable HTTP.
+type ServerConfig struct {
+	Command string            `json:"command,omitempty"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// LoadConfig reads the configuration file at path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read MCP config: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse MCP config %s: %w", path, err)
+	}
+
+	for name, server := range cfg.Servers {
+		if (server.Command == "") == (server.URL == "") {
+			return nil, fmt.Errorf("MCP server %s in %s: exactly one of command and url must be set", name, path)
+		}
+	}
+
+	return &cfg, nil
+}
+
+// Dial connects to the server.
+func (s ServerConfig) Dial(ctx context.Context, name string) (*Client, error) {
+	if s.URL != "" {
+		return DialHTTP(ctx, name, s.URL, s.Headers)
+	}
+	return DialStdio(ctx, name, s.Command, s.Args, s.Env)
+}
+
+// Register connects to the servers of the configuration, in name order, and registers their tools in registry.
+// Tools named like one already registered are skipped, and so are servers that cannot be reached or do not answer
+// in time, so a server being down does not keep the assistant from starting. It returns the clients, to be closed
+// on shutdown.
+func Register(ctx context.Context, registry *tools.Registry, cfg *Config) []*Client {
+	names := make([]string, 0, len(cfg.Servers))
+	for name := range cfg.Servers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var clients []*Client
+	for _, name := range names {
+		client, serverTools, err := connectTools(ctx, name, cfg.Servers[name])
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to connect to MCP server", "server", name, "error", err)
+			continue
+		}
+
+		var registered []string
+		for _, tool := range serverTools {
+			if slices.Contains(registry.Names(), tool.Name()) {
+				slog.WarnContext(ctx, "Skipping MCP tool named like a registered tool", "server", name, "tool", tool.Name())
+				continue
+			}
+
+			registry.Register(tool)
+			registered = append(registered, tool.Name())
+		}
+
+		slog.InfoContext(ctx, "Connected to MCP server", "server", name, "tools", registered)
+		clients = append(clients, client)
+	}
+
+	return clients
+}
+
+// connectTools connects to the server and lists its tools, within connectTimeout.
+func connectTools(ctx context.Context, name string, server ServerConfig) (*Client, []*Tool, error) {
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+
+	client, err := server.Dial(ctx, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serverTools, err := client.Tools(ctx)
+	if err != nil {
+		_ = client.Close()
+		return nil, nil, err
+	}
+
+	return client, serverTools, nil
+}
@@ -0,0 +1,203 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+
+	"github.com/acai-travel/tech-challenge/internal/tools"
+)
+
+// Server serves the tools of a registry over MCP, on stdio with ServeStdio, or over streamable HTTP as an
+// http.Handler. It is stateless: no session is assigned, and every request is answered on its own.
+type Server struct {
+	registry *tools.Registry
+	info     Implementation
+}
+
+// NewServer creates a server for the tools of registry, introducing itself to clients with info.
+func NewServer(registry *tools.Registry, info Implementation) *Server {
+	return &Server{registry: registry, info: info}
+}
+
+// ServeStdio answers the newline-delimited messages read from in, writing responses to out, until in ends.
+// Requests are handled concurrently, so a slow tool call does not hold up the others.
+func (s *Server) ServeStdio(ctx context.Context, in io.Reader, out io.Writer) error {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	enc := json.NewEncoder(out)
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+
+	for scanner.Scan() {
+		data := slices.Clone(scanner.Bytes())
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if resp := s.handle(ctx, data); resp != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				if err := enc.Encode(resp); err != nil {
+					slog.ErrorContext(ctx, "Failed to write MCP response", "error", err)
+				}
+			}
+		}()
+	}
+
+	return scanner.Err()
+}
+
+// ServeHTTP implements the streamable HTTP transport: messages are POSTed, and responses sent back as JSON.
+// The server does not open streams, so GET is not allowed.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Browsers send an Origin, which must be ours, so web pages cannot call a server listening locally.
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if u, err := url.Parse(origin); err != nil || u.Host != r.Host {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return
+		}
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+		http.Error(w, "content type must be application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxMessageSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := s.handle(r.Context(), data)
+	if resp == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		slog.ErrorContext(r.Context(), "Failed to write MCP response", "error", err)
+	}
+}
+
+// handle answers a message, returning nil for notifications and responses, which need no answer.
+func (s *Server) handle(ctx context.Context, data []byte) *response {
+	var msg message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return &response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &RPCError{Code: codeParseError, Message: "invalid JSON"}}
+	}
+
+	if msg.ID == nil || msg.isResponse() {
+		return nil
+	}
+
+	result, err := s.dispatch(ctx, &msg)
+	if err != nil {
+		rpcErr := &RPCError{Code: codeInvalidParams, Message: err.Error()}
+		errors.As(err, &rpcErr)
+		return &response{JSONRPC: "2.0", ID: msg.ID, Error: rpcErr}
+	}
+
+	return &response{JSONRPC: "2.0", ID: msg.ID, Result: result}
+}
+
+func (s *Server) dispatch(ctx context.Context, msg *message) (any, error) {
+	switch msg.Method {
+	case "initialize":
+		var params initializeParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+
+		// The client's revision is spoken when supported, the latest one otherwise.
+		version := protocolVersion
+		if slices.Contains(protocolVersions, params.ProtocolVersion) {
+			version = params.ProtocolVersion
+		}
+
+		return initializeResult{
+			ProtocolVersion: version,
+			Capabilities:    map[string]any{"tools": map[string]any{}},
+			ServerInfo:      s.info,
+		}, nil
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return s.listTools()
+	case "tools/call":
+		var params callToolParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.callTool(ctx, params)
+	default:
+		return nil, &RPCError{Code: codeMethodNotFound, Message: "method not found: " + msg.Method}
+	}
+}
+
+func decodeParams(msg *message, params any) error {
+	if len(msg.Params) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(msg.Params, params); err != nil {
+		return &RPCError{Code: codeInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+	return nil
+}
+
+// listTools returns every tool of the registry, in a single page.
+func (s *Server) listTools() (*listToolsResult, error) {
+	result := &listToolsResult{Tools: []ToolInfo{}}
+
+	for _, tool := range s.registry.GetTools() {
+		schema, err := json.Marshal(tool.Parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Tools = append(result.Tools, ToolInfo{Name: tool.Name, Description: tool.Description, InputSchema: schema})
+	}
+
+	return result, nil
+}
+
+// callTool executes a tool of the registry. Unknown tools are a protocol error, other failures are reported in
+// the result, as the structured error of tools.ToolError, so the calling model can correct its call.
+func (s *Server) callTool(ctx context.Context, params callToolParams) (*CallToolResult, error) {
+	args := string(params.Arguments)
+
+	result, err := s.registry.Execute(ctx, params.Name, args)
+	if err != nil {
+		var terr *tools.ToolError
+		if errors.As(err, &terr) && terr.Code == tools.CodeUnknownTool {
+			return nil, &RPCError{Code: codeInvalidParams, Message: terr.Message}
+		}
+		if terr == nil {
+			terr = &tools.ToolError{Tool: params.Name, Code: tools.CodeExecutionFailed, Message: err.Error()}
+		}
+
+		return &CallToolResult{Content: []Content{{Type: "text", Text: terr.Result()}}, IsError: true}, nil
+	}
+
+	return &CallToolResult{Content: []Content{{Type: "text", Text: result}}}, nil
+}
@@ -0,0 +1,156 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/tools"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTestServer() *Server {
+	registry := &tools.Registry{}
+	registry.Register(&tools.CalculatorTool{})
+	registry.Register(&tools.DateTool{})
+
+	return NewServer(registry, Implementation{Name: "test-tools", Version: "1.0.0"})
+}
+
+func TestServer_HTTP(t *testing.T) {
+	srv := httptest.NewServer(newTestServer())
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The server is exercised through the client, checking both ends agree.
+	client, err := DialHTTP(ctx, "test", srv.URL, nil)
+	if err != nil {
+		t.Fatalf("DialHTTP() error = %v", err)
+	}
+	defer client.Close()
+
+	if client.Server.Name != "test-tools" {
+		t.Errorf("unexpected server info: %+v", client.Server)
+	}
+
+	serverTools, err := client.Tools(ctx)
+	if err != nil {
+		t.Fatalf("Tools() error = %v", err)
+	}
+
+	if len(serverTools) != 2 || serverTools[0].Name() != "calculate" || serverTools[1].Name() != "get_today_date" {
+		t.Fatalf("unexpected tools: %v", serverTools)
+	}
+
+	if diff := cmp.Diff((&tools.CalculatorTool{}).Parameters(), serverTools[0].Parameters()); diff != "" {
+		t.Errorf("calculate schema mismatch (-want +got):\n%s", diff)
+	}
+
+	if result, err := serverTools[0].Execute(ctx, `{"expression": "6 * 7"}`); err != nil || result != "6 * 7 = 42" {
+		t.Errorf("calculate = %q, %v, want 6 * 7 = 42", result, err)
+	}
+
+	// Failures are tool errors, with the registry's structured error as content.
+	_, err = serverTools[0].Execute(ctx, `{"expression": 2}`)
+	if err == nil || !strings.Contains(err.Error(), `"code":"invalid_arguments"`) {
+		t.Errorf("calculate error = %v, want invalid arguments", err)
+	}
+
+	// Unknown tools are protocol errors.
+	_, err = client.CallTool(ctx, "get_stock_price", json.RawMessage(`{}`))
+	if err == nil || !strings.Contains(err.Error(), "unknown tool: get_stock_price (code -32602)") {
+		t.Errorf("CallTool() error = %v, want an unknown tool error", err)
+	}
+}
+
+func TestServer_HTTPRequests(t *testing.T) {
+	srv := httptest.NewServer(newTestServer())
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		method     string
+		origin     string
+		body       string
+		wantStatus int
+	}{
+		{name: "request", method: http.MethodPost, body: `{"jsonrpc": "2.0", "id": 1, "method": "ping"}`, wantStatus: http.StatusOK},
+		{name: "notification", method: http.MethodPost, body: `{"jsonrpc": "2.0", "method": "notifications/initialized"}`, wantStatus: http.StatusAccepted},
+		{name: "stream", method: http.MethodGet, wantStatus: http.StatusMethodNotAllowed},
+		{name: "foreign origin", method: http.MethodPost, origin: "https://evil.example.com", body: `{"jsonrpc": "2.0", "id": 1, "method": "ping"}`, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, srv.URL, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestServer_Stdio(t *testing.T) {
+	in := strings.Join([]string{
+		`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"protocolVersion": "2025-03-26", "capabilities": {}, "clientInfo": {"name": "test", "version": "1"}}}`,
+		`{"jsonrpc": "2.0", "method": "notifications/initialized"}`,
+		`{"jsonrpc": "2.0", "id": 2, "method": "tools/call", "params": {"name": "calculate", "arguments": {"expression": "1+2"}}}`,
+		`{"jsonrpc": "2.0", "id": 3, "method": "resources/list"}`,
+		`not json`,
+	}, "\n")
+
+	var out bytes.Buffer
+	if err := newTestServer().ServeStdio(context.Background(), strings.NewReader(in), &out); err != nil {
+		t.Fatalf("ServeStdio() error = %v", err)
+	}
+
+	// Requests are handled concurrently, responses are matched by ID.
+	got := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var msg struct {
+			ID     json.RawMessage `json:"id"`
+			Result json.RawMessage `json:"result"`
+			Error  *RPCError       `json:"error"`
+		}
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			t.Fatalf("invalid response %q: %v", line, err)
+		}
+
+		if msg.Error != nil {
+			got[string(msg.ID)] = msg.Error.Error()
+		} else {
+			got[string(msg.ID)] = string(msg.Result)
+		}
+	}
+
+	want := map[string]string{
+		"1":    `{"protocolVersion":"2025-03-26","capabilities":{"tools":{}},"serverInfo":{"name":"test-tools","version":"1.0.0"}}`,
+		"2":    `{"content":[{"type":"text","text":"1 + 2 = 3"}]}`,
+		"3":    "method not found: resources/list (code -32601)",
+		"null": "invalid JSON (code -32700)",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("responses mismatch (-want +got):\n%s", diff)
+	}
+}
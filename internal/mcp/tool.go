@@ -0,0 +1,163 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/tools"
+)
+
+// Tool is a tool of an MCP server, usable as one of the assistant's tools.
+type Tool struct {
+	client *Client
+	info   ToolInfo
+	schema *tools.Schema
+}
+
+var _ tools.Tool = (*Tool)(nil)
+
+// Tools returns the tools of the server. Tools whose input schema is not a JSON object are logged and skipped,
+// the model would not know how to call them.
+func (c *Client) Tools(ctx context.Context) ([]*Tool, error) {
+	infos, err := c.ListTools(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*Tool, 0, len(infos))
+	for _, info := range infos {
+		var schema map[string]any
+		if len(info.InputSchema) > 0 {
+			if err := json.Unmarshal(info.InputSchema, &schema); err != nil {
+				slog.WarnContext(ctx, "Skipping MCP tool with an invalid input schema", "server", c.name, "tool", info.Name, "error", err)
+				continue
+			}
+		}
+
+		tool := &Tool{client: c, info: info, schema: schemaFrom(schema)}
+		tool.schema.Type = "object"
+		out = append(out, tool)
+	}
+
+	return out, nil
+}
+
+// Name returns the tool's name on its server.
+func (t *Tool) Name() string {
+	return t.info.Name
+}
+
+// Description returns what the tool does, or its title when the server does not describe it.
+func (t *Tool) Description() string {
+	if t.info.Description != "" {
+		return t.info.Description
+	}
+	return t.info.Title
+}
+
+// Parameters returns the tool's input schema, limited to the keywords of tools.Schema.
+func (t *Tool) Parameters() *tools.Schema {
+	return t.schema
+}
+
+// CacheTTL only caches tools the server declares read-only.
+func (t *Tool) CacheTTL() time.Duration {
+	if t.info.Annotations.ReadOnlyHint {
+		return tools.DefaultCacheTTL
+	}
+	return 0
+}
+
+// Execute calls the tool on its server. Its text content is returned, joined by newlines, or its structured
+// content when it has no text. Errors reported by the tool are returned as errors.
+func (t *Tool) Execute(ctx context.Context, args string) (string, error) {
+	if strings.TrimSpace(args) == "" {
+		args = "{}"
+	}
+
+	result, err := t.client.CallTool(ctx, t.info.Name, json.RawMessage(args))
+	if err != nil {
+		return "", err
+	}
+
+	var texts []string
+	for _, content := range result.Content {
+		switch content.Type {
+		case "text":
+			texts = append(texts, content.Text)
+		default:
+			texts = append(texts, fmt.Sprintf("[%s content omitted]", content.Type))
+		}
+	}
+
+	text := strings.Join(texts, "\n")
+	if len(texts) == 0 && len(result.StructuredContent) > 0 {
+		text = string(result.StructuredContent)
+	}
+
+	if result.IsError {
+		if text == "" {
+			text = "tool call failed"
+		}
+		return "", errors.New(text)
+	}
+
+	return text, nil
+}
+
+// schemaFrom converts a decoded JSON schema to a tools.Schema. Keywords tools.Schema has no field for are
+// dropped, and union types are reduced to their first non-null type.
+func schemaFrom(v any) *tools.Schema {
+	m, _ := v.(map[string]any)
+	s := &tools.Schema{}
+
+	switch typ := m["type"].(type) {
+	case string:
+		s.Type = typ
+	case []any:
+		for _, t := range typ {
+			if t, ok := t.(string); ok && t != "null" {
+				s.Type = t
+				break
+			}
+		}
+	}
+
+	s.Description, _ = m["description"].(string)
+	s.Format, _ = m["format"].(string)
+
+	if properties, ok := m["properties"].(map[string]any); ok {
+		s.Properties = make(map[string]*tools.Schema, len(properties))
+		for name, property := range properties {
+			s.Properties[name] = schemaFrom(property)
+		}
+	}
+
+	if required, ok := m["required"].([]any); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				s.Required = append(s.Required, name)
+			}
+		}
+	}
+
+	if items, ok := m["items"].(map[string]any); ok {
+		s.Items = schemaFrom(items)
+	}
+
+	// Only string enums are supported.
+	if enum, ok := m["enum"].([]any); ok && s.Type == "string" {
+		for _, value := range enum {
+			if value, ok := value.(string); ok {
+				s.Enum = append(s.Enum, value)
+			}
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,345 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// transport carries JSON-RPC messages to and from an MCP server.
+type transport interface {
+	// call sends a request, and returns the server's response to it.
+	call(ctx context.Context, req request) (*message, error)
+	// notify sends a notification.
+	notify(ctx context.Context, req request) error
+	close() error
+}
+
+// maxMessageSize bounds the size of the messages read from servers.
+const maxMessageSize = 16 * 1024 * 1024
+
+// stdioTransport talks to a server run as a subprocess, exchanging newline-delimited messages over its standard
+// input and output. Its standard error is forwarded to ours.
+type stdioTransport struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+
+	mu      sync.Mutex // Guards writes to stdin and pending.
+	pending map[string]chan *message
+	done    chan struct{} // Closed once the server's output ends.
+	err     error         // Why the output ended, set before done is closed.
+}
+
+func newStdioTransport(command string, args []string, env map[string]string) (*stdioTransport, error) {
+	cmd := exec.Command(command, args...)
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	for k, v := range env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", command, err)
+	}
+
+	t := &stdioTransport{
+		cmd:     cmd,
+		stdin:   stdin,
+		pending: make(map[string]chan *message),
+		done:    make(chan struct{}),
+	}
+	go t.read(stdout)
+
+	return t, nil
+}
+
+// read dispatches the messages of the server until its output ends.
+func (t *stdioTransport) read(stdout io.Reader) {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+
+	for scanner.Scan() {
+		var msg message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			slog.Warn("Ignoring invalid MCP message", "error", err)
+			continue
+		}
+
+		switch {
+		case msg.isResponse():
+			t.mu.Lock()
+			ch := t.pending[string(msg.ID)]
+			delete(t.pending, string(msg.ID))
+			t.mu.Unlock()
+
+			if ch != nil {
+				ch <- &msg
+			}
+		case msg.ID != nil:
+			t.answer(&msg)
+		}
+	}
+
+	t.err = scanner.Err()
+	if t.err == nil {
+		t.err = errors.New("server closed its output")
+	}
+	close(t.done)
+}
+
+// answer responds to a request of the server: pings are answered, other methods are not supported.
+func (t *stdioTransport) answer(msg *message) {
+	resp := response{JSONRPC: "2.0", ID: msg.ID, Result: struct{}{}}
+	if msg.Method != "ping" {
+		resp = response{JSONRPC: "2.0", ID: msg.ID, Error: &RPCError{Code: codeMethodNotFound, Message: "method not found"}}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_ = t.write(resp)
+}
+
+// write sends a message, t.mu must be held.
+func (t *stdioTransport) write(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = t.stdin.Write(append(data, '\n'))
+	return err
+}
+
+func (t *stdioTransport) call(ctx context.Context, req request) (*message, error) {
+	id := fmt.Sprint(*req.ID)
+	ch := make(chan *message, 1)
+
+	t.mu.Lock()
+	t.pending[id] = ch
+	err := t.write(req)
+	t.mu.Unlock()
+
+	if err != nil {
+		t.forget(id)
+		return nil, err
+	}
+
+	select {
+	case msg := <-ch:
+		return msg, nil
+	case <-t.done:
+		t.forget(id)
+		return nil, t.err
+	case <-ctx.Done():
+		t.forget(id)
+		return nil, ctx.Err()
+	}
+}
+
+func (t *stdioTransport) forget(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.pending, id)
+}
+
+func (t *stdioTransport) notify(ctx context.Context, req request) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.write(req)
+}
+
+// close closes the server's input, which asks it to exit, and kills it if it has not a few seconds later.
+func (t *stdioTransport) close() error {
+	_ = t.stdin.Close()
+
+	select {
+	case <-t.done:
+	case <-time.After(5 * time.Second):
+		_ = t.cmd.Process.Kill()
+	}
+
+	_ = t.cmd.Wait()
+	return nil
+}
+
+// sessionHeader carries the session the server assigned on initialization.
+const sessionHeader = "Mcp-Session-Id"
+
+// httpTransport talks to a server over the streamable HTTP transport: messages are POSTed, and responses come
+// back either as JSON or as a stream of server-sent events.
+type httpTransport struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+
+	mu      sync.Mutex
+	session string
+}
+
+func newHTTPTransport(url string, headers map[string]string) *httpTransport {
+	return &httpTransport{url: url, headers: headers, client: &http.Client{}}
+}
+
+func (t *httpTransport) post(ctx context.Context, req request) (*http.Response, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := t.request(ctx, http.MethodPost, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json, text/event-stream")
+
+	resp, err := t.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if session := resp.Header.Get(sessionHeader); session != "" {
+		t.mu.Lock()
+		t.session = session
+		t.mu.Unlock()
+	}
+
+	if resp.StatusCode >= 300 {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("unexpected HTTP status %d from %s", resp.StatusCode, t.url)
+	}
+
+	return resp, nil
+}
+
+// request creates a request to the server, with the configured headers and the session, if any.
+func (t *httpTransport) request(ctx context.Context, method string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, t.url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+
+	req.Header.Set("MCP-Protocol-Version", protocolVersion)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.session != "" {
+		req.Header.Set(sessionHeader, t.session)
+	}
+
+	return req, nil
+}
+
+func (t *httpTransport) call(ctx context.Context, req request) (*message, error) {
+	resp, err := t.post(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	id := fmt.Sprint(*req.ID)
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+
+	if mediaType != "text/event-stream" {
+		var msg message
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxMessageSize)).Decode(&msg); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		return &msg, nil
+	}
+
+	// The stream may carry requests and notifications of the server before the response.
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			data = append(data, strings.TrimPrefix(value, " "))
+			continue
+		}
+		if line != "" || len(data) == 0 {
+			continue
+		}
+
+		var msg message
+		err := json.Unmarshal([]byte(strings.Join(data, "\n")), &msg)
+		data = nil
+
+		if err == nil && msg.isResponse() && string(msg.ID) == id {
+			return &msg, nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("stream ended before the response")
+}
+
+func (t *httpTransport) notify(ctx context.Context, req request) error {
+	resp, err := t.post(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// close ends the session, if the server assigned one.
+func (t *httpTransport) close() error {
+	t.mu.Lock()
+	session := t.session
+	t.mu.Unlock()
+
+	if session == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := t.request(ctx, http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
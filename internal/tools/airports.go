@@ -0,0 +1,407 @@
+package tools
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// airportsCSV is the built-in airport dataset, the main airports of the destinations the assistant is asked about
+// the most.
+//
+//go:embed airports.csv
+var airportsCSV []byte
+
+// Airport is an airport of the dataset.
+type Airport struct {
+	IATA      string // e.g. BCN, empty for airports without one.
+	ICAO      string // e.g. LEBL, the OurAirports ident when no ICAO code is assigned.
+	Type      string // e.g. large_airport.
+	Name      string
+	City      string
+	Country   string // ISO 3166-1 alpha-2 code.
+	Latitude  float64
+	Longitude float64
+	Timezone  string // IANA time zone, empty when unknown.
+}
+
+// Code returns the IATA code of the airport, or its ICAO code without one.
+func (a *Airport) Code() string {
+	return cmp.Or(a.IATA, a.ICAO)
+}
+
+// Airports are a dataset of airports.
+type Airports struct {
+	list []Airport
+}
+
+// LoadAirports reads airports from a CSV file in the format of OurAirports' airports.csv
+// (https://ourairports.com/data/), with an optional timezone column. Columns are found by their header, so a full
+// export can be used as is; closed airports and heliports are skipped.
+func LoadAirports(r io.Reader) (*Airports, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read airports: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("failed to read airports: no header")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[name] = i
+	}
+	for _, name := range []string{"ident", "name", "latitude_deg", "longitude_deg"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("failed to read airports: no %s column", name)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	airports := &Airports{}
+	for n, record := range records[1:] {
+		switch field(record, "type") {
+		case "closed", "heliport", "balloonport":
+			continue
+		}
+
+		latitude, err1 := strconv.ParseFloat(field(record, "latitude_deg"), 64)
+		longitude, err2 := strconv.ParseFloat(field(record, "longitude_deg"), 64)
+		if err := errors.Join(err1, err2); err != nil {
+			return nil, fmt.Errorf("failed to read airports: line %d: %w", n+2, err)
+		}
+
+		airports.list = append(airports.list, Airport{
+			IATA:      strings.ToUpper(field(record, "iata_code")),
+			ICAO:      strings.ToUpper(cmp.Or(field(record, "icao_code"), field(record, "ident"))),
+			Type:      field(record, "type"),
+			Name:      field(record, "name"),
+			City:      field(record, "municipality"),
+			Country:   strings.ToUpper(field(record, "iso_country")),
+			Latitude:  latitude,
+			Longitude: longitude,
+			Timezone:  field(record, "timezone"),
+		})
+	}
+
+	return airports, nil
+}
+
+// defaultAirports is the dataset of travel tools without one: the CSV file at AIRPORTS_FILE, or the built-in one.
+var defaultAirports = sync.OnceValues(func() (*Airports, error) {
+	path := os.Getenv("AIRPORTS_FILE")
+	if path == "" {
+		return LoadAirports(bytes.NewReader(airportsCSV))
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read airports: %w", err)
+	}
+	defer f.Close()
+
+	return LoadAirports(f)
+})
+
+// Find returns the airports matching query, an IATA or ICAO code, a city or part of an airport's name, best matches
+// first: codes, then cities, then names, larger airports first. A non-empty country restricts them to an ISO
+// 3166-1 alpha-2 code.
+func (a *Airports) Find(query, country string) []Airport {
+	query = fold(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+
+	type match struct {
+		airport Airport
+		rank    int
+	}
+
+	var matches []match
+	for _, airport := range a.list {
+		if country != "" && !strings.EqualFold(country, airport.Country) {
+			continue
+		}
+
+		switch {
+		case strings.EqualFold(query, airport.IATA) || strings.EqualFold(query, airport.ICAO):
+			matches = append(matches, match{airport, 0})
+		case query == fold(airport.City):
+			matches = append(matches, match{airport, 1})
+		case strings.Contains(fold(airport.Name), query) || strings.Contains(fold(airport.City), query):
+			matches = append(matches, match{airport, 2})
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b match) int {
+		return cmp.Or(cmp.Compare(a.rank, b.rank), cmp.Compare(airportSize(a.airport.Type), airportSize(b.airport.Type)))
+	})
+
+	airports := make([]Airport, len(matches))
+	for i, m := range matches {
+		airports[i] = m.airport
+	}
+	return airports
+}
+
+// airportSize orders airport types, the largest first.
+func airportSize(kind string) int {
+	switch kind {
+	case "large_airport":
+		return 0
+	case "medium_airport":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Place is a point distances are measured between: an airport, or coordinates.
+type Place struct {
+	Name      string
+	Airport   *Airport // Nil for coordinates.
+	Latitude  float64
+	Longitude float64
+}
+
+// Place resolves coordinates as "latitude,longitude", or the best match of an airport code, city or airport name,
+// optionally followed by a country code as in "London, GB".
+func (a *Airports) Place(query string) (Place, error) {
+	if lat, lon, ok := strings.Cut(query, ","); ok {
+		latitude, err1 := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+		longitude, err2 := strconv.ParseFloat(strings.TrimSpace(lon), 64)
+		if err1 == nil && err2 == nil {
+			if math.Abs(latitude) > 90 || math.Abs(longitude) > 180 {
+				return Place{}, fmt.Errorf("coordinates out of range: %q", query)
+			}
+			return Place{Name: fmt.Sprintf("%.4f, %.4f", latitude, longitude), Latitude: latitude, Longitude: longitude}, nil
+		}
+	}
+
+	name, country, _ := strings.Cut(query, ",")
+	airports := a.Find(name, strings.TrimSpace(country))
+	if len(airports) == 0 {
+		return Place{}, fmt.Errorf("unknown place %q: give an airport code, a city with an airport, or coordinates", query)
+	}
+
+	airport := airports[0]
+	return Place{
+		Name:      fmt.Sprintf("%s (%s)", cmp.Or(airport.City, airport.Name), airport.Code()),
+		Airport:   &airport,
+		Latitude:  airport.Latitude,
+		Longitude: airport.Longitude,
+	}, nil
+}
+
+// fold lower cases s and strips the accents of Latin letters, so "Málaga" matches "malaga".
+func fold(s string) string {
+	return accents.Replace(strings.ToLower(s))
+}
+
+var accents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a", "å", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o", "ø", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n", "ß", "ss", "–", "-",
+)
+
+// earthRadius is the mean radius of the Earth, in km.
+const earthRadius = 6371.0088
+
+// Distance returns the great-circle distance between two places, in km.
+func Distance(from, to Place) float64 {
+	lat1, lat2 := from.Latitude*math.Pi/180, to.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (to.Longitude - from.Longitude) * math.Pi / 180
+
+	// Haversine formula, well conditioned for short distances too.
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Flight time estimates: an average cruise speed, and time to taxi, take off, climb, descend and land.
+const (
+	cruiseSpeed    = 800 // km/h
+	flightOverhead = 30 * time.Minute
+)
+
+// EstimateFlightTime returns the typical duration of a direct flight over a distance in km, to the 5 minutes.
+func EstimateFlightTime(km float64) time.Duration {
+	d := flightOverhead + time.Duration(km/cruiseSpeed*float64(time.Hour))
+	return d.Round(5 * time.Minute)
+}
+
+// AirportTool looks up airports.
+type AirportTool struct {
+	Args[airportArgs]
+
+	// Airports defaults to the dataset at AIRPORTS_FILE, or the built-in one.
+	Airports *Airports
+}
+
+type airportArgs struct {
+	Query    string `json:"query" description:"IATA code (e.g. BCN), ICAO code (e.g. LEBL), city (e.g. London) or part of an airport's name"`
+	Country  string `json:"country,omitempty" description:"Optional ISO 3166-1 alpha-2 country code to restrict airports to, e.g. GB"`
+	MaxCount int    `json:"max_count,omitempty" description:"Optional maximum number of airports to return, 5 if not provided"`
+}
+
+// Name returns the tool's identifier.
+func (a *AirportTool) Name() string {
+	return "find_airport"
+}
+
+// Description returns what the tool does.
+func (a *AirportTool) Description() string {
+	return "Find airports by code, city or name. Each line is an airport in the format " +
+		"'IATA (ICAO): Name, City, Country, latitude, longitude, time zone', best matches first."
+}
+
+// CacheTTL is a day, the dataset does not change while running.
+func (a *AirportTool) CacheTTL() time.Duration {
+	return 24 * time.Hour
+}
+
+// Execute returns the airports matching the query.
+func (a *AirportTool) Execute(ctx context.Context, args string) (string, error) {
+	payload, err := a.Decode(args)
+	if err != nil {
+		return "", err
+	}
+
+	airports, err := a.airports()
+	if err != nil {
+		return "", err
+	}
+
+	matches := airports.Find(payload.Query, payload.Country)
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no airport found for %q", payload.Query)
+	}
+
+	if limit := cmp.Or(payload.MaxCount, 5); limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	lines := make([]string, len(matches))
+	for i, airport := range matches {
+		lines[i] = fmt.Sprintf("%s (%s): %s, %s, %s, %.4f, %.4f, %s", cmp.Or(airport.IATA, "-"), airport.ICAO,
+			airport.Name, airport.City, airport.Country, airport.Latitude, airport.Longitude, cmp.Or(airport.Timezone, "unknown"))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func (a *AirportTool) airports() (*Airports, error) {
+	if a.Airports != nil {
+		return a.Airports, nil
+	}
+	return defaultAirports()
+}
+
+// DistanceTool measures the distance between places, and estimates the flight time between them.
+type DistanceTool struct {
+	Args[distanceArgs]
+
+	// Airports defaults to the dataset at AIRPORTS_FILE, or the built-in one.
+	Airports *Airports
+}
+
+type distanceArgs struct {
+	From  string `json:"from" description:"Place to measure from: an airport code (e.g. BCN), a city with an airport (e.g. Barcelona, or London, GB) or coordinates as 'latitude,longitude'"`
+	To    string `json:"to" description:"Place to measure to, as for from"`
+	Units string `json:"units,omitempty" enum:"km,mi,nmi" description:"Optional unit of distance: km, the default, miles or nautical miles"`
+}
+
+// Name returns the tool's identifier.
+func (d *DistanceTool) Name() string {
+	return "calculate_distance"
+}
+
+// Description returns what the tool does.
+func (d *DistanceTool) Description() string {
+	return "Calculate the great-circle distance between two airports, cities or coordinates, and estimate the time " +
+		"a direct flight between them takes"
+}
+
+// CacheTTL is a day, distances do not change.
+func (d *DistanceTool) CacheTTL() time.Duration {
+	return 24 * time.Hour
+}
+
+// Execute returns the distance and flight time between the places.
+func (d *DistanceTool) Execute(ctx context.Context, args string) (string, error) {
+	payload, err := d.Decode(args)
+	if err != nil {
+		return "", err
+	}
+
+	airports := d.Airports
+	if airports == nil {
+		if airports, err = defaultAirports(); err != nil {
+			return "", err
+		}
+	}
+
+	from, err := airports.Place(payload.From)
+	if err != nil {
+		return "", err
+	}
+	to, err := airports.Place(payload.To)
+	if err != nil {
+		return "", err
+	}
+
+	km := Distance(from, to)
+	distance := fmt.Sprintf("%.0f km", km)
+	switch payload.Units {
+	case "mi":
+		distance = fmt.Sprintf("%.0f mi", km/1.609344)
+	case "nmi":
+		distance = fmt.Sprintf("%.0f nmi", km/1.852)
+	}
+
+	var result strings.Builder
+	fmt.Fprintf(&result, "From: %s\n", describePlace(from))
+	fmt.Fprintf(&result, "To: %s\n", describePlace(to))
+	fmt.Fprintf(&result, "Distance: %s (great circle)\n", distance)
+	fmt.Fprintf(&result, "Estimated flight time: %s (direct, at %d km/h plus %.0f minutes to take off and land)",
+		duration(EstimateFlightTime(km)), cruiseSpeed, flightOverhead.Minutes())
+
+	return result.String(), nil
+}
+
+func describePlace(p Place) string {
+	if p.Airport == nil {
+		return p.Name
+	}
+	return fmt.Sprintf("%s, %s, %s", p.Name, p.Airport.Name, p.Airport.Country)
+}
+
+// duration formats durations of whole minutes, e.g. "2h 05m" or "45m".
+func duration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes())
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+}
@@ -0,0 +1,186 @@
+package tools
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoadAirports(t *testing.T) {
+	// Columns of a full OurAirports export, in its order, without time zones.
+	data := `"id","ident","type","name","latitude_deg","longitude_deg","elevation_ft","continent","iso_country","iso_region","municipality","scheduled_service","icao_code","iata_code"
+3,"LEGE","medium_airport","Girona-Costa Brava Airport",41.901001,2.76055,468,"EU","ES","ES-CT","Girona","yes","LEGE","GRO"
+4,"ES-0001","closed","Old Airfield",41.5,2.5,0,"EU","ES","ES-CT","Nowhere","no","",""
+5,"ES-0002","small_airport","Sabadell Airport",41.520901,2.10505,485,"EU","ES","ES-CT","Sabadell","no","",""
+`
+
+	airports, err := LoadAirports(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadAirports() error = %v", err)
+	}
+
+	want := []Airport{
+		{IATA: "GRO", ICAO: "LEGE", Type: "medium_airport", Name: "Girona-Costa Brava Airport", City: "Girona", Country: "ES", Latitude: 41.901001, Longitude: 2.76055},
+		{ICAO: "ES-0002", Type: "small_airport", Name: "Sabadell Airport", City: "Sabadell", Country: "ES", Latitude: 41.520901, Longitude: 2.10505},
+	}
+	if diff := cmp.Diff(want, airports.list); diff != "" {
+		t.Errorf("LoadAirports() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := LoadAirports(strings.NewReader("ident,name\nLEBL,Barcelona\n")); err == nil || !strings.Contains(err.Error(), "no latitude_deg column") {
+		t.Errorf("LoadAirports() error = %v, want a missing column", err)
+	}
+}
+
+func TestAirports_Find(t *testing.T) {
+	airports, err := defaultAirports()
+	if err != nil {
+		t.Fatalf("defaultAirports() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		country string
+		want    []string
+	}{
+		{name: "IATA code", query: "bcn", want: []string{"BCN"}},
+		{name: "ICAO code", query: "LEMD", want: []string{"MAD"}},
+		{name: "city", query: "London", want: []string{"LHR", "LGW", "STN"}},
+		{name: "city without accents", query: "Malaga", want: []string{"AGP"}},
+		{name: "name", query: "heathrow", want: []string{"LHR"}},
+		{name: "cities before names", query: "Paris", want: []string{"CDG", "ORY"}},
+		{name: "country", query: "international", country: "za", want: []string{"JNB", "CPT"}},
+		{name: "unknown", query: "Atlantis", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, airport := range airports.Find(tt.query, tt.country) {
+				got = append(got, airport.Code())
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Find() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	airports, err := defaultAirports()
+	if err != nil {
+		t.Fatalf("defaultAirports() error = %v", err)
+	}
+
+	tests := []struct {
+		from, to string
+		km       float64
+		flight   time.Duration
+	}{
+		{from: "BCN", to: "MAD", km: 483, flight: time.Hour + 5*time.Minute},
+		{from: "London, GB", to: "New York", km: 5540, flight: 7*time.Hour + 25*time.Minute},
+		{from: "Sydney", to: "SYD", km: 0, flight: 30 * time.Minute},
+		{from: "0,0", to: "0,180", km: math.Pi * earthRadius, flight: 25*time.Hour + 30*time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			from, err := airports.Place(tt.from)
+			if err != nil {
+				t.Fatalf("Place(%q) error = %v", tt.from, err)
+			}
+			to, err := airports.Place(tt.to)
+			if err != nil {
+				t.Fatalf("Place(%q) error = %v", tt.to, err)
+			}
+
+			km := Distance(from, to)
+			if math.Abs(km-tt.km) > 0.5 {
+				t.Errorf("Distance() = %.1f km, want %.0f km", km, tt.km)
+			}
+			if got := EstimateFlightTime(km); got != tt.flight {
+				t.Errorf("EstimateFlightTime() = %v, want %v", got, tt.flight)
+			}
+		})
+	}
+}
+
+func TestAirportTool(t *testing.T) {
+	tool := &AirportTool{}
+
+	got, err := tool.Execute(context.Background(), `{"query": "London", "max_count": 2}`)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	want := "LHR (EGLL): London Heathrow Airport, London, GB, 51.4706, -0.4619, Europe/London\n" +
+		"LGW (EGKK): London Gatwick Airport, London, GB, 51.1481, -0.1903, Europe/London"
+	if got != want {
+		t.Errorf("Execute() mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+
+	if _, err := tool.Execute(context.Background(), `{"query": "Atlantis"}`); err == nil || err.Error() != `no airport found for "Atlantis"` {
+		t.Errorf("Execute() error = %v", err)
+	}
+}
+
+func TestDistanceTool(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "cities",
+			args: `{"from": "Barcelona", "to": "JFK"}`,
+			want: "From: Barcelona (BCN), Josep Tarradellas Barcelona-El Prat Airport, ES\n" +
+				"To: New York (JFK), John F Kennedy International Airport, US\n" +
+				"Distance: 6150 km (great circle)\n" +
+				"Estimated flight time: 8h 10m (direct, at 800 km/h plus 30 minutes to take off and land)",
+		},
+		{
+			name: "coordinates in miles",
+			args: `{"from": "BCN", "to": "41.3874, 2.1686", "units": "mi"}`,
+			want: "From: Barcelona (BCN), Josep Tarradellas Barcelona-El Prat Airport, ES\n" +
+				"To: 41.3874, 2.1686\n" +
+				"Distance: 8 mi (great circle)\n" +
+				"Estimated flight time: 30m (direct, at 800 km/h plus 30 minutes to take off and land)",
+		},
+		{
+			name:    "unknown place",
+			args:    `{"from": "Atlantis", "to": "BCN"}`,
+			wantErr: `unknown place "Atlantis": give an airport code, a city with an airport, or coordinates`,
+		},
+		{
+			name:    "coordinates out of range",
+			args:    `{"from": "91, 0", "to": "BCN"}`,
+			wantErr: `coordinates out of range: "91, 0"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&DistanceTool{}).Execute(context.Background(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Execute() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Execute() mismatch (-want +got):\n%s", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
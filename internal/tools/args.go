@@ -0,0 +1,115 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/decimal"
+)
+
+// Args gives a tool typed arguments. Embedded in a tool, it derives the tool's parameters from the fields of
+// the struct T, see SchemaOf, and decodes the arguments of calls into a T.
+type Args[T any] struct{}
+
+// Parameters returns the schema of T.
+func (Args[T]) Parameters() *Schema {
+	return SchemaOf[T]()
+}
+
+// Decode decodes the JSON arguments of a call. Fields left out keep their zero value.
+func (Args[T]) Decode(args string) (T, error) {
+	var v T
+	if strings.TrimSpace(args) == "" {
+		return v, nil
+	}
+
+	if err := json.Unmarshal([]byte(args), &v); err != nil {
+		return v, fmt.Errorf("failed to parse arguments: %w", err)
+	}
+
+	return v, nil
+}
+
+var (
+	timeType    = reflect.TypeFor[time.Time]()
+	decimalType = reflect.TypeFor[decimal.Decimal]()
+)
+
+// SchemaOf derives the JSON schema of T, a struct, from its exported fields:
+//   - properties are named after the json tags of the fields,
+//   - fields are required, unless tagged omitempty or pointers,
+//   - the description, enum (comma separated) and format tags set the matching schema keywords,
+//   - time.Time fields are strings in the date-time format,
+//   - decimal.Decimal fields are numbers, decoded exactly.
+func SchemaOf[T any]() *Schema {
+	return schemaOf(reflect.TypeFor[T]())
+}
+
+func schemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == decimalType:
+		return &Schema{Type: "number"}
+	case t.Kind() == reflect.Struct:
+		return structSchema(t)
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: schemaOf(t.Elem())}
+	default:
+		panic(fmt.Sprintf("tools: no JSON schema for %s", t))
+	}
+}
+
+func structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		prop := schemaOf(field.Type)
+		prop.Description = field.Tag.Get("description")
+		if format := field.Tag.Get("format"); format != "" {
+			prop.Format = format
+		}
+		if enum := field.Tag.Get("enum"); enum != "" {
+			prop.Enum = strings.Split(enum, ",")
+		}
+
+		s.Properties[name] = prop
+		if field.Type.Kind() != reflect.Pointer && !strings.Contains(","+opts+",", ",omitempty,") {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	return s
+}
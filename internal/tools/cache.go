@@ -0,0 +1,257 @@
+package tools
+
+import (
+	"container/list"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// DefaultCacheTTL is how long the results of tools not declaring their own TTL are cached.
+const DefaultCacheTTL = 5 * time.Minute
+
+// CacheableTool is implemented by tools declaring how long their results may be reused, instead of
+// DefaultCacheTTL.
+type CacheableTool interface {
+	// CacheTTL returns how long a result may be served again for the same arguments. Tools whose results
+	// depend on more than their arguments, e.g. the current time or the user, or that change state return 0.
+	CacheTTL() time.Duration
+}
+
+// cacheTTL returns how long the results of the tool may be cached.
+func cacheTTL(tool Tool) time.Duration {
+	if t, ok := tool.(CacheableTool); ok {
+		return t.CacheTTL()
+	}
+	return DefaultCacheTTL
+}
+
+// Cache stores tool results until they expire. Implementations are safe for concurrent use.
+type Cache interface {
+	Get(key string) (string, bool)
+	Set(key, value string, ttl time.Duration)
+}
+
+var (
+	cacheHits, _   = otel.Meter("chat-service").Int64Counter("tool_cache_hits_total")
+	cacheMisses, _ = otel.Meter("chat-service").Int64Counter("tool_cache_misses_total")
+)
+
+// Cached decorates the tool so its results are reused from cache for the tool's TTL, keyed on the tool name and
+// its normalized arguments. Failed calls are not cached. Tools with a zero TTL are returned as is.
+func Cached(tool Tool, cache Cache) Tool {
+	ttl := cacheTTL(tool)
+	if ttl <= 0 {
+		return tool
+	}
+
+	return &cachedTool{Tool: tool, cache: cache, ttl: ttl}
+}
+
+type cachedTool struct {
+	Tool
+	cache Cache
+	ttl   time.Duration
+}
+
+// Timeout returns the timeout of the decorated tool.
+func (c *cachedTool) Timeout() time.Duration {
+	return timeout(c.Tool)
+}
+
+func (c *cachedTool) Execute(ctx context.Context, args string) (string, error) {
+	key := c.Name() + " " + normalizeArgs(args)
+	attrs := metric.WithAttributes(attribute.String("tool", c.Name()))
+
+	if result, ok := c.cache.Get(key); ok {
+		cacheHits.Add(ctx, 1, attrs)
+		return result, nil
+	}
+	cacheMisses.Add(ctx, 1, attrs)
+
+	result, err := c.Tool.Execute(ctx, args)
+	if err != nil {
+		return "", err
+	}
+
+	c.cache.Set(key, result, c.ttl)
+	return result, nil
+}
+
+// normalizeArgs returns the JSON arguments with sorted keys and without insignificant whitespace, so calls with
+// the same arguments share their cache entry. Arguments that are not valid JSON are returned as is.
+func normalizeArgs(args string) string {
+	if strings.TrimSpace(args) == "" {
+		return "{}"
+	}
+
+	dec := json.NewDecoder(strings.NewReader(args))
+	dec.UseNumber()
+
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return args
+	}
+
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return args
+	}
+
+	return string(normalized)
+}
+
+// LRUCache is an in-memory Cache holding a bounded number of entries, evicting the least recently used first.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // Of *cacheEntry, most recently used first.
+	entries  map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key     string
+	value   string
+	expires time.Time
+}
+
+// NewLRUCache creates a cache holding up to capacity entries.
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{
+		capacity: max(capacity, 1),
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (c *LRUCache) Get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return "", false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+func (c *LRUCache) Set(key, value string, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cacheEntry{key: key, value: value, expires: time.Now().Add(ttl)}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+var cacheBucket = []byte("tool_results")
+
+// BoltCache is a Cache persisted in a bbolt database file, so results survive restarts. Values are stored after
+// their expiry time, as big-endian Unix nanoseconds. Expired entries are removed when the cache is opened.
+type BoltCache struct {
+	db *bbolt.DB
+}
+
+// OpenBoltCache opens (or creates) the cache file at path.
+func OpenBoltCache(path string) (*BoltCache, error) {
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tool cache %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(cacheBucket)
+		if err != nil {
+			return err
+		}
+
+		var expired [][]byte
+		err = bucket.ForEach(func(k, v []byte) error {
+			if _, ok := decodeCacheValue(v); !ok {
+				expired = append(expired, k)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, k := range expired {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to initialize tool cache %s: %w", path, err)
+	}
+
+	return &BoltCache{db: db}, nil
+}
+
+// Close releases the cache file.
+func (c *BoltCache) Close() error {
+	return c.db.Close()
+}
+
+func (c *BoltCache) Get(key string) (string, bool) {
+	var value string
+	var ok bool
+
+	_ = c.db.View(func(tx *bbolt.Tx) error {
+		value, ok = decodeCacheValue(tx.Bucket(cacheBucket).Get([]byte(key)))
+		return nil
+	})
+
+	return value, ok
+}
+
+// Set stores the value. Failures are ignored: the result is computed again next time.
+func (c *BoltCache) Set(key, value string, ttl time.Duration) {
+	v := binary.BigEndian.AppendUint64(nil, uint64(time.Now().Add(ttl).UnixNano()))
+	v = append(v, value...)
+
+	_ = c.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(cacheBucket).Put([]byte(key), v)
+	})
+}
+
+// decodeCacheValue returns the value stored in v, unless it expired.
+func decodeCacheValue(v []byte) (string, bool) {
+	if len(v) < 8 || time.Now().UnixNano() > int64(binary.BigEndian.Uint64(v)) {
+		return "", false
+	}
+
+	return string(v[8:]), true
+}
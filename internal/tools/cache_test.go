@@ -0,0 +1,142 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// countingTool counts its calls, and fails when asked to.
+type countingTool struct {
+	calls int
+	ttl   time.Duration
+}
+
+func (c *countingTool) Name() string            { return "count" }
+func (c *countingTool) Description() string     { return "Counts its calls" }
+func (c *countingTool) Parameters() *Schema     { return &Schema{Type: "object"} }
+func (c *countingTool) CacheTTL() time.Duration { return c.ttl }
+func (c *countingTool) Timeout() time.Duration  { return time.Second }
+
+func (c *countingTool) Execute(ctx context.Context, args string) (string, error) {
+	c.calls++
+	if args == `{"fail": true}` {
+		return "", errors.New("failed")
+	}
+	return args, nil
+}
+
+func TestCached(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	ctx := context.Background()
+	tool := &countingTool{ttl: time.Minute}
+	cached := Cached(tool, NewLRUCache(10))
+
+	for _, args := range []string{`{"a": 1, "b": 2}`, `{"b":2,"a":1}`, ` {"a": 1,  "b": 2} `} {
+		result, err := cached.Execute(ctx, args)
+		if err != nil || result != `{"a": 1, "b": 2}` {
+			t.Fatalf("Execute(%s) = %q, %v, want the first result", args, result, err)
+		}
+	}
+	if tool.calls != 1 {
+		t.Errorf("expected calls with the same arguments to be cached, got %d calls", tool.calls)
+	}
+
+	for range 2 {
+		if _, err := cached.Execute(ctx, `{"fail": true}`); err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+	if tool.calls != 3 {
+		t.Errorf("expected failed calls not to be cached, got %d calls", tool.calls)
+	}
+
+	if got := timeout(cached); got != time.Second {
+		t.Errorf("timeout() = %s, want the decorated tool's", got)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	counts := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+				for _, dp := range sum.DataPoints {
+					counts[m.Name] += dp.Value
+				}
+			}
+		}
+	}
+	if counts["tool_cache_hits_total"] != 2 || counts["tool_cache_misses_total"] != 3 {
+		t.Errorf("unexpected cache metrics: %v", counts)
+	}
+}
+
+func TestCached_NotCacheable(t *testing.T) {
+	tool := &DateTool{}
+	if cached := Cached(tool, NewLRUCache(10)); cached != Tool(tool) {
+		t.Errorf("expected a tool with a zero TTL not to be decorated, got %T", cached)
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	cache := NewLRUCache(2)
+
+	cache.Set("a", "1", time.Minute)
+	cache.Set("b", "2", time.Minute)
+	cache.Get("a") // a is now more recently used than b.
+	cache.Set("c", "3", time.Minute)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected the least recently used entry to be evicted")
+	}
+	if v, ok := cache.Get("a"); !ok || v != "1" {
+		t.Errorf("Get(a) = %q, %v, want 1", v, ok)
+	}
+
+	cache.Set("d", "4", -time.Second)
+	if _, ok := cache.Get("d"); ok {
+		t.Error("expected an expired entry to be missing")
+	}
+}
+
+func TestBoltCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+
+	cache, err := OpenBoltCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache.Set("kept", "value", time.Minute)
+	cache.Set("expired", "value", -time.Second)
+
+	if _, ok := cache.Get("expired"); ok {
+		t.Error("expected an expired entry to be missing")
+	}
+	if err := cache.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Entries survive reopening the cache.
+	cache, err = OpenBoltCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cache.Close()
+
+	if v, ok := cache.Get("kept"); !ok || v != "value" {
+		t.Errorf("Get(kept) = %q, %v, want value", v, ok)
+	}
+}
@@ -2,16 +2,18 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"strconv"
-	"strings"
-
-	"github.com/openai/openai-go/v2"
 )
 
-// CalculatorTool performs basic mathematical operations.
-type CalculatorTool struct{}
+// CalculatorTool evaluates arithmetic expressions with exact decimal arithmetic, so results do not suffer from
+// float rounding, e.g. on amounts of money.
+type CalculatorTool struct {
+	Args[calculatorArgs]
+}
+
+type calculatorArgs struct {
+	Expression string `json:"expression" description:"The arithmetic expression to evaluate, e.g. (1200 * 1.21 - 50) / 3"`
+}
 
 // Name returns the tool's identifier.
 func (c *CalculatorTool) Name() string {
@@ -20,71 +22,33 @@ func (c *CalculatorTool) Name() string {
 
 // Description returns what the tool does.
 func (c *CalculatorTool) Description() string {
-	return "Perform basic mathematical calculations (add, subtract, multiply, divide)"
-}
-
-// Parameters defines the tool's input schema.
-func (c *CalculatorTool) Parameters() openai.FunctionParameters {
-	return openai.FunctionParameters{
-		"type": "object",
-		"properties": map[string]any{
-			"operation": map[string]any{
-				"type":        "string",
-				"description": "The mathematical operation to perform",
-				"enum":        []string{"add", "subtract", "multiply", "divide"},
-			},
-			"a": map[string]string{
-				"type":        "number",
-				"description": "First number",
-			},
-			"b": map[string]string{
-				"type":        "number",
-				"description": "Second number",
-			},
-		},
-		"required": []string{"operation", "a", "b"},
-	}
+	return "Evaluate an arithmetic expression exactly. Supports + - * / ^ (integer powers), mod, parentheses, " +
+		"percentages (15% is 0.15) and the functions sqrt, abs, floor, ceil, round(x) or round(x, places), min and max. " +
+		"Returns the normalized expression and its result, with ≈ instead of = when the result is rounded."
 }
 
-// Execute performs the specified mathematical operation.
+// Execute evaluates the expression.
 func (c *CalculatorTool) Execute(ctx context.Context, args string) (string, error) {
-	var payload struct {
-		Operation string  `json:"operation"`
-		A         float64 `json:"a"`
-		B         float64 `json:"b"`
+	payload, err := c.Decode(args)
+	if err != nil {
+		return "", err
 	}
 
-	if err := json.Unmarshal([]byte(args), &payload); err != nil {
-		return "", fmt.Errorf("failed to parse calculator arguments: %w", err)
+	x, err := parseExpression(payload.Expression)
+	if err != nil {
+		return "", fmt.Errorf("invalid expression: %w", err)
 	}
 
-	var result float64
-	var operation string
-
-	switch strings.ToLower(payload.Operation) {
-	case "add":
-		result = payload.A + payload.B
-		operation = "+"
-	case "subtract":
-		result = payload.A - payload.B
-		operation = "-"
-	case "multiply":
-		result = payload.A * payload.B
-		operation = "*"
-	case "divide":
-		if payload.B == 0 {
-			return "Error: Division by zero is not allowed", nil
-		}
-		result = payload.A / payload.B
-		operation = "/"
-	default:
-		return "", fmt.Errorf("unsupported operation: %s", payload.Operation)
+	var inexact bool
+	result, err := x.eval(&inexact)
+	if err != nil {
+		return "", fmt.Errorf("cannot evaluate %s: %w", x.format(), err)
 	}
 
-	// Format result nicely.
-	resultStr := strconv.FormatFloat(result, 'f', -1, 64)
-	aStr := strconv.FormatFloat(payload.A, 'f', -1, 64)
-	bStr := strconv.FormatFloat(payload.B, 'f', -1, 64)
+	sign := "="
+	if inexact || !result.Exact() {
+		sign = "≈"
+	}
 
-	return fmt.Sprintf("%s %s %s = %s", aStr, operation, bStr, resultStr), nil
-}
\ No newline at end of file
+	return fmt.Sprintf("%s %s %s", x.format(), sign, result), nil
+}
@@ -0,0 +1,253 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+)
+
+// DefaultCalendarMaxAge is how long calendars are used from disk before they are fetched again.
+const DefaultCalendarMaxAge = 24 * time.Hour
+
+// calendarRefreshInterval is how often Run looks for calendars older than their maximum age.
+const calendarRefreshInterval = time.Hour
+
+// CalendarStore keeps ICS calendars on disk, so they are fetched once and then refreshed on a schedule by Run
+// instead of on every call. Calendars failing to refresh keep being served from disk.
+type CalendarStore struct {
+	dir    string
+	maxAge time.Duration
+	client *http.Client
+
+	mu     sync.Mutex
+	parsed map[string]parsedCalendar // By file.
+}
+
+type parsedCalendar struct {
+	modTime  time.Time
+	holidays []Holiday
+}
+
+// NewCalendarStore returns a store keeping calendars in dir, created when needed, refreshing the ones older than
+// maxAge.
+func NewCalendarStore(dir string, maxAge time.Duration) *CalendarStore {
+	return &CalendarStore{
+		dir:    dir,
+		maxAge: maxAge,
+		client: &http.Client{Timeout: 15 * time.Second},
+		parsed: make(map[string]parsedCalendar),
+	}
+}
+
+// DefaultCalendars is the calendar store of HolidaysTools without one, in HOLIDAY_CACHE_DIR or else a directory
+// of the user's cache.
+var DefaultCalendars = sync.OnceValue(func() *CalendarStore {
+	dir := os.Getenv("HOLIDAY_CACHE_DIR")
+	if dir == "" {
+		cache, err := os.UserCacheDir()
+		if err != nil {
+			cache = os.TempDir()
+		}
+		dir = filepath.Join(cache, "acai-travel", "holidays")
+	}
+
+	return NewCalendarStore(dir, DefaultCalendarMaxAge)
+})
+
+// Load returns the holidays of the calendar at link, sorted by date, from disk once fetched. Links to local files,
+// as paths or file URLs, are read as they are.
+func (s *CalendarStore) Load(ctx context.Context, link string) ([]Holiday, error) {
+	path, remote := s.path(link)
+
+	info, err := os.Stat(path)
+	if remote && os.IsNotExist(err) {
+		if err := s.fetch(ctx, link); err != nil {
+			return nil, err
+		}
+		info, err = os.Stat(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	cal, ok := s.parsed[path]
+	s.mu.Unlock()
+
+	if ok && cal.modTime.Equal(info.ModTime()) {
+		return cal.holidays, nil
+	}
+
+	holidays, err := parseCalendarFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.parsed[path] = parsedCalendar{modTime: info.ModTime(), holidays: holidays}
+	s.mu.Unlock()
+
+	return holidays, nil
+}
+
+// path returns the file of the calendar at link, and whether it is fetched from a remote link.
+func (s *CalendarStore) path(link string) (string, bool) {
+	u, err := url.Parse(link)
+	switch {
+	case err != nil || u.Scheme == "":
+		return link, false
+	case u.Scheme == "file":
+		return u.Path, false
+	}
+
+	// Files are named after their link, so Refresh knows where to fetch them from.
+	return filepath.Join(s.dir, url.QueryEscape(link)+".ics"), true
+}
+
+// fetch downloads the calendar at link to disk, replacing the one there atomically.
+func (s *CalendarStore) fetch(ctx context.Context, link string) error {
+	slog.InfoContext(ctx, "Loading calendar", "link", link)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch calendar: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch calendar: %s", resp.Status)
+	}
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, "calendar-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to fetch calendar: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	// Calendars that do not parse are not stored, keeping the previous one.
+	if _, err := parseCalendarFile(tmp.Name()); err != nil {
+		return err
+	}
+
+	path, _ := s.path(link)
+	return os.Rename(tmp.Name(), path)
+}
+
+// Refresh fetches the calendars on disk older than the maximum age again.
+func (s *CalendarStore) Refresh(ctx context.Context) error {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".ics")
+		if !ok {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < s.maxAge {
+			continue
+		}
+
+		link, err := url.QueryUnescape(name)
+		if err != nil {
+			continue
+		}
+
+		if err := s.fetch(ctx, link); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", link, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to refresh calendars: %v", errs)
+	}
+	return nil
+}
+
+// Run refreshes the calendars older than the maximum age every hour, until ctx is done.
+func (s *CalendarStore) Run(ctx context.Context) {
+	ticker := time.NewTicker(calendarRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Refresh(ctx); err != nil {
+			slog.WarnContext(ctx, "Calendars are stale", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// parseCalendarFile parses the all-day events of an ICS file into holidays, sorted by date.
+func parseCalendarFile(path string) ([]Holiday, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	cal, err := ics.ParseCalendar(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse calendar: %w", err)
+	}
+
+	var holidays []Holiday
+	for _, event := range cal.Events() {
+		date, err := event.GetAllDayStartAt()
+		if err != nil {
+			continue
+		}
+
+		var name string
+		if summary := event.GetProperty(ics.ComponentPropertySummary); summary != nil {
+			name = summary.Value
+		}
+
+		holidays = append(holidays, Holiday{Date: date, Name: name})
+	}
+
+	slices.SortStableFunc(holidays, func(a, b Holiday) int {
+		return a.Date.Compare(b.Date)
+	})
+
+	return holidays, nil
+}
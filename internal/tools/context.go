@@ -0,0 +1,73 @@
+package tools
+
+import (
+	"context"
+	"sync"
+)
+
+type conversationKey struct{}
+
+// WithConversation returns a context carrying the ID of the conversation tools are called for.
+func WithConversation(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, conversationKey{}, id)
+}
+
+// ConversationID returns the ID of the conversation tools are called for, or an empty string when unknown.
+func ConversationID(ctx context.Context) string {
+	id, _ := ctx.Value(conversationKey{}).(string)
+	return id
+}
+
+// Citation is a source a tool handed to the model, numbered so the reply can refer to it as [n].
+type Citation struct {
+	Number       int
+	DocumentID   string
+	DocumentName string
+	Chunk        int
+	Excerpt      string
+}
+
+// Citations records the sources cited by the tools called for a reply. It is safe for concurrent use, as tools
+// of a turn run at once.
+type Citations struct {
+	mu   sync.Mutex
+	list []Citation
+}
+
+type citationsKey struct{}
+
+// WithCitations returns a context recording the citations of the tools called with it.
+func WithCitations(ctx context.Context) (context.Context, *Citations) {
+	c := &Citations{}
+	return context.WithValue(ctx, citationsKey{}, c), c
+}
+
+// List returns the citations recorded, in number order.
+func (c *Citations) List() []Citation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Citation(nil), c.list...)
+}
+
+// Cite records the source and returns its number, the same one for every call citing the same chunk of a
+// document. It returns 0 when the context does not record citations.
+func Cite(ctx context.Context, citation Citation) int {
+	c, ok := ctx.Value(citationsKey{}).(*Citations)
+	if !ok {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, existing := range c.list {
+		if existing.DocumentID == citation.DocumentID && existing.Chunk == citation.Chunk {
+			return existing.Number
+		}
+	}
+
+	citation.Number = len(c.list) + 1
+	c.list = append(c.list, citation)
+	return citation.Number
+}
@@ -0,0 +1,408 @@
+package tools
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/decimal"
+)
+
+// Rates are the exchange rates of a day, in units of each currency per unit of Base.
+type Rates struct {
+	Date  time.Time
+	Base  string
+	Rates map[string]decimal.Decimal
+}
+
+// Rate returns the rate of currency, in units per unit of the base currency.
+func (r *Rates) Rate(currency string) (decimal.Decimal, bool) {
+	if currency == r.Base {
+		return decimal.New(1), true
+	}
+
+	rate, ok := r.Rates[currency]
+	return rate, ok
+}
+
+// Currencies returns the codes of the currencies with a rate, including the base currency, sorted.
+func (r *Rates) Currencies() []string {
+	codes := []string{r.Base}
+	for code := range r.Rates {
+		codes = append(codes, code)
+	}
+
+	slices.Sort(codes)
+	return slices.Compact(codes)
+}
+
+// RateProvider provides exchange rates.
+type RateProvider interface {
+	// Rates returns the rates of date, or of the closest earlier day rates were published for, as rates are not
+	// published on weekends and holidays. A zero date asks for the latest rates.
+	Rates(ctx context.Context, date time.Time) (*Rates, error)
+}
+
+// ErrNoRates is returned by rate providers without rates for the date asked for.
+var ErrNoRates = errors.New("no exchange rates published")
+
+// ECBRates provides the euro foreign exchange reference rates the European Central Bank publishes every working
+// day, for around 30 currencies since 1999.
+type ECBRates struct {
+	// Client defaults to an http.Client with a 10 second timeout.
+	Client *http.Client
+	// URL is where the rate files are published, https://www.ecb.europa.eu/stats/eurofxref by default.
+	URL string
+	// TTL is how long a downloaded file answers later calls, for any of its dates, so the history of rates, a few
+	// megabytes, is not downloaded again for every date asked for. Files are downloaded on every call when zero.
+	TTL time.Duration
+
+	mu    sync.Mutex
+	files map[string]ecbFile // By file name.
+}
+
+type ecbFile struct {
+	days    []*Rates
+	expires time.Time
+}
+
+// Rates fetches the smallest of the ECB's files holding date: the latest rates, the last 90 days or all of them.
+func (p *ECBRates) Rates(ctx context.Context, date time.Time) (*Rates, error) {
+	file := "eurofxref-daily.xml"
+	switch age := time.Since(date); {
+	case date.IsZero():
+	case age > 85*24*time.Hour:
+		file = "eurofxref-hist.xml"
+	case age > 2*24*time.Hour:
+		file = "eurofxref-hist-90d.xml"
+	}
+
+	days, err := p.fetch(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+
+	if file == "eurofxref-daily.xml" && !date.IsZero() && days[0].Date.After(date) {
+		// The latest rates may already be past the date asked for, e.g. on Monday evening when asked for Sunday.
+		if days, err = p.fetch(ctx, "eurofxref-hist-90d.xml"); err != nil {
+			return nil, err
+		}
+	}
+
+	return ratesOn(days, date)
+}
+
+// fetch returns the days of the file, downloaded or kept from an earlier call for TTL.
+func (p *ECBRates) fetch(ctx context.Context, file string) ([]*Rates, error) {
+	p.mu.Lock()
+	cached, ok := p.files[file]
+	p.mu.Unlock()
+
+	if ok && time.Now().Before(cached.expires) {
+		return cached.days, nil
+	}
+
+	days, err := p.download(ctx, file)
+	if err != nil || p.TTL <= 0 {
+		return days, err
+	}
+
+	p.mu.Lock()
+	if p.files == nil {
+		p.files = make(map[string]ecbFile)
+	}
+	p.files[file] = ecbFile{days: days, expires: time.Now().Add(p.TTL)}
+	p.mu.Unlock()
+
+	return days, nil
+}
+
+func (p *ECBRates) download(ctx context.Context, file string) ([]*Rates, error) {
+	base := "https://www.ecb.europa.eu/stats/eurofxref"
+	if p.URL != "" {
+		base = strings.TrimSuffix(p.URL, "/")
+	}
+
+	client := p.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/"+file, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("exchange rates request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("exchange rates request failed: %s", resp.Status)
+	}
+
+	return parseECBRates(resp.Body)
+}
+
+// FileRates provides rates from a file in the ECB's format, e.g. a copy of
+// https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist.xml, to work offline.
+type FileRates struct {
+	Path string
+}
+
+// Rates reads the file, on every call.
+func (p *FileRates) Rates(ctx context.Context, date time.Time) (*Rates, error) {
+	f, err := os.Open(p.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	days, err := parseECBRates(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return ratesOn(days, date)
+}
+
+// parseECBRates parses rates in the ECB's XML format, returning the days from the latest.
+func parseECBRates(r io.Reader) ([]*Rates, error) {
+	var doc struct {
+		Cube struct {
+			Days []struct {
+				Time  string `xml:"time,attr"`
+				Rates []struct {
+					Currency string `xml:"currency,attr"`
+					Rate     string `xml:"rate,attr"`
+				} `xml:"Cube"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	}
+
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse exchange rates: %w", err)
+	}
+
+	days := make([]*Rates, 0, len(doc.Cube.Days))
+	for _, day := range doc.Cube.Days {
+		date, err := time.Parse(time.DateOnly, day.Time)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse exchange rates: %w", err)
+		}
+
+		rates := &Rates{Date: date, Base: "EUR", Rates: make(map[string]decimal.Decimal, len(day.Rates))}
+		for _, rate := range day.Rates {
+			if rates.Rates[rate.Currency], err = decimal.Parse(rate.Rate); err != nil {
+				return nil, fmt.Errorf("failed to parse exchange rates: %w", err)
+			}
+		}
+
+		days = append(days, rates)
+	}
+
+	if len(days) == 0 {
+		return nil, ErrNoRates
+	}
+
+	slices.SortFunc(days, func(a, b *Rates) int {
+		return b.Date.Compare(a.Date)
+	})
+
+	return days, nil
+}
+
+// ratesOn returns the rates of the latest of days on or before date, or the latest of all for a zero date.
+func ratesOn(days []*Rates, date time.Time) (*Rates, error) {
+	for _, rates := range days {
+		if date.IsZero() || !rates.Date.After(date) {
+			return rates, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w on or before %s", ErrNoRates, date.Format(time.DateOnly))
+}
+
+// maxCachedDays bounds the number of dates CachedRates keeps the rates of.
+const maxCachedDays = 1000
+
+// CachedRates caches the rates of provider: the rates of past days for good, as they never change, and the
+// latest rates, or the ones of recent days that may yet be published, for ttl. The rates of at most
+// maxCachedDays dates are kept, others are dropped to make room, expired ones first.
+func CachedRates(provider RateProvider, ttl time.Duration) RateProvider {
+	return &cachedRates{provider: provider, ttl: ttl, days: make(map[time.Time]cachedDay)}
+}
+
+type cachedRates struct {
+	provider RateProvider
+	ttl      time.Duration
+
+	mu   sync.Mutex
+	days map[time.Time]cachedDay // By date asked for, zero for the latest.
+}
+
+type cachedDay struct {
+	rates   *Rates
+	expires time.Time // Zero for rates that never expire.
+}
+
+func (c *cachedRates) Rates(ctx context.Context, date time.Time) (*Rates, error) {
+	c.mu.Lock()
+	day, ok := c.days[date]
+	c.mu.Unlock()
+
+	if ok && (day.expires.IsZero() || time.Now().Before(day.expires)) {
+		return day.rates, nil
+	}
+
+	rates, err := c.provider.Rates(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	// The rates of a day are final once published, and a week later for days rates were not published on.
+	day = cachedDay{rates: rates}
+	if date.IsZero() || !rates.Date.Equal(date) && time.Since(date) < 7*24*time.Hour {
+		day.expires = time.Now().Add(c.ttl)
+	}
+
+	c.mu.Lock()
+	c.makeRoom(time.Now())
+	c.days[date] = day
+	c.mu.Unlock()
+
+	return rates, nil
+}
+
+// makeRoom drops the expired rates when the cache is full, and then arbitrary ones if it still is. c.mu is held.
+func (c *cachedRates) makeRoom(now time.Time) {
+	if len(c.days) < maxCachedDays {
+		return
+	}
+
+	for date, day := range c.days {
+		if !day.expires.IsZero() && !now.Before(day.expires) {
+			delete(c.days, date)
+		}
+	}
+
+	for date := range c.days {
+		if len(c.days) < maxCachedDays {
+			break
+		}
+		delete(c.days, date)
+	}
+}
+
+// defaultRates provides the rates of CurrencyTools without a provider: the ECB's, or the file at
+// CURRENCY_RATES_FILE when set, cached for an hour.
+var defaultRates = sync.OnceValue(func() RateProvider {
+	if path := os.Getenv("CURRENCY_RATES_FILE"); path != "" {
+		return CachedRates(&FileRates{Path: path}, time.Hour)
+	}
+	return CachedRates(&ECBRates{TTL: time.Hour}, time.Hour)
+})
+
+// zeroDecimalCurrencies are the currencies without minor units, which amounts are rounded to.
+var zeroDecimalCurrencies = []string{"CLP", "ISK", "JPY", "KRW", "VND"}
+
+// CurrencyTool converts amounts of money between currencies.
+type CurrencyTool struct {
+	Args[currencyArgs]
+
+	// Rates defaults to the ECB's reference rates, see defaultRates.
+	Rates RateProvider
+}
+
+type currencyArgs struct {
+	Amount decimal.Decimal `json:"amount" description:"The amount of money to convert, e.g. 200"`
+	From   string          `json:"from" description:"The ISO 4217 code of the currency to convert from, e.g. EUR"`
+	To     string          `json:"to" description:"The ISO 4217 code of the currency to convert to, e.g. USD"`
+	Date   string          `json:"date,omitempty" format:"date" description:"Optional date in YYYY-MM-DD format to convert at the rates of. The latest rates are used if not provided."`
+}
+
+// Name returns the tool's identifier.
+func (c *CurrencyTool) Name() string {
+	return "convert_currency"
+}
+
+// Description returns what the tool does.
+func (c *CurrencyTool) Description() string {
+	return "Convert an amount of money between currencies at the European Central Bank's daily reference rates, " +
+		"the latest or the ones of a past date. Rates are published on working days only, so the closest earlier " +
+		"day's rates are used otherwise."
+}
+
+// CacheTTL is an hour, the ECB publishes reference rates once a working day.
+func (c *CurrencyTool) CacheTTL() time.Duration {
+	return time.Hour
+}
+
+// Execute converts the amount.
+func (c *CurrencyTool) Execute(ctx context.Context, args string) (string, error) {
+	payload, err := c.Decode(args)
+	if err != nil {
+		return "", err
+	}
+
+	var date time.Time
+	if payload.Date != "" {
+		if date, err = time.Parse(time.DateOnly, payload.Date); err != nil {
+			return "", fmt.Errorf("invalid date %q, expected YYYY-MM-DD", payload.Date)
+		}
+		if date.After(time.Now()) {
+			return "", fmt.Errorf("no exchange rates for %s, which is in the future", payload.Date)
+		}
+	}
+
+	provider := c.Rates
+	if provider == nil {
+		provider = defaultRates()
+	}
+
+	rates, err := provider.Rates(ctx, date)
+	if err != nil {
+		return "", fmt.Errorf("failed to get exchange rates: %w", err)
+	}
+
+	from, to := strings.ToUpper(payload.From), strings.ToUpper(payload.To)
+	fromRate, ok := rates.Rate(from)
+	if !ok {
+		return "", fmt.Errorf("unsupported currency %q, supported are %s", payload.From, strings.Join(rates.Currencies(), ", "))
+	}
+	toRate, ok := rates.Rate(to)
+	if !ok {
+		return "", fmt.Errorf("unsupported currency %q, supported are %s", payload.To, strings.Join(rates.Currencies(), ", "))
+	}
+
+	// Rates are per unit of the base currency, so the cross rate is exact up to the division.
+	rate, err := toRate.Quo(fromRate)
+	if err != nil {
+		return "", fmt.Errorf("invalid exchange rate for %s: %w", from, err)
+	}
+
+	places := 2
+	if slices.Contains(zeroDecimalCurrencies, to) {
+		places = 0
+	}
+
+	result := fmt.Sprintf("%s %s = %s %s (rate of %s: 1 %s = %s %s)",
+		payload.Amount, from, payload.Amount.Mul(rate).StringFixed(places), to,
+		rates.Date.Format(time.DateOnly), from, rate.Round(6), to)
+
+	if !date.IsZero() && !rates.Date.Equal(date) {
+		result += fmt.Sprintf(", no rates were published on %s", date.Format(time.DateOnly))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,185 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCurrencyTool(t *testing.T) {
+	tool := &CurrencyTool{Rates: &FileRates{Path: "testdata/eurofxref-hist.xml"}}
+
+	tests := []struct {
+		name    string
+		args    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "from the base currency",
+			args: `{"amount": 200, "from": "EUR", "to": "USD"}`,
+			want: "200 EUR = 213.66 USD (rate of 2024-06-14: 1 EUR = 1.0683 USD)",
+		},
+		{
+			name: "cross rate",
+			args: `{"amount": 99.99, "from": "usd", "to": "gbp"}`,
+			want: "99.99 USD = 78.96 GBP (rate of 2024-06-14: 1 USD = 0.789713 GBP)",
+		},
+		{
+			name: "without minor units",
+			args: `{"amount": 1000, "from": "CHF", "to": "JPY"}`,
+			want: "1000 CHF = 176210 JPY (rate of 2024-06-14: 1 CHF = 176.209931 JPY)",
+		},
+		{
+			name: "historical",
+			args: `{"amount": 0.1, "from": "EUR", "to": "USD", "date": "2024-06-12"}`,
+			want: "0.1 EUR = 0.11 USD (rate of 2024-06-12: 1 EUR = 1.0765 USD)",
+		},
+		{
+			name: "not published on the date",
+			args: `{"amount": 50, "from": "GBP", "to": "EUR", "date": "2024-06-16"}`,
+			want: "50 GBP = 59.27 EUR (rate of 2024-06-14: 1 GBP = 1.185326 EUR), no rates were published on 2024-06-16",
+		},
+		{
+			name:    "before the first rates",
+			args:    `{"amount": 1, "from": "EUR", "to": "USD", "date": "1998-12-31"}`,
+			wantErr: "failed to get exchange rates: no exchange rates published on or before 1998-12-31",
+		},
+		{
+			name:    "in the future",
+			args:    `{"amount": 1, "from": "EUR", "to": "USD", "date": "2999-01-01"}`,
+			wantErr: "no exchange rates for 2999-01-01, which is in the future",
+		},
+		{
+			name:    "unsupported currency",
+			args:    `{"amount": 1, "from": "EUR", "to": "XYZ"}`,
+			wantErr: `unsupported currency "XYZ", supported are CHF, EUR, GBP, JPY, USD`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tool.Execute(context.Background(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Execute() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Execute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestECBRates(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/eurofxref-hist.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var requested []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		if !strings.HasPrefix(r.URL.Path, "/eurofxref/eurofxref-") {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(fixture)
+	}))
+	defer srv.Close()
+
+	provider := &ECBRates{URL: srv.URL + "/eurofxref/"}
+
+	rates, err := provider.Rates(context.Background(), time.Time{})
+	if err != nil || rates.Date.Format(time.DateOnly) != "2024-06-14" {
+		t.Fatalf("Rates() = %+v, %v, want the latest rates", rates, err)
+	}
+
+	rates, err = provider.Rates(context.Background(), time.Date(2024, 6, 13, 0, 0, 0, 0, time.UTC))
+	if err != nil || rates.Rates["USD"].String() != "1.0784" {
+		t.Fatalf("Rates() = %+v, %v, want the rates of 2024-06-13", rates, err)
+	}
+
+	want := []string{"/eurofxref/eurofxref-daily.xml", "/eurofxref/eurofxref-hist.xml"}
+	if strings.Join(requested, " ") != strings.Join(want, " ") {
+		t.Errorf("requested %v, want %v", requested, want)
+	}
+
+	provider.URL = srv.URL
+	if _, err := provider.Rates(context.Background(), time.Time{}); err == nil || !strings.Contains(err.Error(), "404 Not Found") {
+		t.Errorf("Rates() error = %v, want a request failure", err)
+	}
+
+	// With a TTL, the history answers every past date from one download.
+	requested = nil
+	provider = &ECBRates{URL: srv.URL + "/eurofxref/", TTL: time.Hour}
+	for _, date := range []time.Time{time.Date(2024, 6, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC)} {
+		if _, err := provider.Rates(context.Background(), date); err != nil {
+			t.Fatalf("Rates(%s) error = %v", date, err)
+		}
+	}
+	if want := []string{"/eurofxref/eurofxref-hist.xml"}; strings.Join(requested, " ") != strings.Join(want, " ") {
+		t.Errorf("requested %v, want %v", requested, want)
+	}
+}
+
+// countingRates counts the calls to a provider.
+type countingRates struct {
+	RateProvider
+	calls int
+}
+
+func (c *countingRates) Rates(ctx context.Context, date time.Time) (*Rates, error) {
+	c.calls++
+	return c.RateProvider.Rates(ctx, date)
+}
+
+func TestCachedRates(t *testing.T) {
+	provider := &countingRates{RateProvider: &FileRates{Path: "testdata/eurofxref-hist.xml"}}
+	cached := CachedRates(provider, time.Hour)
+	ctx := context.Background()
+
+	for _, date := range []time.Time{{}, time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)} {
+		for range 3 {
+			if _, err := cached.Rates(ctx, date); err != nil {
+				t.Fatalf("Rates(%s) error = %v", date, err)
+			}
+		}
+	}
+
+	if provider.calls != 3 {
+		t.Errorf("provider called %d times, want once per date", provider.calls)
+	}
+
+	// Failures are not cached.
+	for range 2 {
+		if _, err := cached.Rates(ctx, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrNoRates) {
+			t.Fatalf("Rates() error = %v, want ErrNoRates", err)
+		}
+	}
+	if provider.calls != 5 {
+		t.Errorf("provider called %d times, want failures retried", provider.calls)
+	}
+
+	// The cache is bounded.
+	start := time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC)
+	for i := range maxCachedDays + 10 {
+		if _, err := cached.Rates(ctx, start.AddDate(0, 0, i)); err != nil {
+			t.Fatalf("Rates() error = %v", err)
+		}
+	}
+	if n := len(cached.(*cachedRates).days); n > maxCachedDays {
+		t.Errorf("%d dates cached, want at most %d", n, maxCachedDays)
+	}
+}
@@ -2,13 +2,22 @@ package tools
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
-
-	"github.com/openai/openai-go/v2"
 )
 
 // DateTool provides current date and time information.
-type DateTool struct{}
+type DateTool struct {
+	Args[dateArgs]
+
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+type dateArgs struct {
+	Timezone string `json:"timezone,omitempty" description:"Optional IANA time zone (e.g. Asia/Tokyo) or city (e.g. Tokyo) to get the date and time in. The server's time zone is used if not provided."`
+}
 
 // Name returns the tool's identifier.
 func (d *DateTool) Name() string {
@@ -17,18 +26,130 @@ func (d *DateTool) Name() string {
 
 // Description returns what the tool does.
 func (d *DateTool) Description() string {
-	return "Get today's date and time in RFC3339 format"
+	return "Get today's date and time in RFC3339 format, with the weekday and ISO week, in a time zone or city"
 }
 
-// Parameters defines the tool's input schema.
-func (d *DateTool) Parameters() openai.FunctionParameters {
-	return openai.FunctionParameters{
-		"type":       "object",
-		"properties": map[string]any{},
-	}
+// CacheTTL is 0, the current time is never reused.
+func (d *DateTool) CacheTTL() time.Duration {
+	return 0
 }
 
 // Execute returns the current date and time.
 func (d *DateTool) Execute(ctx context.Context, args string) (string, error) {
-	return time.Now().Format(time.RFC3339), nil
-}
\ No newline at end of file
+	payload, err := d.Decode(args)
+	if err != nil {
+		return "", err
+	}
+
+	loc, err := LoadZone(payload.Timezone)
+	if err != nil {
+		return "", err
+	}
+
+	now := d.now().In(loc)
+
+	var result strings.Builder
+	fmt.Fprintf(&result, "%s\n", now.Format(time.RFC3339))
+	fmt.Fprintf(&result, "Weekday: %s\n", now.Weekday())
+	fmt.Fprintf(&result, "ISO week: %s\n", isoWeek(now))
+	fmt.Fprintf(&result, "Time zone: %s", zoneName(loc, now))
+
+	return result.String(), nil
+}
+
+func (d *DateTool) now() time.Time {
+	if d.Now != nil {
+		return d.Now()
+	}
+	return time.Now()
+}
+
+// TimeConversionTool converts times between time zones.
+type TimeConversionTool struct {
+	Args[timeConversionArgs]
+
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+type timeConversionArgs struct {
+	Time string `json:"time" description:"The time to convert: RFC3339 (e.g. 2026-10-16T09:00:00+02:00), YYYY-MM-DD HH:MM, or HH:MM for today"`
+	From string `json:"from,omitempty" description:"Optional IANA time zone or city the time is in, unless it has a UTC offset. The server's time zone is used if not provided."`
+	To   string `json:"to" description:"The IANA time zone or city to convert the time to"`
+}
+
+// timeLayouts are the layouts of times without an offset convert_time accepts, in the time zone they are from.
+var timeLayouts = []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02T15:04:05", time.DateOnly}
+
+// Name returns the tool's identifier.
+func (c *TimeConversionTool) Name() string {
+	return "convert_time"
+}
+
+// Description returns what the tool does.
+func (c *TimeConversionTool) Description() string {
+	return "Convert a date and time from one time zone or city to another, accounting for daylight saving time"
+}
+
+// CacheTTL is 0, times of day are relative to today.
+func (c *TimeConversionTool) CacheTTL() time.Duration {
+	return 0
+}
+
+// Execute converts the time.
+func (c *TimeConversionTool) Execute(ctx context.Context, args string) (string, error) {
+	payload, err := c.Decode(args)
+	if err != nil {
+		return "", err
+	}
+
+	from, err := LoadZone(payload.From)
+	if err != nil {
+		return "", err
+	}
+	to, err := LoadZone(payload.To)
+	if err != nil {
+		return "", err
+	}
+
+	t, hasOffset, err := c.parse(strings.TrimSpace(payload.Time), from)
+	if err != nil {
+		return "", err
+	}
+
+	// Times with an offset are only in the from zone if it is given, e.g. for its name.
+	fromName := utcOffset(t)
+	if !hasOffset || payload.From != "" {
+		t = t.In(from)
+		fromName = zoneName(from, t)
+	}
+
+	converted := t.In(to)
+	return fmt.Sprintf("%s in %s is %s in %s",
+		t.Format("Monday 2006-01-02 15:04"), fromName, converted.Format("Monday 2006-01-02 15:04"), zoneName(to, converted)), nil
+}
+
+// parse parses a time, in loc unless it has an offset.
+func (c *TimeConversionTool) parse(s string, loc *time.Location) (t time.Time, hasOffset bool, err error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, true, nil
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, false, nil
+		}
+	}
+
+	if clock, err := time.Parse("15:04", s); err == nil {
+		now := time.Now()
+		if c.Now != nil {
+			now = c.Now()
+		}
+
+		y, m, d := now.In(loc).Date()
+		return time.Date(y, m, d, clock.Hour(), clock.Minute(), 0, 0, loc), false, nil
+	}
+
+	return time.Time{}, false, fmt.Errorf("invalid time %q, expected RFC3339, YYYY-MM-DD HH:MM or HH:MM", s)
+}
@@ -0,0 +1,250 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxDateSpan bounds the days calculate_date counts business days over.
+const maxDateSpan = 100 * 366
+
+// DateCalculatorTool computes date offsets and differences, in calendar days or business days.
+type DateCalculatorTool struct {
+	Args[dateCalculationArgs]
+
+	// Holidays are the holidays business days skip, besides weekends. Defaults to the ones of HolidaysTool.
+	Holidays HolidayCalendar
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+type dateCalculationArgs struct {
+	Operation    string `json:"operation" enum:"add,difference" description:"add: the date at an offset from start. difference: the days and business days from start to end."`
+	Start        string `json:"start,omitempty" format:"date" description:"Optional date in YYYY-MM-DD format to start from. Today is used if not provided."`
+	End          string `json:"end,omitempty" format:"date" description:"The date in YYYY-MM-DD format to count to, for difference"`
+	Years        int    `json:"years,omitempty" description:"Years to add, negative to go back"`
+	Months       int    `json:"months,omitempty" description:"Months to add, negative to go back"`
+	Weeks        int    `json:"weeks,omitempty" description:"Weeks to add, negative to go back"`
+	Days         int    `json:"days,omitempty" description:"Days to add, negative to go back"`
+	BusinessDays int    `json:"business_days,omitempty" description:"Business days to add after the other offsets, skipping weekends and public holidays, negative to go back"`
+	Timezone     string `json:"timezone,omitempty" description:"Optional IANA time zone or city to take today in. The server's time zone is used if not provided."`
+	Country      string `json:"country,omitempty" description:"Optional country whose public holidays business days skip, as for get_holidays. The ones of Spain are used if not provided, with the ones of Catalonia unless a region is given."`
+	Region       string `json:"region,omitempty" description:"Optional region of the country whose public holidays business days skip, as for get_holidays"`
+}
+
+// Name returns the tool's identifier.
+func (c *DateCalculatorTool) Name() string {
+	return "calculate_date"
+}
+
+// Description returns what the tool does.
+func (c *DateCalculatorTool) Description() string {
+	return "Calculate the date at an offset from a date or today, e.g. 45 days or 10 business days from now, or the " +
+		"number of days and business days between two dates. Business days skip weekends and the public holidays of " +
+		"a country and region, by default the ones of Barcelona."
+}
+
+// Timeout returns how long a call may take. Counting business days loads the holidays, like HolidaysTool.
+func (c *DateCalculatorTool) Timeout() time.Duration {
+	return 20 * time.Second
+}
+
+// CacheTTL is 0, dates are relative to today by default.
+func (c *DateCalculatorTool) CacheTTL() time.Duration {
+	return 0
+}
+
+// Execute computes the offset or difference.
+func (c *DateCalculatorTool) Execute(ctx context.Context, args string) (string, error) {
+	payload, err := c.Decode(args)
+	if err != nil {
+		return "", err
+	}
+
+	loc, err := LoadZone(payload.Timezone)
+	if err != nil {
+		return "", err
+	}
+
+	start, err := c.date(payload.Start, loc)
+	if err != nil {
+		return "", err
+	}
+
+	switch payload.Operation {
+	case "add":
+		return c.add(ctx, start, payload)
+	case "difference":
+		if payload.End == "" {
+			return "", fmt.Errorf("end is required for difference")
+		}
+
+		end, err := c.date(payload.End, loc)
+		if err != nil {
+			return "", err
+		}
+		return c.difference(ctx, start, end, payload)
+	default:
+		return "", fmt.Errorf("unknown operation %q", payload.Operation)
+	}
+}
+
+// date parses a date, or returns today's in loc for an empty one. Dates are days, at midnight UTC.
+func (c *DateCalculatorTool) date(s string, loc *time.Location) (time.Time, error) {
+	if s == "" {
+		now := time.Now()
+		if c.Now != nil {
+			now = c.Now()
+		}
+
+		y, m, d := now.In(loc).Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), nil
+	}
+
+	date, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", s)
+	}
+	return date, nil
+}
+
+func (c *DateCalculatorTool) add(ctx context.Context, start time.Time, payload dateCalculationArgs) (string, error) {
+	if abs(payload.BusinessDays) > maxDateSpan/2 {
+		return "", fmt.Errorf("cannot add more than %d business days", maxDateSpan/2)
+	}
+
+	var offsets []string
+	for _, offset := range []struct {
+		n    int
+		unit string
+	}{{payload.Years, "year"}, {payload.Months, "month"}, {payload.Weeks, "week"}, {payload.Days, "day"}, {payload.BusinessDays, "business day"}} {
+		if offset.n != 0 {
+			offsets = append(offsets, count(offset.n, offset.unit))
+		}
+	}
+	if len(offsets) == 0 {
+		return "", fmt.Errorf("add needs a number of years, months, weeks, days or business days")
+	}
+
+	date := start.AddDate(payload.Years, payload.Months, payload.Weeks*7+payload.Days)
+
+	var note string
+	if payload.BusinessDays != 0 {
+		holidays, skipped, n := c.holidays(ctx, payload), []string(nil), payload.BusinessDays
+
+		step := 1
+		if n < 0 {
+			step, n = -1, -n
+		}
+		for n > 0 {
+			date = date.AddDate(0, 0, step)
+			if name, ok := holidays.days[date.Format(time.DateOnly)]; ok && !isWeekend(date) {
+				skipped = append(skipped, date.Format(time.DateOnly)+": "+name)
+			} else if !isWeekend(date) {
+				n--
+			}
+		}
+
+		note = holidays.note(skipped)
+	}
+
+	return fmt.Sprintf("%s + %s = %s%s", day(start), strings.Join(offsets, ", "), day(date), note), nil
+}
+
+func (c *DateCalculatorTool) difference(ctx context.Context, start, end time.Time, payload dateCalculationArgs) (string, error) {
+	days := int(end.Sub(start).Hours() / 24)
+	if abs(days) > maxDateSpan {
+		return "", fmt.Errorf("dates are more than %d days apart", maxDateSpan)
+	}
+
+	step := 1
+	if days < 0 {
+		step = -1
+	}
+
+	// Business days are counted after start, up to and including end, e.g. 1 from Friday to Monday.
+	holidays, skipped, business := c.holidays(ctx, payload), []string(nil), 0
+	for date := start; !date.Equal(end); {
+		date = date.AddDate(0, 0, step)
+		if name, ok := holidays.days[date.Format(time.DateOnly)]; ok && !isWeekend(date) {
+			skipped = append(skipped, date.Format(time.DateOnly)+": "+name)
+		} else if !isWeekend(date) {
+			business += step
+		}
+	}
+
+	span := count(days, "day")
+	if weeks := abs(days) / 7; weeks > 0 {
+		span += " (" + count(weeks, "week")
+		if rest := abs(days) % 7; rest > 0 {
+			span += " and " + count(rest, "day")
+		}
+		span += ")"
+	}
+
+	return fmt.Sprintf("From %s to %s: %s, %s%s",
+		day(start), day(end), span, count(business, "business day"), holidays.note(skipped)), nil
+}
+
+// businessHolidays are the holidays business days skip, by date.
+type businessHolidays struct {
+	days map[string]string
+	err  error // Why holidays could not be loaded, when they could not.
+}
+
+func (c *DateCalculatorTool) holidays(ctx context.Context, payload dateCalculationArgs) businessHolidays {
+	calendar := c.Holidays
+	if calendar == nil {
+		calendar = &HolidaysTool{}
+	}
+
+	holidays, err := calendar.Holidays(ctx, payload.Country, payload.Region)
+	if err != nil {
+		return businessHolidays{err: err}
+	}
+
+	days := make(map[string]string, len(holidays))
+	for _, holiday := range holidays {
+		days[holiday.Date.Format(time.DateOnly)] = holiday.Name
+	}
+
+	return businessHolidays{days: days}
+}
+
+// note explains which holidays were skipped, or that none could be.
+func (h businessHolidays) note(skipped []string) string {
+	switch {
+	case h.err != nil:
+		return fmt.Sprintf("\nOnly weekends were skipped, holidays are unavailable: %v", h.err)
+	case len(skipped) > 0:
+		return "\nHolidays skipped:\n" + strings.Join(skipped, "\n")
+	default:
+		return ""
+	}
+}
+
+func isWeekend(date time.Time) bool {
+	return date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+}
+
+// day formats a date with its weekday and ISO week, e.g. "2026-10-16 (Friday, 2026-W42)".
+func day(date time.Time) string {
+	return fmt.Sprintf("%s (%s, %s)", date.Format(time.DateOnly), date.Weekday(), isoWeek(date))
+}
+
+// count formats n units, e.g. "1 day" or "-3 days".
+func count(n int, unit string) string {
+	if n == 1 || n == -1 {
+		return fmt.Sprintf("%d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,214 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// holidayList is a fixed holiday calendar.
+type holidayList []Holiday
+
+func (l holidayList) Holidays(ctx context.Context, country, region string) ([]Holiday, error) {
+	return l, nil
+}
+
+// failingCalendar is a holiday calendar that cannot be loaded.
+type failingCalendar struct{}
+
+func (failingCalendar) Holidays(ctx context.Context, country, region string) ([]Holiday, error) {
+	return nil, errors.New("calendar unreachable")
+}
+
+func fixedNow() time.Time {
+	return time.Date(2026, 10, 16, 22, 30, 0, 0, time.UTC)
+}
+
+func TestLoadZone(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Asia/Tokyo", want: "Asia/Tokyo"},
+		{name: "UTC", want: "UTC"},
+		{name: "Tokyo", want: "Asia/Tokyo"},
+		{name: "new york", want: "America/New_York"},
+		{name: "Buenos Aires", want: "America/Buenos_Aires"},
+		{name: " Barcelona ", want: "Europe/Madrid"},
+		{name: "São Paulo", want: "America/Sao_Paulo"},
+		{name: "ürümqi", want: "Asia/Urumqi"},
+		{name: "łódź"},
+		{name: "", want: "Local"},
+		{name: "Atlantis"},
+		{name: "../../etc/passwd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := LoadZone(tt.name)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("LoadZone(%q) = %s, want an error", tt.name, loc)
+				}
+				return
+			}
+
+			if err != nil || loc.String() != tt.want {
+				t.Errorf("LoadZone(%q) = %v, %v, want %s", tt.name, loc, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateTool(t *testing.T) {
+	tool := &DateTool{Now: fixedNow}
+
+	got, err := tool.Execute(context.Background(), `{"timezone": "Tokyo"}`)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	want := "2026-10-17T07:30:00+09:00\nWeekday: Saturday\nISO week: 2026-W42\nTime zone: Asia/Tokyo (JST, UTC+09:00)"
+	if got != want {
+		t.Errorf("Execute() = %q, want %q", got, want)
+	}
+
+	if _, err := tool.Execute(context.Background(), `{"timezone": "Atlantis"}`); err == nil {
+		t.Error("Execute() with an unknown zone succeeded")
+	}
+}
+
+func TestTimeConversionTool(t *testing.T) {
+	tool := &TimeConversionTool{Now: fixedNow}
+
+	tests := []struct {
+		name    string
+		args    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "between zones",
+			args: `{"time": "2026-10-16 09:00", "from": "Barcelona", "to": "America/New_York"}`,
+			want: "Friday 2026-10-16 09:00 in Europe/Madrid (CEST, UTC+02:00) is Friday 2026-10-16 03:00 in America/New_York (EDT, UTC-04:00)",
+		},
+		{
+			name: "across daylight saving time",
+			args: `{"time": "2026-11-02 09:00", "from": "Europe/Madrid", "to": "New York"}`,
+			want: "Monday 2026-11-02 09:00 in Europe/Madrid (CET, UTC+01:00) is Monday 2026-11-02 03:00 in America/New_York (EST, UTC-05:00)",
+		},
+		{
+			name: "with an offset",
+			args: `{"time": "2026-10-16T23:00:00-07:00", "to": "Asia/Kolkata"}`,
+			want: "Friday 2026-10-16 23:00 in UTC-07:00 is Saturday 2026-10-17 11:30 in Asia/Kolkata (IST, UTC+05:30)",
+		},
+		{
+			name: "time of day",
+			args: `{"time": "18:00", "from": "Sydney", "to": "UTC"}`,
+			want: "Saturday 2026-10-17 18:00 in Australia/Sydney (AEDT, UTC+11:00) is Saturday 2026-10-17 07:00 in UTC (UTC, UTC+00:00)",
+		},
+		{name: "invalid time", args: `{"time": "tomorrow", "to": "UTC"}`, wantErr: true},
+		{name: "unknown zone", args: `{"time": "10:00", "to": "Atlantis"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tool.Execute(context.Background(), tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Execute() = %q, want an error", got)
+				}
+				return
+			}
+
+			if err != nil || got != tt.want {
+				t.Errorf("Execute() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateCalculatorTool(t *testing.T) {
+	holidays := holidayList{
+		{Date: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), Name: "All Saints' Day"},
+		{Date: time.Date(2026, 12, 8, 0, 0, 0, 0, time.UTC), Name: "Immaculate Conception"},
+		{Date: time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC), Name: "Christmas Day"},
+	}
+	tool := &DateCalculatorTool{Holidays: holidays, Now: fixedNow}
+
+	tests := []struct {
+		name    string
+		tool    *DateCalculatorTool
+		args    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "days from today",
+			args: `{"operation": "add", "days": 45}`,
+			want: "2026-10-16 (Friday, 2026-W42) + 45 days = 2026-11-30 (Monday, 2026-W49)",
+		},
+		{
+			name: "today in a zone",
+			args: `{"operation": "add", "weeks": -1, "timezone": "Asia/Tokyo"}`,
+			want: "2026-10-17 (Saturday, 2026-W42) + -1 week = 2026-10-10 (Saturday, 2026-W41)",
+		},
+		{
+			name: "months",
+			args: `{"operation": "add", "start": "2026-01-31", "years": 1, "months": 1}`,
+			want: "2026-01-31 (Saturday, 2026-W05) + 1 year, 1 month = 2027-03-03 (Wednesday, 2027-W09)",
+		},
+		{
+			name: "business days",
+			args: `{"operation": "add", "start": "2026-12-04", "business_days": 3}`,
+			want: "2026-12-04 (Friday, 2026-W49) + 3 business days = 2026-12-10 (Thursday, 2026-W50)\nHolidays skipped:\n2026-12-08: Immaculate Conception",
+		},
+		{
+			name: "business days back",
+			args: `{"operation": "add", "start": "2026-12-28", "business_days": -2}`,
+			want: "2026-12-28 (Monday, 2026-W53) + -2 business days = 2026-12-23 (Wednesday, 2026-W52)\nHolidays skipped:\n2026-12-25: Christmas Day",
+		},
+		{
+			name: "difference",
+			args: `{"operation": "difference", "end": "2026-12-25"}`,
+			want: "From 2026-10-16 (Friday, 2026-W42) to 2026-12-25 (Friday, 2026-W52): 70 days (10 weeks), 48 business days\nHolidays skipped:\n2026-12-08: Immaculate Conception\n2026-12-25: Christmas Day",
+		},
+		{
+			name: "difference back",
+			args: `{"operation": "difference", "start": "2026-10-19", "end": "2026-10-09"}`,
+			want: "From 2026-10-19 (Monday, 2026-W43) to 2026-10-09 (Friday, 2026-W41): -10 days (1 week and 3 days), -6 business days",
+		},
+		{
+			name: "holidays unavailable",
+			tool: &DateCalculatorTool{Holidays: failingCalendar{}},
+			args: `{"operation": "difference", "start": "2026-12-07", "end": "2026-12-09"}`,
+			want: "From 2026-12-07 (Monday, 2026-W50) to 2026-12-09 (Wednesday, 2026-W50): 2 days, 2 business days\nOnly weekends were skipped, holidays are unavailable: calendar unreachable",
+		},
+		{name: "no offset", args: `{"operation": "add"}`, wantErr: "add needs a number of years, months, weeks, days or business days"},
+		{name: "no end", args: `{"operation": "difference"}`, wantErr: "end is required for difference"},
+		{name: "too far", args: `{"operation": "difference", "start": "1900-01-01", "end": "2026-01-01"}`, wantErr: "dates are more than 36600 days apart"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := tool
+			if tt.tool != nil {
+				tool = tt.tool
+			}
+
+			got, err := tool.Execute(context.Background(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil || got != tt.want {
+				t.Errorf("Execute() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/documents"
+)
+
+// maxPassages bounds the passages returned by a search, so results fit in the prompt.
+const maxPassages = 10
+
+// excerptLength is the length, in bytes or so, of the excerpts of passages kept in citations.
+const excerptLength = 240
+
+// DocumentSearchTool searches the documents uploaded by the user, the ones of every conversation and the ones
+// of the conversation it is called for. The passages found are recorded as citations, see Cite.
+type DocumentSearchTool struct {
+	Args[documentSearchArgs]
+
+	Library *documents.Library
+	// UserID returns the user the tool is called for.
+	UserID func(context.Context) string
+}
+
+type documentSearchArgs struct {
+	Query    string `json:"query" description:"What to look for in the documents, as a question or keywords"`
+	MaxCount int    `json:"max_count,omitempty" description:"Optional maximum number of passages to return, 5 if not provided, 10 at most"`
+}
+
+// Name returns the tool's identifier.
+func (d *DocumentSearchTool) Name() string {
+	return "search_documents"
+}
+
+// Description returns what the tool does.
+func (d *DocumentSearchTool) Description() string {
+	return "Search the documents the user uploaded, e.g. bookings, itineraries or policies, for the passages " +
+		"relevant to a question. Passages are numbered, cite them as [n] in the reply."
+}
+
+// CacheTTL is 0, results depend on the user and conversation.
+func (d *DocumentSearchTool) CacheTTL() time.Duration {
+	return 0
+}
+
+// Execute returns the passages closest to the query.
+func (d *DocumentSearchTool) Execute(ctx context.Context, args string) (string, error) {
+	payload, err := d.Decode(args)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.TrimSpace(payload.Query) == "" {
+		return "", errors.New("query is empty")
+	}
+
+	limit := min(cmp.Or(payload.MaxCount, 5), maxPassages)
+	matches, err := d.Library.Search(ctx, d.UserID(ctx), ConversationID(ctx), payload.Query, limit)
+	if err != nil {
+		return "", err
+	}
+
+	if len(matches) == 0 {
+		return "No uploaded documents to search.", nil
+	}
+
+	var result strings.Builder
+	for i, m := range matches {
+		n := Cite(ctx, Citation{
+			DocumentID:   m.DocumentID.Hex(),
+			DocumentName: m.DocumentName,
+			Chunk:        m.Index,
+			Excerpt:      excerpt(m.Text, excerptLength),
+		})
+		if n == 0 {
+			n = i + 1
+		}
+
+		if i > 0 {
+			result.WriteString("\n\n")
+		}
+		fmt.Fprintf(&result, "[%d] %s, part %d:\n%s", n, m.DocumentName, m.Index+1, m.Text)
+	}
+
+	return result.String(), nil
+}
+
+// excerpt returns the start of text, cut at a word after at most n bytes, with an ellipsis when cut.
+func excerpt(text string, n int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if len(text) <= n {
+		return text
+	}
+
+	if i := strings.LastIndexByte(text[:n+1], ' '); i > 0 {
+		return text[:i] + "…"
+	}
+	return strings.ToValidUTF8(text[:n], "") + "…"
+}
@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/acai-travel/tech-challenge/internal/documents"
+	"github.com/google/go-cmp/cmp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDocumentSearchTool(t *testing.T) {
+	library := documents.NewLibrary(&documents.HashingEmbedder{}, documents.NewMemoryIndex())
+
+	add := func(owner, conversation, name, text string) primitive.ObjectID {
+		doc := &documents.Document{ID: primitive.NewObjectID(), OwnerID: owner, ConversationID: conversation, Name: name, CreatedAt: time.Now()}
+		if err := library.Add(context.Background(), doc, text); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+		return doc.ID
+	}
+
+	policy := add("alice", "", "policy.md", "Checked baggage allowance: two bags of 23 kg each.")
+	add("alice", "rome", "itinerary.txt", "Flight to Rome on May 3rd, hotel near the Pantheon.")
+	add("bob", "", "bob.txt", "Checked baggage allowance: one bag of 20 kg.")
+
+	tool := &DocumentSearchTool{Library: library, UserID: userID}
+
+	t.Run("cites the passages found", func(t *testing.T) {
+		ctx, citations := WithCitations(WithConversation(withUser(context.Background(), "alice"), "paris"))
+
+		got, err := tool.Execute(ctx, `{"query": "checked baggage allowance"}`)
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+
+		want := "[1] policy.md, part 1:\nChecked baggage allowance: two bags of 23 kg each."
+		if got != want {
+			t.Errorf("Execute() = %q, want %q", got, want)
+		}
+
+		// Finding the passage again keeps its number.
+		if _, err := tool.Execute(ctx, `{"query": "baggage"}`); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+
+		wantCitations := []Citation{{
+			Number:       1,
+			DocumentID:   policy.Hex(),
+			DocumentName: "policy.md",
+			Chunk:        0,
+			Excerpt:      "Checked baggage allowance: two bags of 23 kg each.",
+		}}
+		if diff := cmp.Diff(wantCitations, citations.List()); diff != "" {
+			t.Errorf("citations mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("includes the documents of the conversation", func(t *testing.T) {
+		ctx := WithConversation(withUser(context.Background(), "alice"), "rome")
+
+		got, err := tool.Execute(ctx, `{"query": "hotel in Rome", "max_count": 1}`)
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+
+		want := "[1] itinerary.txt, part 1:\nFlight to Rome on May 3rd, hotel near the Pantheon."
+		if got != want {
+			t.Errorf("Execute() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("nothing uploaded", func(t *testing.T) {
+		got, err := tool.Execute(withUser(context.Background(), "carol"), `{"query": "baggage"}`)
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if want := "No uploaded documents to search."; got != want {
+			t.Errorf("Execute() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("empty query", func(t *testing.T) {
+		if _, err := tool.Execute(context.Background(), `{"query": " "}`); err == nil {
+			t.Error("Execute() succeeded, want an error")
+		}
+	})
+}
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		text string
+		n    int
+		want string
+	}{
+		{text: "Gate B32", n: 20, want: "Gate B32"},
+		{text: "Boarding\n  closes at   09:10", n: 40, want: "Boarding closes at 09:10"},
+		{text: "Boarding closes at 09:10", n: 15, want: "Boarding closes…"},
+		{text: "Supercalifragilistic", n: 5, want: "Super…"},
+	}
+
+	for _, tt := range tests {
+		if got := excerpt(tt.text, tt.n); got != tt.want {
+			t.Errorf("excerpt(%q, %d) = %q, want %q", tt.text, tt.n, got, tt.want)
+		}
+	}
+}
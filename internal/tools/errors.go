@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Codes of the errors reported by Registry.Execute.
+const (
+	CodeUnknownTool      = "unknown_tool"
+	CodeInvalidArguments = "invalid_arguments"
+	CodeExecutionFailed  = "execution_failed"
+	CodeTimeout          = "timeout"
+	CodeCanceled         = "canceled"
+)
+
+// ToolError is a failed tool call, as reported by Registry.Execute. It is fed back to the model as the result
+// of the call, so it can correct its arguments or try another way.
+type ToolError struct {
+	Tool    string  `json:"tool"`
+	Code    string  `json:"code"`
+	Message string  `json:"message"`
+	Issues  []Issue `json:"issues,omitempty"` // Set for invalid arguments.
+
+	err error // The error returned by the tool, if any.
+}
+
+func (e *ToolError) Error() string {
+	if len(e.Issues) == 0 {
+		return e.Message
+	}
+
+	issues := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		issues[i] = issue.String()
+	}
+
+	return e.Message + ": " + strings.Join(issues, "; ")
+}
+
+func (e *ToolError) Unwrap() error {
+	return e.err
+}
+
+// Result returns the error as the JSON result of the call fed back to the model.
+func (e *ToolError) Result() string {
+	data, err := json.Marshal(map[string]any{"error": e})
+	if err != nil {
+		return `{"error":{"message":"tool call failed"}}`
+	}
+
+	return string(data)
+}
@@ -0,0 +1,494 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/acai-travel/tech-challenge/internal/decimal"
+)
+
+// Limits keeping expressions from the model cheap to evaluate.
+const (
+	maxExpressionLength = 1000
+	maxExponent         = 1000
+	maxResultBits       = 100_000
+)
+
+// expr is a node of a parsed arithmetic expression.
+type expr interface {
+	// eval returns the value of the expression. It sets *inexact when rounding was involved.
+	eval(inexact *bool) (decimal.Decimal, error)
+	// format returns the normalized form of the expression.
+	format() string
+	// precedence returns how tightly the expression binds, for parenthesizing it in formatted parents.
+	precedence() int
+}
+
+// Precedences of expressions, from loosest to tightest.
+const (
+	precSum = iota + 1
+	precProduct
+	precUnary
+	precPower
+	precPercent
+	precPrimary
+)
+
+type literal struct {
+	value decimal.Decimal
+}
+
+func (n *literal) eval(*bool) (decimal.Decimal, error) { return n.value, nil }
+func (n *literal) format() string                      { return n.value.String() }
+func (n *literal) precedence() int                     { return precPrimary }
+
+type negation struct {
+	x expr
+}
+
+func (n *negation) eval(inexact *bool) (decimal.Decimal, error) {
+	x, err := n.x.eval(inexact)
+	return x.Neg(), err
+}
+
+func (n *negation) format() string  { return "-" + formatOperand(n.x, precPower) }
+func (n *negation) precedence() int { return precUnary }
+
+type percent struct {
+	x expr
+}
+
+func (p *percent) eval(inexact *bool) (decimal.Decimal, error) {
+	x, err := p.x.eval(inexact)
+	if err != nil {
+		return x, err
+	}
+	return x.Quo(decimal.New(100))
+}
+
+func (p *percent) format() string  { return formatOperand(p.x, precPrimary) + "%" }
+func (p *percent) precedence() int { return precPercent }
+
+type operation struct {
+	op   string // "+", "-", "*", "/", "mod" or "^"
+	l, r expr
+}
+
+func (b *operation) eval(inexact *bool) (decimal.Decimal, error) {
+	l, err := b.l.eval(inexact)
+	if err != nil {
+		return l, err
+	}
+
+	r, err := b.r.eval(inexact)
+	if err != nil {
+		return r, err
+	}
+
+	switch b.op {
+	case "+":
+		return l.Add(r), nil
+	case "-":
+		return l.Sub(r), nil
+	case "*":
+		return l.Mul(r), nil
+	case "/":
+		return l.Quo(r)
+	case "mod":
+		return l.Mod(r)
+	default:
+		return power(l, r)
+	}
+}
+
+// power returns l ^ r, for integer exponents not making the result too large.
+func power(l, r decimal.Decimal) (decimal.Decimal, error) {
+	n, ok := r.Int64()
+	if !ok {
+		return decimal.Decimal{}, fmt.Errorf("exponent %s is not an integer, use sqrt for square roots", r)
+	}
+	if n > maxExponent || n < -maxExponent {
+		return decimal.Decimal{}, fmt.Errorf("exponent %d out of range", n)
+	}
+
+	rat := l.Rat()
+	if bits := max(rat.Num().BitLen(), rat.Denom().BitLen()); int64(bits)*max(n, -n) > maxResultBits {
+		return decimal.Decimal{}, errors.New("result too large")
+	}
+
+	return l.Pow(n)
+}
+
+func (b *operation) format() string {
+	p := b.precedence()
+
+	// Sums and products are left-associative, powers right-associative.
+	left, right := p, p+1
+	if b.op == "^" {
+		left, right = precPercent, precUnary
+	}
+
+	return formatOperand(b.l, left) + " " + b.op + " " + formatOperand(b.r, right)
+}
+
+func (b *operation) precedence() int {
+	switch b.op {
+	case "+", "-":
+		return precSum
+	case "^":
+		return precPower
+	default:
+		return precProduct
+	}
+}
+
+type funcCall struct {
+	name string
+	args []expr
+}
+
+// functions are the functions expressions may call, with their number of arguments, -1 for one or more.
+var functions = map[string]int{
+	"sqrt": 1, "abs": 1, "floor": 1, "ceil": 1, "round": -1, "min": -1, "max": -1,
+}
+
+func (c *funcCall) eval(inexact *bool) (decimal.Decimal, error) {
+	args := make([]decimal.Decimal, len(c.args))
+	for i, arg := range c.args {
+		var err error
+		if args[i], err = arg.eval(inexact); err != nil {
+			return args[i], err
+		}
+	}
+
+	switch c.name {
+	case "sqrt":
+		root, exact, err := args[0].Sqrt()
+		*inexact = *inexact || !exact
+		return root, err
+	case "abs":
+		return args[0].Abs(), nil
+	case "floor":
+		return args[0].Floor(), nil
+	case "ceil":
+		return args[0].Ceil(), nil
+	case "round":
+		// round(x) rounds to an integer, round(x, places) to decimal places.
+		if len(args) > 2 {
+			return decimal.Decimal{}, errors.New("round takes 1 or 2 arguments")
+		}
+
+		var places int64
+		if len(args) == 2 {
+			var ok bool
+			if places, ok = args[1].Int64(); !ok || places < -maxExponent || places > maxExponent {
+				return decimal.Decimal{}, fmt.Errorf("round places %s must be an integer", args[1])
+			}
+		}
+		return args[0].Round(int(places)), nil
+	default:
+		result := args[0]
+		for _, arg := range args[1:] {
+			if (c.name == "min") == (arg.Cmp(result) < 0) {
+				result = arg
+			}
+		}
+		return result, nil
+	}
+}
+
+func (c *funcCall) format() string {
+	args := make([]string, len(c.args))
+	for i, arg := range c.args {
+		args[i] = arg.format()
+	}
+	return c.name + "(" + strings.Join(args, ", ") + ")"
+}
+
+func (c *funcCall) precedence() int { return precPrimary }
+
+// formatOperand formats an operand, parenthesized when it binds looser than minPrecedence.
+func formatOperand(x expr, minPrecedence int) string {
+	if x.precedence() < minPrecedence {
+		return "(" + x.format() + ")"
+	}
+	return x.format()
+}
+
+// token is a lexical token: a number, a name, or an operator or punctuation.
+type token struct {
+	text string
+	pos  int // Byte offset in the expression, for error messages.
+}
+
+func (t token) isNumber() bool {
+	return t.text != "" && (isDigit(t.text[0]) || t.text[0] == '.')
+}
+
+func (t token) isName() bool {
+	return t.text != "" && isLetter(t.text[0])
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// startsOperand tells whether the token can start an operand, which makes a "%" before it a modulo.
+func (t token) startsOperand() bool {
+	return t.isNumber() || t.isName() || t.text == "("
+}
+
+// tokenize splits the expression into tokens. "×" and "÷" are read as "*" and "/", "**" as "^".
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case isDigit(c) || c == '.':
+			j := i
+			for j < len(s) && (isDigit(s[j]) || s[j] == '.') {
+				j++
+			}
+			// Scientific notation, e.g. 1.5e3.
+			if j < len(s) && (s[j] == 'e' || s[j] == 'E') {
+				k := j + 1
+				if k < len(s) && (s[k] == '+' || s[k] == '-') {
+					k++
+				}
+				if k < len(s) && isDigit(s[k]) {
+					for j = k; j < len(s) && isDigit(s[j]); j++ {
+					}
+				}
+			}
+			tokens = append(tokens, token{text: s[i:j], pos: i})
+			i = j
+		case isLetter(c):
+			j := i
+			for j < len(s) && isLetter(s[j]) {
+				j++
+			}
+			tokens = append(tokens, token{text: strings.ToLower(s[i:j]), pos: i})
+			i = j
+		case strings.HasPrefix(s[i:], "**"):
+			tokens = append(tokens, token{text: "^", pos: i})
+			i += 2
+		case strings.HasPrefix(s[i:], "×"):
+			tokens = append(tokens, token{text: "*", pos: i})
+			i += len("×")
+		case strings.HasPrefix(s[i:], "÷"):
+			tokens = append(tokens, token{text: "/", pos: i})
+			i += len("÷")
+		case strings.IndexByte("+-*/%^(),", c) >= 0:
+			tokens = append(tokens, token{text: string(c), pos: i})
+			i++
+		default:
+			r, _ := utf8.DecodeRuneInString(s[i:])
+			return nil, fmt.Errorf("unexpected character %q at position %d", r, i)
+		}
+	}
+
+	return tokens, nil
+}
+
+// parser is a recursive descent parser of arithmetic expressions:
+//
+//	sum     = product { ("+" | "-") product }
+//	product = unary { ("*" | "/" | "%" | "mod") unary }
+//	unary   = ("-" | "+") unary | power
+//	power   = percent [ "^" unary ]
+//	percent = primary { "%" }   a "%" not followed by an operand
+//	primary = number | name "(" sum { "," sum } ")" | "(" sum ")"
+type parser struct {
+	tokens []token
+	pos    int
+	end    int // Length of the expression, the position reported at its end.
+}
+
+// parseExpression parses an arithmetic expression.
+func parseExpression(s string) (expr, error) {
+	if len(s) > maxExpressionLength {
+		return nil, fmt.Errorf("expression longer than %d characters", maxExpressionLength)
+	}
+
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, errors.New("empty expression")
+	}
+
+	p := &parser{tokens: tokens, end: len(s)}
+	x, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, p.unexpected()
+	}
+	return x, nil
+}
+
+func (p *parser) peek() token {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return token{pos: p.end}
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) unexpected() error {
+	t := p.peek()
+	if t.text == "" {
+		return errors.New("unexpected end of expression")
+	}
+	return fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+}
+
+func (p *parser) sum() (expr, error) {
+	x, err := p.product()
+	for err == nil && (p.peek().text == "+" || p.peek().text == "-") {
+		op := p.next().text
+
+		var y expr
+		if y, err = p.product(); err == nil {
+			x = &operation{op: op, l: x, r: y}
+		}
+	}
+	return x, err
+}
+
+func (p *parser) product() (expr, error) {
+	x, err := p.unary()
+	for err == nil {
+		op := p.peek().text
+		switch op {
+		case "*", "/", "mod":
+		case "%":
+			op = "mod" // A "%" left by percent is a modulo.
+		default:
+			return x, nil
+		}
+		p.next()
+
+		var y expr
+		if y, err = p.unary(); err == nil {
+			x = &operation{op: op, l: x, r: y}
+		}
+	}
+	return x, err
+}
+
+func (p *parser) unary() (expr, error) {
+	switch p.peek().text {
+	case "-":
+		p.next()
+		x, err := p.unary()
+		return &negation{x: x}, err
+	case "+":
+		p.next()
+		return p.unary()
+	}
+	return p.power()
+}
+
+func (p *parser) power() (expr, error) {
+	x, err := p.percent()
+	if err != nil || p.peek().text != "^" {
+		return x, err
+	}
+	p.next()
+
+	y, err := p.unary()
+	return &operation{op: "^", l: x, r: y}, err
+}
+
+func (p *parser) percent() (expr, error) {
+	x, err := p.primary()
+	for err == nil && p.peek().text == "%" {
+		if p.pos+1 < len(p.tokens) && p.tokens[p.pos+1].startsOperand() {
+			break
+		}
+		p.next()
+		x = &percent{x: x}
+	}
+	return x, err
+}
+
+func (p *parser) primary() (expr, error) {
+	t := p.peek()
+
+	switch {
+	case t.isNumber():
+		p.next()
+		value, err := decimal.Parse(t.text)
+		if err != nil {
+			return nil, fmt.Errorf("%w at position %d", err, t.pos)
+		}
+		return &literal{value: value}, nil
+	case t.isName():
+		arity, ok := functions[t.text]
+		if !ok {
+			return nil, fmt.Errorf("unknown function %q at position %d", t.text, t.pos)
+		}
+		p.next()
+
+		if p.peek().text != "(" {
+			return nil, p.unexpected()
+		}
+		p.next()
+
+		c := &funcCall{name: t.text}
+		for {
+			arg, err := p.sum()
+			if err != nil {
+				return nil, err
+			}
+			c.args = append(c.args, arg)
+
+			if p.peek().text != "," {
+				break
+			}
+			p.next()
+		}
+
+		if p.peek().text != ")" {
+			return nil, p.unexpected()
+		}
+		p.next()
+
+		if arity > 0 && len(c.args) != arity {
+			return nil, fmt.Errorf("%s takes %d argument(s), got %d", c.name, arity, len(c.args))
+		}
+		return c, nil
+	case t.text == "(":
+		p.next()
+		x, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek().text != ")" {
+			return nil, p.unexpected()
+		}
+		p.next()
+		return x, nil
+	default:
+		return nil, p.unexpected()
+	}
+}
@@ -0,0 +1,260 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Flight is the status of a flight on a day. Times are in the zones of the airports.
+type Flight struct {
+	Number  string // e.g. IB2620.
+	Airline string
+	From    Airport
+	To      Airport
+	Status  string // One of the FlightStatus constants.
+
+	ScheduledDeparture time.Time
+	ScheduledArrival   time.Time
+	EstimatedDeparture time.Time // Actual once departed.
+	EstimatedArrival   time.Time // Actual once landed.
+
+	Terminal string // Of departure, empty when unknown.
+	Gate     string // Of departure, empty when unknown.
+
+	Source string // Where the status comes from, e.g. to tell live data from a schedule.
+}
+
+// Flight statuses.
+const (
+	FlightScheduled = "scheduled"
+	FlightDelayed   = "delayed"
+	FlightInAir     = "in the air"
+	FlightLanded    = "landed"
+	FlightCancelled = "cancelled"
+)
+
+// FlightStatusProvider provides the status of flights.
+type FlightStatusProvider interface {
+	// FlightStatus returns the status of the flight number departing on date, a day in the zone of its departure
+	// airport. A zero date asks for the flight departing today.
+	FlightStatus(ctx context.Context, number string, date time.Time) (*Flight, error)
+}
+
+// ErrUnknownFlight is returned by flight status providers for flights they do not know, or that do not operate
+// on the day asked for.
+var ErrUnknownFlight = errors.New("unknown flight")
+
+// ScheduledFlight is a flight of a StubFlights schedule.
+type ScheduledFlight struct {
+	Number    string
+	Airline   string
+	From, To  string         // Airport codes.
+	Departure string         // Local time at From, e.g. "07:05".
+	Days      []time.Weekday // Days the flight operates, every day when empty.
+	Delay     time.Duration
+	Cancelled bool
+	Terminal  string
+	Gate      string
+}
+
+// StubFlights provides flight statuses from a fixed schedule, with no live data: departures are on time, or
+// late by the delay of the schedule, and flights take the time EstimateFlightTime gives. It stands in for a real
+// provider in development, demos and tests.
+type StubFlights struct {
+	// Schedule defaults to a handful of flights to and from Barcelona.
+	Schedule []ScheduledFlight
+	// Airports defaults to the dataset at AIRPORTS_FILE, or the built-in one.
+	Airports *Airports
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+// stubSchedule is the default schedule of StubFlights.
+var stubSchedule = []ScheduledFlight{
+	{Number: "IB2620", Airline: "Iberia", From: "BCN", To: "MAD", Departure: "07:00", Terminal: "1", Gate: "B32"},
+	{Number: "VY7820", Airline: "Vueling", From: "BCN", To: "LGW", Departure: "09:35", Delay: 25 * time.Minute, Terminal: "1", Gate: "A14"},
+	{Number: "BA479", Airline: "British Airways", From: "LHR", To: "BCN", Departure: "13:05", Terminal: "5"},
+	{Number: "AF1149", Airline: "Air France", From: "CDG", To: "BCN", Departure: "10:20", Terminal: "2F",
+		Days: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}},
+	{Number: "LH1132", Airline: "Lufthansa", From: "FRA", To: "BCN", Departure: "12:55", Terminal: "1"},
+	{Number: "KL1671", Airline: "KLM", From: "AMS", To: "BCN", Departure: "06:50", Cancelled: true},
+	{Number: "DL169", Airline: "Delta Air Lines", From: "JFK", To: "BCN", Departure: "17:25", Terminal: "4"},
+	{Number: "IB6251", Airline: "Iberia", From: "MAD", To: "JFK", Departure: "15:40", Terminal: "4S"},
+	{Number: "QR146", Airline: "Qatar Airways", From: "DOH", To: "BCN", Departure: "01:45"},
+}
+
+// FlightStatus returns the status of a flight of the schedule, as of now.
+func (s *StubFlights) FlightStatus(ctx context.Context, number string, date time.Time) (*Flight, error) {
+	number = flightNumber(number)
+	schedule := s.Schedule
+	if schedule == nil {
+		schedule = stubSchedule
+	}
+
+	i := slices.IndexFunc(schedule, func(f ScheduledFlight) bool { return flightNumber(f.Number) == number })
+	if i < 0 {
+		return nil, ErrUnknownFlight
+	}
+	scheduled := schedule[i]
+
+	airports := s.Airports
+	if airports == nil {
+		var err error
+		if airports, err = defaultAirports(); err != nil {
+			return nil, err
+		}
+	}
+
+	from, err := airports.Place(scheduled.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := airports.Place(scheduled.To)
+	if err != nil {
+		return nil, err
+	}
+	fromZone, err := LoadZone(from.Airport.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	toZone, err := LoadZone(to.Airport.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	departure, err := time.Parse("15:04", scheduled.Departure)
+	if err != nil {
+		return nil, fmt.Errorf("invalid departure time of %s: %w", scheduled.Number, err)
+	}
+
+	now := time.Now()
+	if s.Now != nil {
+		now = s.Now()
+	}
+	if date.IsZero() {
+		date = now.In(fromZone)
+	}
+	if len(scheduled.Days) > 0 && !slices.Contains(scheduled.Days, date.Weekday()) {
+		return nil, fmt.Errorf("%w: %s does not operate on %s", ErrUnknownFlight, scheduled.Number, date.Weekday())
+	}
+
+	y, m, d := date.Date()
+	flight := &Flight{
+		Number:             scheduled.Number,
+		Airline:            scheduled.Airline,
+		From:               *from.Airport,
+		To:                 *to.Airport,
+		ScheduledDeparture: time.Date(y, m, d, departure.Hour(), departure.Minute(), 0, 0, fromZone),
+		Terminal:           scheduled.Terminal,
+		Gate:               scheduled.Gate,
+		Source:             "schedule, not live data",
+	}
+	flight.ScheduledArrival = flight.ScheduledDeparture.Add(EstimateFlightTime(Distance(from, to))).In(toZone)
+	flight.EstimatedDeparture = flight.ScheduledDeparture.Add(scheduled.Delay)
+	flight.EstimatedArrival = flight.ScheduledArrival.Add(scheduled.Delay)
+
+	switch {
+	case scheduled.Cancelled:
+		flight.Status = FlightCancelled
+	case now.Before(flight.EstimatedDeparture) && scheduled.Delay > 0:
+		flight.Status = FlightDelayed
+	case now.Before(flight.EstimatedDeparture):
+		flight.Status = FlightScheduled
+	case now.Before(flight.EstimatedArrival):
+		flight.Status = FlightInAir
+	default:
+		flight.Status = FlightLanded
+	}
+
+	return flight, nil
+}
+
+// flightNumber normalizes flight numbers, e.g. "ib 2620" to "IB2620".
+func flightNumber(s string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(s), ""))
+}
+
+// FlightStatusTool provides the status of flights.
+type FlightStatusTool struct {
+	Args[flightStatusArgs]
+
+	// Provider defaults to StubFlights, until a live provider is configured.
+	Provider FlightStatusProvider
+}
+
+type flightStatusArgs struct {
+	Flight string `json:"flight" description:"The flight number, airline code and number, e.g. IB2620"`
+	Date   string `json:"date,omitempty" format:"date" description:"Optional date of departure in YYYY-MM-DD format, local to the departure airport. Today is used if not provided."`
+}
+
+// Name returns the tool's identifier.
+func (f *FlightStatusTool) Name() string {
+	return "get_flight_status"
+}
+
+// Description returns what the tool does.
+func (f *FlightStatusTool) Description() string {
+	return "Get the status of a flight on a day: its route, scheduled and estimated times of departure and arrival, " +
+		"local to each airport, and terminal and gate when known"
+}
+
+// CacheTTL is short, statuses change by the minute around departure.
+func (f *FlightStatusTool) CacheTTL() time.Duration {
+	return time.Minute
+}
+
+// Execute returns the status of the flight.
+func (f *FlightStatusTool) Execute(ctx context.Context, args string) (string, error) {
+	payload, err := f.Decode(args)
+	if err != nil {
+		return "", err
+	}
+
+	var date time.Time
+	if payload.Date != "" {
+		if date, err = time.Parse(time.DateOnly, payload.Date); err != nil {
+			return "", fmt.Errorf("invalid date %q, expected YYYY-MM-DD", payload.Date)
+		}
+	}
+
+	provider := f.Provider
+	if provider == nil {
+		provider = &StubFlights{}
+	}
+
+	flight, err := provider.FlightStatus(ctx, payload.Flight, date)
+	if err != nil {
+		return "", fmt.Errorf("no status for flight %s: %w", payload.Flight, err)
+	}
+
+	var result strings.Builder
+	fmt.Fprintf(&result, "%s (%s): %s (%s) to %s (%s)\n", flight.Number, flight.Airline,
+		flight.From.City, flight.From.Code(), flight.To.City, flight.To.Code())
+	fmt.Fprintf(&result, "Status: %s\n", flight.Status)
+	fmt.Fprintf(&result, "Departure: %s\n", flightTimes(flight.ScheduledDeparture, flight.EstimatedDeparture))
+	fmt.Fprintf(&result, "Arrival: %s", flightTimes(flight.ScheduledArrival, flight.EstimatedArrival))
+	if flight.Terminal != "" {
+		fmt.Fprintf(&result, "\nTerminal: %s", flight.Terminal)
+	}
+	if flight.Gate != "" {
+		fmt.Fprintf(&result, "\nGate: %s", flight.Gate)
+	}
+	if flight.Source != "" {
+		fmt.Fprintf(&result, "\nSource: %s", flight.Source)
+	}
+
+	return result.String(), nil
+}
+
+// flightTimes formats a scheduled time, and the estimated one when different.
+func flightTimes(scheduled, estimated time.Time) string {
+	const layout = "2006-01-02 15:04 MST"
+	if estimated.IsZero() || estimated.Equal(scheduled) {
+		return scheduled.Format(layout)
+	}
+	return fmt.Sprintf("%s, estimated %s", scheduled.Format(layout), estimated.Format(layout))
+}
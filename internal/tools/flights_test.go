@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStubFlights(t *testing.T) {
+	schedule := []ScheduledFlight{
+		{Number: "IB2620", Airline: "Iberia", From: "BCN", To: "MAD", Departure: "07:00"},
+		{Number: "VY7820", Airline: "Vueling", From: "BCN", To: "LGW", Departure: "09:35", Delay: 25 * time.Minute},
+		{Number: "KL1671", Airline: "KLM", From: "AMS", To: "BCN", Departure: "06:50", Cancelled: true},
+		{Number: "AF1149", Airline: "Air France", From: "CDG", To: "BCN", Departure: "10:20", Days: []time.Weekday{time.Monday}},
+	}
+
+	tests := []struct {
+		name    string
+		number  string
+		now     time.Time
+		date    time.Time
+		want    string
+		wantErr error
+	}{
+		{name: "scheduled", number: "IB2620", now: time.Date(2026, 10, 16, 4, 0, 0, 0, time.UTC), want: FlightScheduled},
+		{name: "in the air", number: "ib 2620", now: time.Date(2026, 10, 16, 5, 30, 0, 0, time.UTC), want: FlightInAir},
+		{name: "landed", number: "IB2620", now: time.Date(2026, 10, 16, 6, 10, 0, 0, time.UTC), want: FlightLanded},
+		{name: "another day", number: "IB2620", now: time.Date(2026, 10, 16, 6, 10, 0, 0, time.UTC), date: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), want: FlightScheduled},
+		{name: "delayed", number: "VY7820", now: time.Date(2026, 10, 16, 7, 45, 0, 0, time.UTC), want: FlightDelayed},
+		{name: "delayed departure", number: "VY7820", now: time.Date(2026, 10, 16, 8, 5, 0, 0, time.UTC), want: FlightInAir},
+		{name: "cancelled", number: "KL1671", now: time.Date(2026, 10, 16, 4, 0, 0, 0, time.UTC), want: FlightCancelled},
+		{name: "not operating", number: "AF1149", now: time.Date(2026, 10, 16, 4, 0, 0, 0, time.UTC), wantErr: ErrUnknownFlight},
+		{name: "unknown", number: "XX1", now: time.Date(2026, 10, 16, 4, 0, 0, 0, time.UTC), wantErr: ErrUnknownFlight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &StubFlights{Schedule: schedule, Now: func() time.Time { return tt.now }}
+
+			got, err := provider.FlightStatus(context.Background(), tt.number, tt.date)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FlightStatus() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got.Status != tt.want {
+				t.Errorf("FlightStatus() status = %q, want %q", got.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlightStatusTool(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 50, 0, 0, time.UTC)
+	tool := &FlightStatusTool{Provider: &StubFlights{Now: func() time.Time { return now }}}
+
+	tests := []struct {
+		name    string
+		args    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "delayed",
+			args: `{"flight": "VY7820"}`,
+			want: "VY7820 (Vueling): Barcelona (BCN) to London (LGW)\n" +
+				"Status: in the air\n" +
+				"Departure: 2026-10-16 09:35 CEST, estimated 2026-10-16 10:00 CEST\n" +
+				"Arrival: 2026-10-16 10:30 BST, estimated 2026-10-16 10:55 BST\n" +
+				"Terminal: 1\n" +
+				"Gate: A14\n" +
+				"Source: schedule, not live data",
+		},
+		{
+			name: "overnight",
+			args: `{"flight": "DL169", "date": "2026-10-16"}`,
+			want: "DL169 (Delta Air Lines): New York (JFK) to Barcelona (BCN)\n" +
+				"Status: scheduled\n" +
+				"Departure: 2026-10-16 17:25 EDT\n" +
+				"Arrival: 2026-10-17 07:35 CEST\n" +
+				"Terminal: 4\n" +
+				"Source: schedule, not live data",
+		},
+		{name: "not operating", args: `{"flight": "AF1149", "date": "2026-10-17"}`, wantErr: "no status for flight AF1149: unknown flight: AF1149 does not operate on Saturday"},
+		{name: "invalid date", args: `{"flight": "AF1149", "date": "17/10/2026"}`, wantErr: `invalid date "17/10/2026", expected YYYY-MM-DD`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tool.Execute(context.Background(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Execute() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Execute() mismatch (-want +got):\n%s", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
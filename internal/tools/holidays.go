@@ -1,32 +1,171 @@
 package tools
 
 import (
+	"bytes"
 	"context"
+	_ "embed"
 	"encoding/json"
 	"fmt"
-	"log/slog"
 	"os"
+	"slices"
 	"strings"
+	"sync"
 	"time"
-
-	ics "github.com/arran4/golang-ical"
-	"github.com/openai/openai-go/v2"
 )
 
-// LoadCalendar loads calendar events from the specified URL.
-func LoadCalendar(ctx context.Context, link string) ([]*ics.VEvent, error) {
-	slog.InfoContext(ctx, "Loading calendar", "link", link)
+//go:embed holidays_catalog.json
+var defaultHolidayCatalog []byte
+
+// HolidayCatalog maps countries, and regions of them, to the ICS calendars of their holidays.
+type HolidayCatalog struct {
+	// Default is the calendar of calls not asking for a country.
+	Default struct {
+		Country string `json:"country"`
+		Region  string `json:"region,omitempty"`
+	} `json:"default"`
+	// Countries are keyed by their ISO 3166-1 alpha-2 code, in lower case.
+	Countries map[string]HolidayCountry `json:"countries"`
+}
 
-	cal, err := ics.ParseCalendarFromUrl(link, ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse calendar: %w", err)
+// HolidayCountry is where the holidays of a country are published.
+type HolidayCountry struct {
+	Name string `json:"name"`
+	// Link is the calendar of the national holidays, if there is one.
+	Link string `json:"link,omitempty"`
+	// Regions are the calendars of regions, with their own holidays on top of the national ones, keyed by name in
+	// lower case, with dashes for spaces.
+	Regions map[string]string `json:"regions,omitempty"`
+}
+
+// LoadHolidayCatalog loads a catalog from a JSON file, or the built-in one for an empty path.
+func LoadHolidayCatalog(path string) (*HolidayCatalog, error) {
+	data := defaultHolidayCatalog
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	var catalog HolidayCatalog
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&catalog); err != nil {
+		return nil, fmt.Errorf("invalid holiday catalog %s: %w", path, err)
+	}
+
+	return &catalog, nil
+}
+
+// Link returns the calendar of a country, by code or name, and optionally a region of it. An empty country is the
+// catalog's default country, and its default region too unless a region is given.
+func (c *HolidayCatalog) Link(country, region string) (string, error) {
+	if country == "" {
+		country = c.Default.Country
+		if region == "" {
+			region = c.Default.Region
+		}
+	}
+
+	code, entry, ok := c.country(country)
+	if !ok {
+		return "", fmt.Errorf("no holidays known for country %q, known are %s", country, strings.Join(c.names(), ", "))
+	}
+
+	if region == "" {
+		if entry.Link == "" {
+			return "", fmt.Errorf("holidays of %s are by region, one of %s", entry.Name, strings.Join(entry.regions(), ", "))
+		}
+		return entry.Link, nil
+	}
+
+	link, ok := entry.Regions[slug(region)]
+	if !ok {
+		if len(entry.Regions) == 0 {
+			return "", fmt.Errorf("no regional holidays known for %s (%s), leave out the region", entry.Name, strings.ToUpper(code))
+		}
+		return "", fmt.Errorf("no holidays known for region %q of %s, known are %s", region, entry.Name, strings.Join(entry.regions(), ", "))
+	}
+
+	return link, nil
+}
+
+func (c *HolidayCatalog) country(name string) (string, HolidayCountry, bool) {
+	name = slug(name)
+	if entry, ok := c.Countries[name]; ok {
+		return name, entry, true
 	}
 
-	return cal.Events(), nil
+	for code, entry := range c.Countries {
+		if slug(entry.Name) == name {
+			return code, entry, true
+		}
+	}
+
+	return "", HolidayCountry{}, false
+}
+
+// names returns the names of the countries of the catalog, sorted.
+func (c *HolidayCatalog) names() []string {
+	var names []string
+	for code, entry := range c.Countries {
+		names = append(names, fmt.Sprintf("%s (%s)", entry.Name, strings.ToUpper(code)))
+	}
+
+	slices.Sort(names)
+	return names
+}
+
+func (c HolidayCountry) regions() []string {
+	var regions []string
+	for region := range c.Regions {
+		regions = append(regions, region)
+	}
+
+	slices.Sort(regions)
+	return regions
+}
+
+// slug normalizes country and region names, e.g. "Balearic Islands" to "balearic-islands".
+func slug(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(name, "_", " "))), "-")
+}
+
+// defaultHolidays is the catalog of HolidaysTools without one: the catalog at HOLIDAY_CATALOG, or the built-in one.
+var defaultHolidays = sync.OnceValues(func() (*HolidayCatalog, error) {
+	return LoadHolidayCatalog(os.Getenv("HOLIDAY_CATALOG"))
+})
+
+// Holiday is a public holiday.
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// HolidayCalendar provides public holidays, e.g. for business days to skip.
+type HolidayCalendar interface {
+	// Holidays returns the holidays of a country and optionally a region of it, sorted by date. An empty country
+	// is the default country, and an empty country and region the default calendar.
+	Holidays(ctx context.Context, country, region string) ([]Holiday, error)
 }
 
 // HolidaysTool provides information about public holidays.
-type HolidaysTool struct{}
+type HolidaysTool struct {
+	Args[holidaysArgs]
+
+	// Catalog defaults to the catalog at HOLIDAY_CATALOG, or the built-in one.
+	Catalog *HolidayCatalog
+	// Calendars defaults to DefaultCalendars.
+	Calendars *CalendarStore
+}
+
+type holidaysArgs struct {
+	Country    string    `json:"country,omitempty" description:"Optional country, as an ISO 3166-1 alpha-2 code (e.g. ES) or a name (e.g. Spain). Spain is used if not provided, with the holidays of Catalonia unless a region is given."`
+	Region     string    `json:"region,omitempty" description:"Optional region of the country with holidays of its own, e.g. Madrid. Only national holidays are returned if not provided."`
+	BeforeDate time.Time `json:"before_date,omitempty" description:"Optional date in RFC3339 format to get holidays before this date. If not provided, all holidays will be returned."`
+	AfterDate  time.Time `json:"after_date,omitempty" description:"Optional date in RFC3339 format to get holidays after this date. If not provided, all holidays will be returned."`
+	MaxCount   int       `json:"max_count,omitempty" description:"Optional maximum number of holidays to return, the earliest first. If not provided, all holidays will be returned."`
+}
 
 // Name returns the tool's identifier.
 func (h *HolidaysTool) Name() string {
@@ -35,73 +174,81 @@ func (h *HolidaysTool) Name() string {
 
 // Description returns what the tool does.
 func (h *HolidaysTool) Description() string {
-	return "Gets local bank and public holidays. Each line is a single holiday in the format 'YYYY-MM-DD: Holiday Name'."
-}
-
-// Parameters defines the tool's input schema.
-func (h *HolidaysTool) Parameters() openai.FunctionParameters {
-	return openai.FunctionParameters{
-		"type": "object",
-		"properties": map[string]any{
-			"before_date": map[string]string{
-				"type":        "string",
-				"description": "Optional date in RFC3339 format to get holidays before this date. If not provided, all holidays will be returned.",
-			},
-			"after_date": map[string]string{
-				"type":        "string",
-				"description": "Optional date in RFC3339 format to get holidays after this date. If not provided, all holidays will be returned.",
-			},
-			"max_count": map[string]string{
-				"type":        "integer",
-				"description": "Optional maximum number of holidays to return. If not provided, all holidays will be returned.",
-			},
-		},
-	}
+	return "Gets bank and public holidays of a country, and optionally a region of it, by default the ones of Barcelona. " +
+		"Each line is a single holiday in the format 'YYYY-MM-DD: Holiday Name', sorted by date."
 }
 
-// Execute retrieves holiday information based on the provided criteria.
-func (h *HolidaysTool) Execute(ctx context.Context, args string) (string, error) {
-	link := "https://www.officeholidays.com/ics/spain/catalonia"
-	if v := os.Getenv("HOLIDAY_CALENDAR_LINK"); v != "" {
+// Timeout returns how long a call may take. Fetching a calendar for the first time can be slow.
+func (h *HolidaysTool) Timeout() time.Duration {
+	return 20 * time.Second
+}
+
+// CacheTTL is a day, calendars are only published once in a while.
+func (h *HolidaysTool) CacheTTL() time.Duration {
+	return 24 * time.Hour
+}
+
+// Holidays loads the holidays of a country and region from their calendar in the catalog. The default calendar,
+// asked for with neither a country nor a region, can be replaced with HOLIDAY_CALENDAR_LINK.
+func (h *HolidaysTool) Holidays(ctx context.Context, country, region string) ([]Holiday, error) {
+	catalog := h.Catalog
+	if catalog == nil {
+		var err error
+		if catalog, err = defaultHolidays(); err != nil {
+			return nil, err
+		}
+	}
+
+	link, err := catalog.Link(country, region)
+	if err != nil {
+		return nil, err
+	}
+	if v := os.Getenv("HOLIDAY_CALENDAR_LINK"); v != "" && country == "" && region == "" {
 		link = v
 	}
 
-	events, err := LoadCalendar(ctx, link)
+	calendars := h.Calendars
+	if calendars == nil {
+		calendars = DefaultCalendars()
+	}
+
+	holidays, err := calendars.Load(ctx, link)
 	if err != nil {
-		return "failed to load holiday events", nil
+		return nil, fmt.Errorf("failed to load holiday events: %w", err)
 	}
 
-	var payload struct {
-		BeforeDate time.Time `json:"before_date,omitempty"`
-		AfterDate  time.Time `json:"after_date,omitempty"`
-		MaxCount   int       `json:"max_count,omitempty"`
+	return holidays, nil
+}
+
+// Execute retrieves holiday information based on the provided criteria.
+func (h *HolidaysTool) Execute(ctx context.Context, args string) (string, error) {
+	payload, err := h.Decode(args)
+	if err != nil {
+		return "", err
 	}
 
-	if err := json.Unmarshal([]byte(args), &payload); err != nil {
-		return "failed to parse tool call arguments: " + err.Error(), nil
+	all, err := h.Holidays(ctx, payload.Country, payload.Region)
+	if err != nil {
+		return "", err
 	}
 
 	var holidays []string
-	for _, event := range events {
-		date, err := event.GetAllDayStartAt()
-		if err != nil {
+	for _, holiday := range all {
+		if !payload.BeforeDate.IsZero() && holiday.Date.After(payload.BeforeDate) {
 			continue
 		}
 
-		if payload.MaxCount > 0 && len(holidays) >= payload.MaxCount {
-			break
-		}
-
-		if !payload.BeforeDate.IsZero() && date.After(payload.BeforeDate) {
+		if !payload.AfterDate.IsZero() && holiday.Date.Before(payload.AfterDate) {
 			continue
 		}
 
-		if !payload.AfterDate.IsZero() && date.Before(payload.AfterDate) {
-			continue
+		// Holidays are sorted, so the count applies to the earliest ones matching the dates.
+		if payload.MaxCount > 0 && len(holidays) >= payload.MaxCount {
+			break
 		}
 
-		holidays = append(holidays, date.Format(time.DateOnly)+": "+event.GetProperty(ics.ComponentPropertySummary).Value)
+		holidays = append(holidays, holiday.Date.Format(time.DateOnly)+": "+holiday.Name)
 	}
 
 	return strings.Join(holidays, "\n"), nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,193 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHolidaysTool(t *testing.T) {
+	catalog, err := LoadHolidayCatalog("testdata/holidays/catalog.json")
+	if err != nil {
+		t.Fatalf("LoadHolidayCatalog() error = %v", err)
+	}
+	tool := &HolidaysTool{Catalog: catalog, Calendars: NewCalendarStore(t.TempDir(), time.Hour)}
+
+	tests := []struct {
+		name    string
+		args    string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "default region, sorted",
+			args: `{"max_count": 3}`,
+			want: []string{"2026-01-01: New Year's Day", "2026-01-06: Epiphany", "2026-04-06: Easter Monday"},
+		},
+		{
+			name: "national",
+			args: `{"country": "ES", "after_date": "2026-10-01T00:00:00Z"}`,
+			want: []string{"2026-10-12: Hispanic Day", "2026-12-08: Immaculate Conception", "2026-12-25: Christmas Day"},
+		},
+		{
+			name: "count after dates",
+			args: `{"country": "Spain", "region": "Catalonia", "after_date": "2026-09-01T00:00:00Z", "before_date": "2026-12-31T00:00:00Z", "max_count": 2}`,
+			want: []string{"2026-09-11: National Day of Catalonia", "2026-10-12: Hispanic Day"},
+		},
+		{
+			name:    "region of the default country",
+			args:    `{"region": "Madrid"}`,
+			wantErr: `no holidays known for region "Madrid" of Spain, known are catalonia`,
+		},
+		{
+			name:    "unknown country",
+			args:    `{"country": "Atlantis"}`,
+			wantErr: `no holidays known for country "Atlantis", known are France (FR), Spain (ES), United States (US)`,
+		},
+		{
+			name:    "unknown region",
+			args:    `{"country": "es", "region": "Mordor"}`,
+			wantErr: `no holidays known for region "Mordor" of Spain, known are catalonia`,
+		},
+		{
+			name:    "country without regions",
+			args:    `{"country": "fr", "region": "Brittany"}`,
+			wantErr: "no regional holidays known for France (FR), leave out the region",
+		},
+		{
+			name:    "country by region only",
+			args:    `{"country": "United States"}`,
+			wantErr: "holidays of United States are by region, one of new-york",
+		},
+		{
+			name:    "missing calendar",
+			args:    `{"country": "fr"}`,
+			wantErr: "failed to load holiday events",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tool.Execute(context.Background(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if want := strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("Execute() = %q, want %q", got, want)
+			}
+		})
+	}
+
+	t.Run("calendar link override", func(t *testing.T) {
+		t.Setenv("HOLIDAY_CALENDAR_LINK", "testdata/holidays/spain.ics")
+
+		// Only the default calendar is replaced, not the one of a region asked for.
+		for args, want := range map[string]int{`{}`: 6, `{"region": "Catalonia"}`: 9} {
+			got, err := tool.Execute(context.Background(), args)
+			if n := strings.Count(got, "\n") + 1; err != nil || n != want {
+				t.Errorf("Execute(%s) = %d holidays, %v, want %d", args, n, err, want)
+			}
+		}
+	})
+}
+
+func TestLoadHolidayCatalog(t *testing.T) {
+	catalog, err := LoadHolidayCatalog("")
+	if err != nil {
+		t.Fatalf("LoadHolidayCatalog() error = %v", err)
+	}
+
+	// The default calendar is the one the tool was first written for.
+	if link, err := catalog.Link("", ""); err != nil || link != "https://www.officeholidays.com/ics/spain/catalonia" {
+		t.Errorf("Link() = %q, %v, want the calendar of Catalonia", link, err)
+	}
+
+	if link, err := catalog.Link("", "Madrid"); err != nil || link != "https://www.officeholidays.com/ics/spain/madrid" {
+		t.Errorf("Link() = %q, %v, want the calendar of Madrid", link, err)
+	}
+
+	if link, err := catalog.Link("Spain", "Balearic Islands"); err != nil || !strings.HasSuffix(link, "/spain/balearic-islands") {
+		t.Errorf("Link() = %q, %v, want the calendar of the Balearic Islands", link, err)
+	}
+
+	if _, err := LoadHolidayCatalog("testdata/eurofxref-hist.xml"); err == nil {
+		t.Error("LoadHolidayCatalog() of an invalid catalog succeeded")
+	}
+}
+
+func TestCalendarStore(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/holidays/spain.ics")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var requests atomic.Int32
+	var failing atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(fixture)
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := NewCalendarStore(dir, time.Hour)
+	link := srv.URL + "/ics/spain"
+
+	// Calendars are fetched once, then read from disk.
+	for range 3 {
+		holidays, err := store.Load(ctx, link)
+		if err != nil || len(holidays) != 6 || holidays[0].Name != "New Year's Day" {
+			t.Fatalf("Load() = %v, %v, want the 6 holidays sorted", holidays, err)
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("calendar fetched %d times, want once", n)
+	}
+
+	// Calendars younger than the maximum age are not refreshed.
+	if err := store.Refresh(ctx); err != nil || requests.Load() != 1 {
+		t.Errorf("Refresh() = %v after %d requests, want no request", err, requests.Load())
+	}
+
+	// Calendars are kept on disk across stores, and refreshed once stale.
+	stale := NewCalendarStore(dir, 0)
+	if err := stale.Refresh(ctx); err != nil || requests.Load() != 2 {
+		t.Errorf("Refresh() = %v after %d requests, want the calendar fetched again", err, requests.Load())
+	}
+
+	// Calendars failing to refresh are still served.
+	failing.Store(true)
+	if err := stale.Refresh(ctx); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("Refresh() error = %v, want the failure", err)
+	}
+	if holidays, err := stale.Load(ctx, link); err != nil || len(holidays) != 6 {
+		t.Errorf("Load() = %v, %v, want the calendar on disk", holidays, err)
+	}
+
+	if _, err := store.Load(ctx, srv.URL+"/ics/france"); err == nil {
+		t.Error("Load() of an unavailable calendar succeeded")
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	if len(files) != 1 {
+		t.Errorf("files on disk = %v, want the calendar only", files)
+	}
+}
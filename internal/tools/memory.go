@@ -0,0 +1,314 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Bounds of what is remembered about a user, so memories stay facts rather than documents, and fit in prompts.
+const (
+	maxMemories      = 100
+	maxMemoryLength  = 300
+	maxRecalledItems = 20
+)
+
+// Memory is a fact remembered about a user, e.g. their home city or dietary needs.
+type Memory struct {
+	ID             string
+	Content        string
+	ConversationID string // The conversation it was remembered in, if any.
+	CreatedAt      time.Time
+}
+
+// MemoryStore persists the memories of users, each only ever visible to its owner.
+type MemoryStore interface {
+	// CreateMemory stores the memory and returns its ID.
+	CreateMemory(ctx context.Context, ownerID string, m Memory) (string, error)
+	// ListMemories returns all the owner's memories, oldest first.
+	ListMemories(ctx context.Context, ownerID string) ([]Memory, error)
+	DeleteMemory(ctx context.Context, ownerID, id string) error
+}
+
+// RememberTool stores a fact about the user, for the assistant to know in their later conversations.
+type RememberTool struct {
+	Args[rememberArgs]
+
+	Store MemoryStore
+	// UserID returns the user the tool is called for.
+	UserID func(context.Context) string
+
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+type rememberArgs struct {
+	Content string `json:"content" description:"The fact to remember, a short sentence about the user, e.g. 'Lives in Barcelona' or 'Prefers metric units'"`
+}
+
+// Name returns the tool's identifier.
+func (r *RememberTool) Name() string {
+	return "remember"
+}
+
+// Description returns what the tool does.
+func (r *RememberTool) Description() string {
+	return "Remember a lasting fact about the user across conversations, e.g. their home city, preferred units or " +
+		"dietary needs, when they share it or ask you to remember it. Do not remember one-off details of a trip."
+}
+
+// CacheTTL is 0, calls change what is remembered.
+func (r *RememberTool) CacheTTL() time.Duration {
+	return 0
+}
+
+// Execute stores the fact, unless it is already remembered.
+func (r *RememberTool) Execute(ctx context.Context, args string) (string, error) {
+	payload, err := r.Decode(args)
+	if err != nil {
+		return "", err
+	}
+
+	content := strings.Join(strings.Fields(payload.Content), " ")
+	switch {
+	case content == "":
+		return "", errors.New("content is empty")
+	case len(content) > maxMemoryLength:
+		return "", fmt.Errorf("content is too long, remember facts of at most %d characters", maxMemoryLength)
+	}
+
+	owner := r.UserID(ctx)
+	memories, err := r.Store.ListMemories(ctx, owner)
+	if err != nil {
+		return "", err
+	}
+
+	for _, m := range memories {
+		if strings.EqualFold(m.Content, content) {
+			return fmt.Sprintf("Already remembered [%s]: %s", m.ID, m.Content), nil
+		}
+	}
+
+	if len(memories) >= maxMemories {
+		return "", fmt.Errorf("%d facts are remembered already, forget some first", len(memories))
+	}
+
+	now := time.Now
+	if r.Now != nil {
+		now = r.Now
+	}
+
+	id, err := r.Store.CreateMemory(ctx, owner, Memory{Content: content, ConversationID: ConversationID(ctx), CreatedAt: now()})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Remembered [%s]: %s", id, content), nil
+}
+
+// ForgetTool removes a fact remembered about the user.
+type ForgetTool struct {
+	Args[forgetArgs]
+
+	Store MemoryStore
+	// UserID returns the user the tool is called for.
+	UserID func(context.Context) string
+}
+
+type forgetArgs struct {
+	Memory string `json:"memory" description:"The ID of the memory to forget, or words it contains, e.g. 'Barcelona'"`
+}
+
+// Name returns the tool's identifier.
+func (f *ForgetTool) Name() string {
+	return "forget"
+}
+
+// Description returns what the tool does.
+func (f *ForgetTool) Description() string {
+	return "Forget a fact remembered about the user, when it no longer holds or they ask you to forget it. " +
+		"When several memories match, none is forgotten and they are listed with their IDs."
+}
+
+// CacheTTL is 0, calls change what is remembered.
+func (f *ForgetTool) CacheTTL() time.Duration {
+	return 0
+}
+
+// Execute removes the memory with the ID, or the only one containing all the words.
+func (f *ForgetTool) Execute(ctx context.Context, args string) (string, error) {
+	payload, err := f.Decode(args)
+	if err != nil {
+		return "", err
+	}
+
+	query := strings.TrimSpace(payload.Memory)
+	if query == "" {
+		return "", errors.New("memory is empty")
+	}
+
+	owner := f.UserID(ctx)
+	memories, err := f.Store.ListMemories(ctx, owner)
+	if err != nil {
+		return "", err
+	}
+
+	var matches []Memory
+	for _, m := range memories {
+		if m.ID == query {
+			matches = []Memory{m}
+			break
+		}
+		if containsAll(words(m.Content), words(query)) {
+			matches = append(matches, m)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no memory matches %q", query)
+	case 1:
+	default:
+		return "", fmt.Errorf("%d memories match %q, forget one by ID:\n%s", len(matches), query, listMemories(matches))
+	}
+
+	if err := f.Store.DeleteMemory(ctx, owner, matches[0].ID); err != nil {
+		return "", err
+	}
+
+	return "Forgot: " + matches[0].Content, nil
+}
+
+// RecallTool lists the facts remembered about the user.
+type RecallTool struct {
+	Args[recallArgs]
+
+	Store MemoryStore
+	// UserID returns the user the tool is called for.
+	UserID func(context.Context) string
+}
+
+type recallArgs struct {
+	Query string `json:"query,omitempty" description:"Optional words to look for, e.g. 'diet'. The most recent memories are listed if not provided."`
+}
+
+// Name returns the tool's identifier.
+func (r *RecallTool) Name() string {
+	return "recall"
+}
+
+// Description returns what the tool does.
+func (r *RecallTool) Description() string {
+	return "Recall the facts remembered about the user in earlier conversations, with their IDs, " +
+		"beyond the ones already given in the instructions"
+}
+
+// CacheTTL is 0, memories belong to the user and change within a conversation.
+func (r *RecallTool) CacheTTL() time.Duration {
+	return 0
+}
+
+// Execute lists the memories matching the query.
+func (r *RecallTool) Execute(ctx context.Context, args string) (string, error) {
+	payload, err := r.Decode(args)
+	if err != nil {
+		return "", err
+	}
+
+	memories, err := r.Store.ListMemories(ctx, r.UserID(ctx))
+	if err != nil {
+		return "", err
+	}
+
+	query := strings.TrimSpace(payload.Query)
+	if query == "" {
+		if len(memories) == 0 {
+			return "Nothing is remembered about the user yet.", nil
+		}
+		return listMemories(memories[max(len(memories)-maxRecalledItems, 0):]), nil
+	}
+
+	memories = slices.DeleteFunc(RelevantMemories(memories, query, maxRecalledItems), func(m Memory) bool {
+		return score(m, words(query)) == 0
+	})
+
+	if len(memories) == 0 {
+		return fmt.Sprintf("No memory matches %q.", query), nil
+	}
+
+	return listMemories(memories), nil
+}
+
+// RelevantMemories returns the limit memories sharing the most words with the text, the most recent ones among
+// equally relevant ones, or all of them when there are no more than limit. They are returned oldest first.
+func RelevantMemories(memories []Memory, text string, limit int) []Memory {
+	if len(memories) <= limit {
+		return memories
+	}
+
+	query := words(text)
+	ranked := slices.Clone(memories)
+	slices.SortStableFunc(ranked, func(a, b Memory) int {
+		return cmp.Or(cmp.Compare(score(b, query), score(a, query)), b.CreatedAt.Compare(a.CreatedAt))
+	})
+
+	ranked = ranked[:limit]
+	slices.SortStableFunc(ranked, func(a, b Memory) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return ranked
+}
+
+// score returns the number of the query words the memory contains.
+func score(m Memory, query []string) int {
+	content := words(m.Content)
+
+	n := 0
+	for _, w := range query {
+		if slices.Contains(content, w) {
+			n++
+		}
+	}
+	return n
+}
+
+// words returns the distinct words of the text, folded to lowercase and without accents, except for words of
+// less than 3 letters, mostly not worth matching on.
+func words(text string) []string {
+	var out []string
+	for _, w := range strings.FieldsFunc(fold(text), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) }) {
+		if len([]rune(w)) >= 3 && !slices.Contains(out, w) {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+// containsAll reports whether every word of want is in words, and want is not empty.
+func containsAll(words, want []string) bool {
+	if len(want) == 0 {
+		return false
+	}
+
+	for _, w := range want {
+		if !slices.Contains(words, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// listMemories lists the memories one per line, with their IDs and dates.
+func listMemories(memories []Memory) string {
+	lines := make([]string, len(memories))
+	for i, m := range memories {
+		lines[i] = fmt.Sprintf("- [%s] %s (remembered on %s)", m.ID, m.Content, m.CreatedAt.Format(time.DateOnly))
+	}
+	return strings.Join(lines, "\n")
+}
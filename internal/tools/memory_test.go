@@ -0,0 +1,183 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// memoryStore is a MemoryStore keeping memories in a map, by owner.
+type memoryStore map[string][]Memory
+
+func (s memoryStore) CreateMemory(ctx context.Context, ownerID string, m Memory) (string, error) {
+	m.ID = fmt.Sprintf("%s-%d", ownerID, m.CreatedAt.Unix())
+	s[ownerID] = append(s[ownerID], m)
+	return m.ID, nil
+}
+
+func (s memoryStore) ListMemories(ctx context.Context, ownerID string) ([]Memory, error) {
+	return slices.Clone(s[ownerID]), nil
+}
+
+func (s memoryStore) DeleteMemory(ctx context.Context, ownerID, id string) error {
+	for i, m := range s[ownerID] {
+		if m.ID == id {
+			s[ownerID] = append(s[ownerID][:i:i], s[ownerID][i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("memory %s not found", id)
+}
+
+type userKey struct{}
+
+func withUser(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, userKey{}, id)
+}
+
+func userID(ctx context.Context) string {
+	id, _ := ctx.Value(userKey{}).(string)
+	return id
+}
+
+func TestMemoryTools(t *testing.T) {
+	store := memoryStore{}
+	ctx := WithConversation(withUser(context.Background(), "alice"), "conv-1")
+
+	day := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	remember := &RememberTool{Store: store, UserID: userID, Now: func() time.Time { day = day.Add(time.Minute); return day }}
+	forget := &ForgetTool{Store: store, UserID: userID}
+	recall := &RecallTool{Store: store, UserID: userID}
+
+	contents := func(owner string) []string {
+		t.Helper()
+
+		memories, err := store.ListMemories(context.Background(), owner)
+		if err != nil {
+			t.Fatalf("ListMemories() error = %v", err)
+		}
+
+		var out []string
+		for _, m := range memories {
+			out = append(out, m.Content)
+		}
+		return out
+	}
+
+	for _, fact := range []string{"Lives in  Barcelona", "Is vegetarian", "Prefers aisle seats", "Flies from Barcelona-El Prat"} {
+		got, err := remember.Execute(ctx, fmt.Sprintf(`{"content": %q}`, fact))
+		if err != nil {
+			t.Fatalf("remember %q: error = %v", fact, err)
+		}
+		if !strings.HasPrefix(got, "Remembered [") {
+			t.Errorf("remember %q = %q", fact, got)
+		}
+	}
+
+	t.Run("remember", func(t *testing.T) {
+		got, err := remember.Execute(ctx, `{"content": "is VEGETARIAN"}`)
+		if err != nil || !strings.HasPrefix(got, "Already remembered [") {
+			t.Errorf("remember a known fact = %q, %v", got, err)
+		}
+
+		if _, err := remember.Execute(ctx, `{"content": " "}`); err == nil {
+			t.Error("remember an empty fact succeeded")
+		}
+		if _, err := remember.Execute(ctx, fmt.Sprintf(`{"content": %q}`, strings.Repeat("a", maxMemoryLength+1))); err == nil {
+			t.Error("remember a long fact succeeded")
+		}
+
+		want := []string{"Lives in Barcelona", "Is vegetarian", "Prefers aisle seats", "Flies from Barcelona-El Prat"}
+		if diff := cmp.Diff(want, contents("alice")); diff != "" {
+			t.Errorf("memories mismatch (-want +got):\n%s", diff)
+		}
+
+		memories, _ := store.ListMemories(context.Background(), "alice")
+		if m := memories[0]; m.ConversationID != "conv-1" || !m.CreatedAt.Equal(time.Date(2026, 10, 16, 9, 1, 0, 0, time.UTC)) {
+			t.Errorf("unexpected memory: %+v", m)
+		}
+	})
+
+	t.Run("recall", func(t *testing.T) {
+		got, err := recall.Execute(ctx, `{"query": "diet, vegetarian"}`)
+		if err != nil {
+			t.Fatalf("recall error = %v", err)
+		}
+		if !strings.Contains(got, "] Is vegetarian (remembered on 2026-10-16)") || strings.Count(got, "\n") != 0 {
+			t.Errorf("recall = %q", got)
+		}
+
+		if got, _ := recall.Execute(ctx, `{}`); strings.Count(got, "\n") != 3 {
+			t.Errorf("recall everything = %q", got)
+		}
+		if got, _ := recall.Execute(ctx, `{"query": "passport"}`); got != `No memory matches "passport".` {
+			t.Errorf("recall without matches = %q", got)
+		}
+		if got, _ := recall.Execute(withUser(context.Background(), "bob"), `{}`); got != "Nothing is remembered about the user yet." {
+			t.Errorf("recall of another user = %q", got)
+		}
+	})
+
+	t.Run("forget", func(t *testing.T) {
+		if _, err := forget.Execute(ctx, `{"memory": "barcelona"}`); err == nil || !strings.Contains(err.Error(), "2 memories match") {
+			t.Errorf("forget an ambiguous memory: error = %v", err)
+		}
+		if _, err := forget.Execute(ctx, `{"memory": "passport"}`); err == nil {
+			t.Error("forget an unknown memory succeeded")
+		}
+		if _, err := forget.Execute(withUser(context.Background(), "bob"), `{"memory": "vegetarian"}`); err == nil {
+			t.Error("forget a memory of another user succeeded")
+		}
+
+		got, err := forget.Execute(ctx, `{"memory": "Lives Barcelona"}`)
+		if err != nil || got != "Forgot: Lives in Barcelona" {
+			t.Errorf("forget = %q, %v", got, err)
+		}
+
+		memories, _ := store.ListMemories(context.Background(), "alice")
+		if got, err := forget.Execute(ctx, fmt.Sprintf(`{"memory": %q}`, memories[0].ID)); err != nil || got != "Forgot: Is vegetarian" {
+			t.Errorf("forget by ID = %q, %v", got, err)
+		}
+
+		want := []string{"Prefers aisle seats", "Flies from Barcelona-El Prat"}
+		if diff := cmp.Diff(want, contents("alice")); diff != "" {
+			t.Errorf("memories mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestRelevantMemories(t *testing.T) {
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	var memories []Memory
+	for i, content := range []string{"Lives in Barcelona", "Is vegetarian", "Prefers aisle seats", "Has a dog named Café", "Prefers metric units"} {
+		memories = append(memories, Memory{ID: fmt.Sprint(i), Content: content, CreatedAt: day.AddDate(0, 0, i)})
+	}
+
+	tests := []struct {
+		text  string
+		limit int
+		want  []string
+	}{
+		{text: "anything", limit: 5, want: []string{"Lives in Barcelona", "Is vegetarian", "Prefers aisle seats", "Has a dog named Café", "Prefers metric units"}},
+		{text: "Find a vegetarian restaurant in Barcelona", limit: 2, want: []string{"Lives in Barcelona", "Is vegetarian"}},
+		{text: "Is there a cafe where my dog is welcome?", limit: 1, want: []string{"Has a dog named Café"}},
+		{text: "Hello!", limit: 2, want: []string{"Has a dog named Café", "Prefers metric units"}},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, m := range RelevantMemories(memories, tt.text, tt.limit) {
+			got = append(got, m.Content)
+		}
+
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("RelevantMemories(%q, %d) mismatch (-want +got):\n%s", tt.text, tt.limit, diff)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// OpenMeteo provides the weather of Open-Meteo, which needs no API key. Locations are looked up with its
+// geocoding API, unless they are coordinates.
+type OpenMeteo struct {
+	// Client defaults to a client shared by weather providers.
+	Client *http.Client
+	// GeocodingURL defaults to https://geocoding-api.open-meteo.com/v1/search.
+	GeocodingURL string
+	// ForecastURL defaults to https://api.open-meteo.com/v1/forecast.
+	ForecastURL string
+}
+
+type openMeteoForecast struct {
+	Timezone string `json:"timezone"`
+	Current  struct {
+		Time                string  `json:"time"`
+		Temperature2m       float64 `json:"temperature_2m"`
+		ApparentTemperature float64 `json:"apparent_temperature"`
+		RelativeHumidity2m  int     `json:"relative_humidity_2m"`
+		WeatherCode         int     `json:"weather_code"`
+		WindSpeed10m        float64 `json:"wind_speed_10m"`
+	} `json:"current"`
+	Daily struct {
+		Time                        []string  `json:"time"`
+		WeatherCode                 []int     `json:"weather_code"`
+		Temperature2mMax            []float64 `json:"temperature_2m_max"`
+		Temperature2mMin            []float64 `json:"temperature_2m_min"`
+		PrecipitationSum            []float64 `json:"precipitation_sum"`
+		PrecipitationProbabilityMax []int     `json:"precipitation_probability_max"`
+	} `json:"daily"`
+	Hourly struct {
+		Time                     []string  `json:"time"`
+		WeatherCode              []int     `json:"weather_code"`
+		Temperature2m            []float64 `json:"temperature_2m"`
+		Precipitation            []float64 `json:"precipitation"`
+		PrecipitationProbability []int     `json:"precipitation_probability"`
+		WindSpeed10m             []float64 `json:"wind_speed_10m"`
+	} `json:"hourly"`
+}
+
+// Weather looks up the location, then fetches its weather.
+func (p *OpenMeteo) Weather(ctx context.Context, query WeatherQuery) (*Weather, error) {
+	location, err := p.locate(ctx, query.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Set("latitude", strconv.FormatFloat(location.Latitude, 'f', -1, 64))
+	q.Set("longitude", strconv.FormatFloat(location.Longitude, 'f', -1, 64))
+	q.Set("timezone", "auto")
+	q.Set("current", "temperature_2m,apparent_temperature,relative_humidity_2m,weather_code,wind_speed_10m")
+	if query.Days > 0 {
+		q.Set("daily", "weather_code,temperature_2m_max,temperature_2m_min,precipitation_sum,precipitation_probability_max")
+		q.Set("forecast_days", strconv.Itoa(query.Days))
+	}
+	if query.Hours > 0 {
+		q.Set("hourly", "weather_code,temperature_2m,precipitation,precipitation_probability,wind_speed_10m")
+		q.Set("forecast_hours", strconv.Itoa(query.Hours))
+	}
+
+	var data openMeteoForecast
+	if err := p.get(ctx, p.ForecastURL, "https://api.open-meteo.com/v1/forecast", q, &data); err != nil {
+		return nil, err
+	}
+
+	location.Timezone = data.Timezone
+	weather := &Weather{
+		Location: location,
+		Current: WeatherConditions{
+			Time:        data.Current.Time,
+			Condition:   weatherCode(data.Current.WeatherCode),
+			Temperature: data.Current.Temperature2m,
+			FeelsLike:   data.Current.ApparentTemperature,
+			WindSpeed:   data.Current.WindSpeed10m,
+			Humidity:    data.Current.RelativeHumidity2m,
+		},
+	}
+
+	// Values come as columns, one entry per day or hour.
+	d := data.Daily
+	for i := range min(len(d.Time), len(d.WeatherCode), len(d.Temperature2mMax), len(d.Temperature2mMin), len(d.PrecipitationSum), len(d.PrecipitationProbabilityMax)) {
+		weather.Daily = append(weather.Daily, DailyForecast{
+			Date:                     d.Time[i],
+			Condition:                weatherCode(d.WeatherCode[i]),
+			TemperatureMin:           d.Temperature2mMin[i],
+			TemperatureMax:           d.Temperature2mMax[i],
+			Precipitation:            d.PrecipitationSum[i],
+			PrecipitationProbability: d.PrecipitationProbabilityMax[i],
+		})
+	}
+
+	h := data.Hourly
+	for i := range min(len(h.Time), len(h.WeatherCode), len(h.Temperature2m), len(h.Precipitation), len(h.PrecipitationProbability), len(h.WindSpeed10m)) {
+		weather.Hourly = append(weather.Hourly, HourlyForecast{
+			Time:                     h.Time[i],
+			Condition:                weatherCode(h.WeatherCode[i]),
+			Temperature:              h.Temperature2m[i],
+			Precipitation:            h.Precipitation[i],
+			PrecipitationProbability: h.PrecipitationProbability[i],
+			WindSpeed:                h.WindSpeed10m[i],
+		})
+	}
+
+	return weather, nil
+}
+
+// locate returns the location of coordinates, or the best match of a place name.
+func (p *OpenMeteo) locate(ctx context.Context, query string) (WeatherLocation, error) {
+	if lat, lon, ok := strings.Cut(query, ","); ok {
+		latitude, err1 := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+		longitude, err2 := strconv.ParseFloat(strings.TrimSpace(lon), 64)
+		if err1 == nil && err2 == nil {
+			return WeatherLocation{Name: query, Latitude: latitude, Longitude: longitude}, nil
+		}
+	}
+
+	// The geocoding API matches place names only, e.g. "Paris" but not "Paris, France".
+	name, country, _ := strings.Cut(query, ",")
+	country = strings.TrimSpace(country)
+
+	q := url.Values{}
+	q.Set("name", strings.TrimSpace(name))
+	q.Set("count", "10")
+	q.Set("format", "json")
+
+	var data struct {
+		Results []struct {
+			Name        string  `json:"name"`
+			Latitude    float64 `json:"latitude"`
+			Longitude   float64 `json:"longitude"`
+			Country     string  `json:"country"`
+			CountryCode string  `json:"country_code"`
+			Admin1      string  `json:"admin1"`
+		} `json:"results"`
+	}
+	if err := p.get(ctx, p.GeocodingURL, "https://geocoding-api.open-meteo.com/v1/search", q, &data); err != nil {
+		return WeatherLocation{}, err
+	}
+
+	// Results are sorted by relevance, the first one in the country asked for is the best match.
+	for _, r := range data.Results {
+		if country == "" || strings.EqualFold(country, r.Country) || strings.EqualFold(country, r.CountryCode) || strings.EqualFold(country, r.Admin1) {
+			return WeatherLocation{Name: r.Name, Region: r.Admin1, Country: r.Country, Latitude: r.Latitude, Longitude: r.Longitude}, nil
+		}
+	}
+
+	return WeatherLocation{}, ErrUnknownLocation
+}
+
+func (p *OpenMeteo) get(ctx context.Context, link, fallback string, query url.Values, v any) error {
+	if link == "" {
+		link = fallback
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	client := p.Client
+	if client == nil {
+		client = weatherClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("weather API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var failure struct {
+			Reason string `json:"reason"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&failure)
+		return fmt.Errorf("weather API request failed: %s %s", resp.Status, failure.Reason)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse weather response: %w", err)
+	}
+	return nil
+}
+
+// weatherCodes describe the WMO weather interpretation codes of Open-Meteo.
+var weatherCodes = map[int]string{
+	0:  "Clear sky",
+	1:  "Mainly clear",
+	2:  "Partly cloudy",
+	3:  "Overcast",
+	45: "Fog",
+	48: "Depositing rime fog",
+	51: "Light drizzle",
+	53: "Moderate drizzle",
+	55: "Dense drizzle",
+	56: "Light freezing drizzle",
+	57: "Dense freezing drizzle",
+	61: "Slight rain",
+	63: "Moderate rain",
+	65: "Heavy rain",
+	66: "Light freezing rain",
+	67: "Heavy freezing rain",
+	71: "Slight snow fall",
+	73: "Moderate snow fall",
+	75: "Heavy snow fall",
+	77: "Snow grains",
+	80: "Slight rain showers",
+	81: "Moderate rain showers",
+	82: "Violent rain showers",
+	85: "Slight snow showers",
+	86: "Heavy snow showers",
+	95: "Thunderstorm",
+	96: "Thunderstorm with slight hail",
+	99: "Thunderstorm with heavy hail",
+}
+
+func weatherCode(code int) string {
+	if text, ok := weatherCodes[code]; ok {
+		return text
+	}
+	return fmt.Sprintf("Unknown (WMO code %d)", code)
+}
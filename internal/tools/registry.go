@@ -3,19 +3,39 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
-	"github.com/openai/openai-go/v2"
+	"github.com/acai-travel/tech-challenge/internal/llm"
 )
 
 // Tool defines the interface that all assistant tools must implement.
 type Tool interface {
 	Name() string
 	Description() string
-	Parameters() openai.FunctionParameters
+	Parameters() *Schema
 	Execute(ctx context.Context, args string) (string, error)
 }
 
+// DefaultTimeout bounds the calls of tools that do not declare their own timeout.
+const DefaultTimeout = 30 * time.Second
+
+// TimeoutTool is implemented by tools declaring how long a call may take, instead of DefaultTimeout.
+type TimeoutTool interface {
+	Timeout() time.Duration
+}
+
+// timeout returns how long a call to the tool may take.
+func timeout(tool Tool) time.Duration {
+	if t, ok := tool.(TimeoutTool); ok && t.Timeout() > 0 {
+		return t.Timeout()
+	}
+	return DefaultTimeout
+}
+
 // Registry manages the collection of available tools for the assistant.
 type Registry struct {
 	tools map[string]Tool
@@ -24,41 +44,141 @@ type Registry struct {
 // NewRegistry creates a new tool registry with all available tools registered.
 func NewRegistry() *Registry {
 	r := &Registry{tools: make(map[string]Tool)}
-	
+
 	// Register all available tools.
 	r.Register(&WeatherTool{})
 	r.Register(&DateTool{})
+	r.Register(&TimeConversionTool{})
+	r.Register(&DateCalculatorTool{})
 	r.Register(&HolidaysTool{})
 	r.Register(&CalculatorTool{})
-	
+	r.Register(&CurrencyTool{})
+	r.Register(&AirportTool{})
+	r.Register(&DistanceTool{})
+	r.Register(&FlightStatusTool{})
+
 	return r
 }
 
 // Register adds a tool to the registry.
 func (r *Registry) Register(tool Tool) {
+	if r.tools == nil {
+		r.tools = make(map[string]Tool)
+	}
 	r.tools[tool.Name()] = tool
 }
 
-// GetTools returns all tools in OpenAI function format.
-func (r *Registry) GetTools() []openai.ChatCompletionToolUnionParam {
-	var tools []openai.ChatCompletionToolUnionParam
-	
+// GetTools returns all tools as model tool definitions, sorted by name so requests are deterministic.
+func (r *Registry) GetTools() []llm.Tool {
+	var tools []llm.Tool
+
 	for _, tool := range r.tools {
-		tools = append(tools, openai.ChatCompletionFunctionTool(openai.FunctionDefinitionParam{
+		tools = append(tools, llm.Tool{
 			Name:        tool.Name(),
-			Description: openai.String(tool.Description()),
+			Description: tool.Description(),
 			Parameters:  tool.Parameters(),
-		}))
+		})
 	}
-	
+
+	slices.SortFunc(tools, func(a, b llm.Tool) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
 	return tools
 }
 
-// Execute runs the specified tool with the given arguments.
+// Names returns the names of the registered tools, sorted.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.tools))
+	for name := range r.tools {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+	return names
+}
+
+// Select returns a registry with the tools named in enabled, or all of them when it is empty, except the ones
+// named in disabled. Names of tools that are not registered are ignored. Tools left out are neither advertised
+// nor executed by the returned registry.
+func (r *Registry) Select(enabled, disabled []string) *Registry {
+	selected := &Registry{tools: make(map[string]Tool)}
+
+	for name, tool := range r.tools {
+		if (len(enabled) == 0 || slices.Contains(enabled, name)) && !slices.Contains(disabled, name) {
+			selected.tools[name] = tool
+		}
+	}
+
+	return selected
+}
+
+// WithCache returns a registry with the same tools, their results cached in cache, see Cached.
+func (r *Registry) WithCache(cache Cache) *Registry {
+	cached := &Registry{tools: make(map[string]Tool)}
+	for name, tool := range r.tools {
+		cached.tools[name] = Cached(tool, cache)
+	}
+
+	return cached
+}
+
+// Execute runs the specified tool with the given arguments, once validated against the tool's parameters, under
+// the tool's timeout. It reports failures as a *ToolError. Calls still running when the context is done are
+// reported as timed out or canceled right away, without waiting for tools ignoring their context.
 func (r *Registry) Execute(ctx context.Context, name, args string) (string, error) {
 	tool, exists := r.tools[name]
 	if !exists {
-		return "", fmt.Errorf("unknown tool: %s", name)
+		return "", &ToolError{Tool: name, Code: CodeUnknownTool, Message: fmt.Sprintf("unknown tool: %s", name)}
+	}
+
+	if issues := tool.Parameters().Validate(args); len(issues) > 0 {
+		return "", &ToolError{Tool: name, Code: CodeInvalidArguments, Message: "invalid arguments", Issues: issues}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return "", canceled(name, err)
+	}
+
+	parent := ctx
+	limit := timeout(tool)
+
+	ctx, cancel := context.WithTimeout(ctx, limit)
+	defer cancel()
+
+	type outcome struct {
+		result string
+		err    error
 	}
-	return tool.Execute(ctx, args)
-}
\ No newline at end of file
+
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := tool.Execute(ctx, args)
+		done <- outcome{result, err}
+	}()
+
+	var out outcome
+	select {
+	case out = <-done:
+	case <-ctx.Done():
+		out.err = ctx.Err()
+	}
+
+	switch {
+	case out.err == nil:
+		return out.result, nil
+	case parent.Err() != nil:
+		return "", canceled(name, parent.Err())
+	case ctx.Err() != nil:
+		return "", &ToolError{Tool: name, Code: CodeTimeout, Message: fmt.Sprintf("call timed out after %s", limit), err: ctx.Err()}
+	}
+
+	if terr := (*ToolError)(nil); errors.As(out.err, &terr) {
+		return "", terr
+	}
+	return "", &ToolError{Tool: name, Code: CodeExecutionFailed, Message: out.err.Error(), err: out.err}
+}
+
+func canceled(name string, err error) *ToolError {
+	return &ToolError{Tool: name, Code: CodeCanceled, Message: "call canceled", err: err}
+}
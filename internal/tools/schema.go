@@ -0,0 +1,206 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Schema is a provider-neutral description of a tool's JSON arguments, a subset of JSON Schema that every
+// model provider understands.
+type Schema struct {
+	Type        string             // "object", "string", "number", "integer", "boolean" or "array", empty for any value.
+	Description string             // What the value means, shown to the model.
+	Properties  map[string]*Schema // Fields of an object.
+	Required    []string           // Fields of an object that must be present.
+	Items       *Schema            // Element schema of an array.
+	Enum        []string           // Allowed values of a string.
+	Format      string             // Format of a string, e.g. "date-time".
+}
+
+// MarshalJSON encodes the schema as JSON Schema. Objects always include their properties, even when empty,
+// since some providers reject object schemas without them.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	out := map[string]any{}
+
+	if s.Type != "" {
+		out["type"] = s.Type
+	}
+
+	if s.Description != "" {
+		out["description"] = s.Description
+	}
+
+	if s.Type == "object" {
+		properties := s.Properties
+		if properties == nil {
+			properties = map[string]*Schema{}
+		}
+		out["properties"] = properties
+	}
+
+	if len(s.Required) > 0 {
+		out["required"] = s.Required
+	}
+
+	if s.Items != nil {
+		out["items"] = s.Items
+	}
+
+	if len(s.Enum) > 0 {
+		out["enum"] = s.Enum
+	}
+
+	if s.Format != "" {
+		out["format"] = s.Format
+	}
+
+	return json.Marshal(out)
+}
+
+// Issue is a way tool arguments do not match their schema.
+type Issue struct {
+	Path    string `json:"path,omitempty"` // Field at fault, e.g. "items[0].name", empty for the arguments as a whole.
+	Message string `json:"message"`
+}
+
+func (i Issue) String() string {
+	subject := i.Path
+	if subject == "" {
+		subject = "arguments"
+	}
+
+	return subject + " " + i.Message
+}
+
+// Validate checks the JSON arguments of a call against the schema: types, required fields, enums and the
+// "date-time" and "date" string formats. Null fields count as missing. Blank arguments are an empty object, as
+// sent by some models for tools without parameters.
+func (s *Schema) Validate(args string) []Issue {
+	if strings.TrimSpace(args) == "" {
+		args = "{}"
+	}
+
+	dec := json.NewDecoder(strings.NewReader(args))
+	dec.UseNumber()
+
+	var value any
+	if err := dec.Decode(&value); err != nil || dec.More() {
+		return []Issue{{Message: "must be a valid JSON object"}}
+	}
+
+	var issues []Issue
+	s.validate("", value, &issues)
+	return issues
+}
+
+func (s *Schema) validate(path string, value any, issues *[]Issue) {
+	report := func(format string, args ...any) {
+		*issues = append(*issues, Issue{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			report("must be an object, got %s", jsonType(value))
+			return
+		}
+
+		for _, name := range s.Required {
+			if object[name] == nil {
+				*issues = append(*issues, Issue{Path: joinPath(path, name), Message: "is required"})
+			}
+		}
+
+		// Properties are checked in name order, so issues are reported in a stable order.
+		names := make([]string, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		for _, name := range names {
+			if v := object[name]; v != nil {
+				s.Properties[name].validate(joinPath(path, name), v, issues)
+			}
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			report("must be an array, got %s", jsonType(value))
+			return
+		}
+
+		if s.Items != nil {
+			for i, item := range items {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, issues)
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			report("must be a string, got %s", jsonType(value))
+			return
+		}
+
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, str) {
+			report("must be one of %s, got %q", strings.Join(s.Enum, ", "), str)
+		}
+
+		switch s.Format {
+		case "date-time":
+			if _, err := time.Parse(time.RFC3339, str); err != nil {
+				report("must be a date-time in RFC 3339 format, e.g. 2025-08-20T10:00:00Z, got %q", str)
+			}
+		case "date":
+			if _, err := time.Parse(time.DateOnly, str); err != nil {
+				report("must be a date in YYYY-MM-DD format, got %q", str)
+			}
+		}
+	case "number", "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			report("must be a %s, got %s", s.Type, jsonType(value))
+			return
+		}
+
+		if f, err := n.Float64(); s.Type == "integer" && (err != nil || f != math.Trunc(f)) {
+			report("must be an integer, got %s", n)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			report("must be a boolean, got %s", jsonType(value))
+		}
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// jsonType names the JSON type of a decoded value.
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
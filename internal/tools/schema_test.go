@@ -0,0 +1,206 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSchema_Validate(t *testing.T) {
+	schema := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"operation": {Type: "string", Enum: []string{"add", "subtract"}},
+			"a":         {Type: "number"},
+			"count":     {Type: "integer"},
+			"verbose":   {Type: "boolean"},
+			"at":        {Type: "string", Format: "date-time"},
+			"on":        {Type: "string", Format: "date"},
+			"items": {Type: "array", Items: &Schema{
+				Type:       "object",
+				Properties: map[string]*Schema{"name": {Type: "string"}},
+				Required:   []string{"name"},
+			}},
+		},
+		Required: []string{"operation", "a"},
+	}
+
+	tests := []struct {
+		name string
+		args string
+		want []Issue
+	}{
+		{
+			name: "valid",
+			args: `{"operation": "add", "a": 1.5, "count": 2, "verbose": true, "at": "2025-08-20T10:00:00Z", "on": "2025-08-20", "items": [{"name": "x"}]}`,
+		},
+		{
+			name: "null optional fields",
+			args: `{"operation": "add", "a": 1, "count": null}`,
+		},
+		{
+			name: "integral number as integer",
+			args: `{"operation": "add", "a": 1, "count": 2.0}`,
+		},
+		{
+			name: "invalid JSON",
+			args: `{"operation": `,
+			want: []Issue{{Message: "must be a valid JSON object"}},
+		},
+		{
+			name: "not an object",
+			args: `[1, 2]`,
+			want: []Issue{{Message: "must be an object, got array"}},
+		},
+		{
+			name: "blank arguments missing required fields",
+			args: ``,
+			want: []Issue{{Path: "operation", Message: "is required"}, {Path: "a", Message: "is required"}},
+		},
+		{
+			name: "null required field",
+			args: `{"operation": "add", "a": null}`,
+			want: []Issue{{Path: "a", Message: "is required"}},
+		},
+		{
+			name: "wrong types",
+			args: `{"operation": 1, "a": "1", "count": 1.5, "verbose": "yes"}`,
+			want: []Issue{
+				{Path: "a", Message: "must be a number, got string"},
+				{Path: "count", Message: "must be an integer, got 1.5"},
+				{Path: "operation", Message: "must be a string, got number"},
+				{Path: "verbose", Message: "must be a boolean, got string"},
+			},
+		},
+		{
+			name: "not in enum",
+			args: `{"operation": "power", "a": 1}`,
+			want: []Issue{{Path: "operation", Message: `must be one of add, subtract, got "power"`}},
+		},
+		{
+			name: "formats",
+			args: `{"operation": "add", "a": 1, "at": "2025-08-20", "on": "20/08/2025"}`,
+			want: []Issue{
+				{Path: "at", Message: `must be a date-time in RFC 3339 format, e.g. 2025-08-20T10:00:00Z, got "2025-08-20"`},
+				{Path: "on", Message: `must be a date in YYYY-MM-DD format, got "20/08/2025"`},
+			},
+		},
+		{
+			name: "nested items",
+			args: `{"operation": "add", "a": 1, "items": [{"name": "x"}, {}, {"name": 2}]}`,
+			want: []Issue{
+				{Path: "items[1].name", Message: "is required"},
+				{Path: "items[2].name", Message: "must be a string, got number"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, schema.Validate(tt.args)); diff != "" {
+				t.Errorf("Validate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSchemaOf(t *testing.T) {
+	type item struct {
+		Name string `json:"name"`
+	}
+
+	type args struct {
+		Mode     string    `json:"mode" description:"The mode" enum:"fast,slow"`
+		Count    int       `json:"count,omitempty"`
+		Ratio    *float64  `json:"ratio"`
+		At       time.Time `json:"at,omitempty"`
+		On       string    `json:"on" format:"date"`
+		Items    []item    `json:"items,omitempty"`
+		Skipped  string    `json:"-"`
+		internal string
+	}
+
+	want := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"mode":  {Type: "string", Description: "The mode", Enum: []string{"fast", "slow"}},
+			"count": {Type: "integer"},
+			"ratio": {Type: "number"},
+			"at":    {Type: "string", Format: "date-time"},
+			"on":    {Type: "string", Format: "date"},
+			"items": {Type: "array", Items: &Schema{
+				Type:       "object",
+				Properties: map[string]*Schema{"name": {Type: "string"}},
+				Required:   []string{"name"},
+			}},
+		},
+		Required: []string{"mode", "on"},
+	}
+
+	if diff := cmp.Diff(want, SchemaOf[args]()); diff != "" {
+		t.Errorf("SchemaOf() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRegistry_ExecuteErrors(t *testing.T) {
+	r := NewRegistry()
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		tool   string
+		args   string
+		want   *ToolError
+		result string
+	}{
+		{
+			name:   "unknown tool",
+			tool:   "get_stock_price",
+			args:   `{}`,
+			want:   &ToolError{Tool: "get_stock_price", Code: CodeUnknownTool, Message: "unknown tool: get_stock_price"},
+			result: `{"error":{"tool":"get_stock_price","code":"unknown_tool","message":"unknown tool: get_stock_price"}}`,
+		},
+		{
+			name: "invalid arguments",
+			tool: "calculate",
+			args: `{"operation": "power", "a": 2}`,
+			want: &ToolError{Tool: "calculate", Code: CodeInvalidArguments, Message: "invalid arguments", Issues: []Issue{
+				{Path: "expression", Message: "is required"},
+			}},
+			result: `{"error":{"tool":"calculate","code":"invalid_arguments","message":"invalid arguments","issues":[{"path":"expression","message":"is required"}]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.Execute(ctx, tt.tool, tt.args)
+
+			var got *ToolError
+			if !errors.As(err, &got) {
+				t.Fatalf("Execute() error = %v, want a *ToolError", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(ToolError{})); diff != "" {
+				t.Errorf("Execute() error mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.result, got.Result()); diff != "" {
+				t.Errorf("Result() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestToolError_Error(t *testing.T) {
+	err := &ToolError{Code: CodeInvalidArguments, Message: "invalid arguments", Issues: []Issue{
+		{Message: "must be a valid JSON object"},
+		{Path: "a", Message: "is required"},
+	}}
+
+	if got, want := err.Error(), "invalid arguments: arguments must be a valid JSON object; a is required"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
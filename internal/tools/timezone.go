@@ -0,0 +1,117 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	// Embedded so zones resolve on hosts without a zoneinfo database, e.g. in minimal containers.
+	_ "time/tzdata"
+)
+
+// cityZones maps cities, in lower case, to their IANA time zone, for the ones not named after them.
+var cityZones = map[string]string{
+	"abu dhabi":      "Asia/Dubai",
+	"barcelona":      "Europe/Madrid",
+	"beijing":        "Asia/Shanghai",
+	"boston":         "America/New_York",
+	"cancun":         "America/Cancun",
+	"cape town":      "Africa/Johannesburg",
+	"delhi":          "Asia/Kolkata",
+	"doha":           "Asia/Qatar",
+	"florence":       "Europe/Rome",
+	"frankfurt":      "Europe/Berlin",
+	"geneva":         "Europe/Zurich",
+	"hanoi":          "Asia/Ho_Chi_Minh",
+	"honolulu":       "Pacific/Honolulu",
+	"ibiza":          "Europe/Madrid",
+	"las vegas":      "America/Los_Angeles",
+	"miami":          "America/New_York",
+	"milan":          "Europe/Rome",
+	"montreal":       "America/Toronto",
+	"mumbai":         "Asia/Kolkata",
+	"munich":         "Europe/Berlin",
+	"new delhi":      "Asia/Kolkata",
+	"nice":           "Europe/Paris",
+	"orlando":        "America/New_York",
+	"osaka":          "Asia/Tokyo",
+	"porto":          "Europe/Lisbon",
+	"rio de janeiro": "America/Sao_Paulo",
+	"san francisco":  "America/Los_Angeles",
+	"seattle":        "America/Los_Angeles",
+	"seville":        "Europe/Madrid",
+	"sydney":         "Australia/Sydney",
+	"valencia":       "Europe/Madrid",
+	"venice":         "Europe/Rome",
+	"washington":     "America/New_York",
+}
+
+// zoneRegions are the IANA areas cities are looked up in, when not named after a zone in full.
+var zoneRegions = []string{"Europe", "America", "Asia", "Africa", "Australia", "Pacific", "Atlantic", "Indian"}
+
+// LoadZone returns the time zone of an IANA name, e.g. Asia/Tokyo, or of a city, e.g. Tokyo or San Francisco.
+// An empty name is the server's local zone.
+func LoadZone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return time.Local, nil
+	}
+
+	// LoadLocation also accepts paths, which are not zone names.
+	if !strings.Contains(name, "..") {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc, nil
+		}
+	}
+
+	city := strings.ToLower(name)
+	if zone, ok := cityZones[city]; ok {
+		return time.LoadLocation(zone)
+	}
+
+	// IANA zones are named after cities, capitalized, without accents and with underscores, e.g. America/New_York
+	// or America/Sao_Paulo.
+	words := strings.Fields(fold(city))
+	for i, word := range words {
+		first, size := utf8.DecodeRuneInString(word)
+		words[i] = string(unicode.ToUpper(first)) + word[size:]
+	}
+	for _, region := range zoneRegions {
+		if loc, err := time.LoadLocation(region + "/" + strings.Join(words, "_")); err == nil {
+			return loc, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown time zone or city %q, use an IANA time zone such as Europe/Madrid", name)
+}
+
+// zoneName returns the name of loc with its abbreviation and UTC offset at t, e.g. "Asia/Tokyo (JST, UTC+09:00)".
+func zoneName(loc *time.Location, t time.Time) string {
+	t = t.In(loc)
+	abbrev, _ := t.Zone()
+
+	// Zones without an abbreviation of their own use their offset, e.g. "+04".
+	if strings.HasPrefix(abbrev, "+") || strings.HasPrefix(abbrev, "-") {
+		return fmt.Sprintf("%s (%s)", loc, utcOffset(t))
+	}
+	return fmt.Sprintf("%s (%s, %s)", loc, abbrev, utcOffset(t))
+}
+
+// utcOffset returns the UTC offset of t, e.g. "UTC+09:00".
+func utcOffset(t time.Time) string {
+	_, offset := t.Zone()
+
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	return fmt.Sprintf("UTC%s%02d:%02d", sign, offset/3600, offset%3600/60)
+}
+
+// isoWeek returns the ISO week of t, e.g. "2026-W42".
+func isoWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
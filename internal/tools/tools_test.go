@@ -2,7 +2,11 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestCalculatorTool(t *testing.T) {
@@ -17,37 +21,145 @@ func TestCalculatorTool(t *testing.T) {
 	}{
 		{
 			name:     "addition",
-			args:     `{"operation": "add", "a": 5, "b": 3}`,
+			args:     `{"expression": "5 + 3"}`,
 			expected: "5 + 3 = 8",
 			hasError: false,
 		},
 		{
 			name:     "subtraction",
-			args:     `{"operation": "subtract", "a": 10, "b": 4}`,
+			args:     `{"expression": "10 - 4"}`,
 			expected: "10 - 4 = 6",
 			hasError: false,
 		},
 		{
 			name:     "multiplication",
-			args:     `{"operation": "multiply", "a": 6, "b": 7}`,
+			args:     `{"expression": "6 * 7"}`,
 			expected: "6 * 7 = 42",
 			hasError: false,
 		},
 		{
 			name:     "division",
-			args:     `{"operation": "divide", "a": 15, "b": 3}`,
+			args:     `{"expression": "15 / 3"}`,
 			expected: "15 / 3 = 5",
 			hasError: false,
 		},
 		{
-			name:     "division by zero",
-			args:     `{"operation": "divide", "a": 10, "b": 0}`,
-			expected: "Error: Division by zero is not allowed",
+			name:     "exact decimals",
+			args:     `{"expression": "0.1 + 0.2"}`,
+			expected: "0.1 + 0.2 = 0.3",
+			hasError: false,
+		},
+		{
+			name:     "money",
+			args:     `{"expression": "19.99 * 3"}`,
+			expected: "19.99 * 3 = 59.97",
+			hasError: false,
+		},
+		{
+			name:     "precedence and parentheses",
+			args:     `{"expression": "2+3*(4-1)^2"}`,
+			expected: "2 + 3 * (4 - 1) ^ 2 = 29",
+			hasError: false,
+		},
+		{
+			name:     "right-associative power",
+			args:     `{"expression": "2^3^2"}`,
+			expected: "2 ^ 3 ^ 2 = 512",
+			hasError: false,
+		},
+		{
+			name:     "negative power",
+			args:     `{"expression": "-2**2 + (-2)^2"}`,
+			expected: "-2 ^ 2 + (-2) ^ 2 = 0",
+			hasError: false,
+		},
+		{
+			name:     "redundant parentheses",
+			args:     `{"expression": "((1 + 2)) - (3 - 4)"}`,
+			expected: "1 + 2 - (3 - 4) = 4",
+			hasError: false,
+		},
+		{
+			name:     "percentages",
+			args:     `{"expression": "1200 * 15%"}`,
+			expected: "1200 * 15% = 180",
+			hasError: false,
+		},
+		{
+			name:     "modulo",
+			args:     `{"expression": "17 % 5 + 17 mod 3"}`,
+			expected: "17 mod 5 + 17 mod 3 = 4",
+			hasError: false,
+		},
+		{
+			name:     "functions",
+			args:     `{"expression": "round(10 / 3, 2) + ABS(-1) + max(1, 4, 2) - min(3, floor(2.7), ceil(0.2))"}`,
+			expected: "round(10 / 3, 2) + abs(-1) + max(1, 4, 2) - min(3, floor(2.7), ceil(0.2)) = 7.33",
+			hasError: false,
+		},
+		{
+			name:     "exact square root",
+			args:     `{"expression": "sqrt(2.25) × 2 ÷ 3"}`,
+			expected: "sqrt(2.25) * 2 / 3 = 1",
+			hasError: false,
+		},
+		{
+			name:     "inexact square root",
+			args:     `{"expression": "sqrt(2)"}`,
+			expected: "sqrt(2) ≈ 1.4142135623730950488",
+			hasError: false,
+		},
+		{
+			name:     "repeating decimal",
+			args:     `{"expression": "1/3"}`,
+			expected: "1 / 3 ≈ 0.33333333333333333333",
+			hasError: false,
+		},
+		{
+			name:     "scientific notation",
+			args:     `{"expression": "1.5e3 + 1"}`,
+			expected: "1500 + 1 = 1501",
 			hasError: false,
 		},
 		{
-			name:     "invalid operation",
-			args:     `{"operation": "power", "a": 2, "b": 3}`,
+			name:     "division by zero",
+			args:     `{"expression": "10 / 0"}`,
+			expected: "",
+			hasError: true,
+		},
+		{
+			name:     "fractional exponent",
+			args:     `{"expression": "2 ^ 0.5"}`,
+			expected: "",
+			hasError: true,
+		},
+		{
+			name:     "result too large",
+			args:     `{"expression": "10 ^ 1000 ^ 1000"}`,
+			expected: "",
+			hasError: true,
+		},
+		{
+			name:     "unknown function",
+			args:     `{"expression": "cbrt(8)"}`,
+			expected: "",
+			hasError: true,
+		},
+		{
+			name:     "syntax error",
+			args:     `{"expression": "(1 + 2"}`,
+			expected: "",
+			hasError: true,
+		},
+		{
+			name:     "wrong arity",
+			args:     `{"expression": "sqrt(1, 2)"}`,
+			expected: "",
+			hasError: true,
+		},
+		{
+			name:     "empty",
+			args:     `{"expression": ""}`,
 			expected: "",
 			hasError: true,
 		},
@@ -77,14 +189,14 @@ func TestToolRegistry(t *testing.T) {
 	
 	// Test that all tools are registered.
 	tools := registry.GetTools()
-	expectedTools := []string{"get_weather", "get_today_date", "get_holidays", "calculate"}
+	expectedTools := []string{"get_weather", "get_today_date", "get_holidays", "calculate", "convert_currency", "convert_time", "calculate_date", "find_airport", "calculate_distance", "get_flight_status"}
 	
 	if len(tools) != len(expectedTools) {
 		t.Errorf("expected %d tools, got %d", len(expectedTools), len(tools))
 	}
 	
 	// Test tool execution.
-	result, err := registry.Execute(context.Background(), "calculate", `{"operation": "add", "a": 2, "b": 3}`)
+	result, err := registry.Execute(context.Background(), "calculate", `{"expression": "2 + 3"}`)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -99,4 +211,89 @@ func TestToolRegistry(t *testing.T) {
 	if err == nil {
 		t.Error("expected error for unknown tool")
 	}
-}
\ No newline at end of file
+}
+func TestRegistry_Select(t *testing.T) {
+	registry := NewRegistry()
+
+	tests := []struct {
+		name     string
+		enabled  []string
+		disabled []string
+		want     []string
+	}{
+		{name: "everything", want: []string{"calculate", "calculate_date", "calculate_distance", "convert_currency", "convert_time", "find_airport", "get_flight_status", "get_holidays", "get_today_date", "get_weather"}},
+		{name: "enabled only", enabled: []string{"calculate", "get_weather", "get_stock_price"}, want: []string{"calculate", "get_weather"}},
+		{name: "disabled only", disabled: []string{"get_weather", "get_holidays"}, want: []string{"calculate", "calculate_date", "calculate_distance", "convert_currency", "convert_time", "find_airport", "get_flight_status", "get_today_date"}},
+		{name: "disabled wins", enabled: []string{"calculate", "get_weather"}, disabled: []string{"get_weather"}, want: []string{"calculate"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected := registry.Select(tt.enabled, tt.disabled)
+
+			if diff := cmp.Diff(tt.want, selected.Names()); diff != "" {
+				t.Errorf("Names() mismatch (-want +got):\n%s", diff)
+			}
+
+			var advertised []string
+			for _, tool := range selected.GetTools() {
+				advertised = append(advertised, tool.Name)
+			}
+			if diff := cmp.Diff(tt.want, advertised); diff != "" {
+				t.Errorf("GetTools() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("tools left out are not executed", func(t *testing.T) {
+		selected := registry.Select(nil, []string{"calculate"})
+
+		if _, err := selected.Execute(context.Background(), "calculate", `{"expression": "2 + 3"}`); err == nil {
+			t.Error("expected error for a disabled tool")
+		}
+	})
+}
+
+// blockingTool blocks until released, ignoring its context.
+type blockingTool struct {
+	release chan struct{}
+}
+
+func (b *blockingTool) Name() string           { return "block" }
+func (b *blockingTool) Description() string    { return "Blocks until released" }
+func (b *blockingTool) Parameters() *Schema    { return &Schema{Type: "object"} }
+func (b *blockingTool) Timeout() time.Duration { return 10 * time.Millisecond }
+
+func (b *blockingTool) Execute(ctx context.Context, args string) (string, error) {
+	<-b.release
+	return "released", nil
+}
+
+func TestRegistry_ExecuteDeadline(t *testing.T) {
+	tool := &blockingTool{release: make(chan struct{})}
+	defer close(tool.release)
+
+	r := &Registry{}
+	r.Register(tool)
+
+	t.Run("timeout", func(t *testing.T) {
+		_, err := r.Execute(context.Background(), "block", `{}`)
+
+		var terr *ToolError
+		if !errors.As(err, &terr) || terr.Code != CodeTimeout || !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Execute() error = %v, want a timeout", err)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(time.Millisecond, cancel)
+
+		_, err := r.Execute(ctx, "block", `{}`)
+
+		var terr *ToolError
+		if !errors.As(err, &terr) || terr.Code != CodeCanceled || !errors.Is(err, context.Canceled) {
+			t.Fatalf("Execute() error = %v, want a cancellation", err)
+		}
+	})
+}
@@ -3,18 +3,123 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
-	"net/url"
 	"os"
-	"strings"
+	"sync"
 	"time"
+)
+
+// WeatherProvider provides current weather and forecasts. Measures are metric: °C, km/h and mm.
+type WeatherProvider interface {
+	Weather(ctx context.Context, query WeatherQuery) (*Weather, error)
+}
+
+// WeatherQuery asks for the weather of a location.
+type WeatherQuery struct {
+	// Location is a place name, or coordinates as "latitude,longitude".
+	Location string
+	// Days is the number of days of daily forecast, from today, none when 0.
+	Days int
+	// Hours is the number of hours of hourly forecast, from the current hour, none when 0.
+	Hours int
+}
+
+// ErrUnknownLocation is returned by weather providers for locations they cannot find.
+var ErrUnknownLocation = errors.New("unknown location")
+
+// Weather is the current weather and forecast of a location. Times are local to the location.
+type Weather struct {
+	Location WeatherLocation   `json:"location"`
+	Units    WeatherUnits      `json:"units"`
+	Current  WeatherConditions `json:"current"`
+	Daily    []DailyForecast   `json:"daily,omitempty"`
+	Hourly   []HourlyForecast  `json:"hourly,omitempty"`
+}
+
+// WeatherLocation is the place a weather is of.
+type WeatherLocation struct {
+	Name      string  `json:"name"`
+	Region    string  `json:"region,omitempty"`
+	Country   string  `json:"country,omitempty"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Timezone  string  `json:"timezone,omitempty"`
+}
+
+// WeatherUnits are the units of the measures of a weather.
+type WeatherUnits struct {
+	Temperature   string `json:"temperature"`
+	WindSpeed     string `json:"wind_speed"`
+	Precipitation string `json:"precipitation"`
+}
+
+// WeatherConditions are the current conditions of a location.
+type WeatherConditions struct {
+	Time        string  `json:"time"`
+	Condition   string  `json:"condition"`
+	Temperature float64 `json:"temperature"`
+	FeelsLike   float64 `json:"feels_like"`
+	WindSpeed   float64 `json:"wind_speed"`
+	Humidity    int     `json:"humidity"`
+}
+
+// DailyForecast is the forecast of a day, with its precipitation in total.
+type DailyForecast struct {
+	Date                     string  `json:"date"`
+	Condition                string  `json:"condition"`
+	TemperatureMin           float64 `json:"temperature_min"`
+	TemperatureMax           float64 `json:"temperature_max"`
+	Precipitation            float64 `json:"precipitation"`
+	PrecipitationProbability int     `json:"precipitation_probability"`
+}
 
-	"github.com/openai/openai-go/v2"
+// HourlyForecast is the forecast of an hour.
+type HourlyForecast struct {
+	Time                     string  `json:"time"`
+	Condition                string  `json:"condition"`
+	Temperature              float64 `json:"temperature"`
+	Precipitation            float64 `json:"precipitation"`
+	PrecipitationProbability int     `json:"precipitation_probability"`
+	WindSpeed                float64 `json:"wind_speed"`
+}
+
+// Forecast limits, within what the providers offer.
+const (
+	maxForecastDays  = 14
+	maxForecastHours = 72
 )
 
+// weatherClient is shared by weather providers without a client of their own, reusing connections across calls.
+var weatherClient = &http.Client{Timeout: 10 * time.Second}
+
+// defaultWeather is the provider of WeatherTools without one: the one named by WEATHER_PROVIDER, weatherapi or
+// open-meteo, by default weatherapi.com when WEATHER_API_KEY is set and the keyless Open-Meteo otherwise.
+var defaultWeather = sync.OnceValues(func() (WeatherProvider, error) {
+	key := os.Getenv("WEATHER_API_KEY")
+
+	switch name := os.Getenv("WEATHER_PROVIDER"); {
+	case name == "weatherapi" || name == "" && key != "":
+		if key == "" {
+			return nil, errors.New("weather service unavailable: WEATHER_API_KEY not configured")
+		}
+		return &WeatherAPI{Key: key}, nil
+	case name == "open-meteo" || name == "":
+		return &OpenMeteo{}, nil
+	default:
+		return nil, fmt.Errorf("unknown WEATHER_PROVIDER %q", name)
+	}
+})
+
 // WeatherTool provides weather information for specified locations.
-type WeatherTool struct{}
+type WeatherTool struct {
+	Args[weatherRequest]
+
+	// Provider defaults to the one selected by WEATHER_PROVIDER, see defaultWeather.
+	Provider WeatherProvider
+}
 
 // Name returns the tool's identifier.
 func (w *WeatherTool) Name() string {
@@ -23,120 +128,128 @@ func (w *WeatherTool) Name() string {
 
 // Description returns what the tool does.
 func (w *WeatherTool) Description() string {
-	return "Get current weather and forecast information for a specified location"
-}
-
-// Parameters defines the tool's input schema.
-func (w *WeatherTool) Parameters() openai.FunctionParameters {
-	return openai.FunctionParameters{
-		"type": "object",
-		"properties": map[string]any{
-			"location": map[string]any{
-				"type":        "string",
-				"description": "City name, coordinates, or location query",
-			},
-			"forecast": map[string]any{
-				"type":        "boolean",
-				"description": "Include forecast information (optional)",
-			},
-		},
-		"required": []string{"location"},
-	}
+	return "Get current weather, and optionally daily and hourly forecasts, for a specified location. Returns JSON " +
+		"with the location, the units of measures, the current conditions and the forecasts, in local time."
 }
 
 type weatherRequest struct {
-	Location string `json:"location"`
-	Forecast bool   `json:"forecast,omitempty"`
-}
-
-type weatherResponse struct {
-	Location struct {
-		Name    string `json:"name"`
-		Country string `json:"country"`
-	} `json:"location"`
-	Current struct {
-		TempC     float64 `json:"temp_c"`
-		Condition struct {
-			Text string `json:"text"`
-		} `json:"condition"`
-		WindKph  float64 `json:"wind_kph"`
-		Humidity int     `json:"humidity"`
-		FeelsC   float64 `json:"feelslike_c"`
-	} `json:"current"`
-	Forecast struct {
-		ForecastDay []struct {
-			Date string `json:"date"`
-			Day  struct {
-				MaxTempC  float64 `json:"maxtemp_c"`
-				MinTempC  float64 `json:"mintemp_c"`
-				Condition struct {
-					Text string `json:"text"`
-				} `json:"condition"`
-			} `json:"day"`
-		} `json:"forecastday"`
-	} `json:"forecast"`
+	Location string `json:"location" description:"City name, coordinates, or location query"`
+	Forecast bool   `json:"forecast,omitempty" description:"Include a 3 day forecast (optional), unless days is given"`
+	Days     int    `json:"days,omitempty" description:"Optional number of days of daily forecast, from today, up to 14"`
+	Hours    int    `json:"hours,omitempty" description:"Optional number of hours of hourly forecast, from now, up to 72"`
+	Units    string `json:"units,omitempty" enum:"metric,imperial" description:"Optional units: metric (°C, km/h, mm), the default, or imperial (°F, mph, in)"`
+}
+
+// Timeout returns how long a call may take. It allows for a geocoding and a forecast request, of up to 10 seconds
+// together in practice.
+func (w *WeatherTool) Timeout() time.Duration {
+	return 15 * time.Second
+}
+
+// CacheTTL is a few minutes, weather changes slowly enough to answer repeated questions from the cache.
+func (w *WeatherTool) CacheTTL() time.Duration {
+	return 10 * time.Minute
 }
 
 // Execute retrieves weather information for the specified location.
 func (w *WeatherTool) Execute(ctx context.Context, args string) (string, error) {
-	var req weatherRequest
-	if err := json.Unmarshal([]byte(args), &req); err != nil {
-		return "", fmt.Errorf("invalid arguments: %w", err)
+	req, err := w.Decode(args)
+	if err != nil {
+		return "", err
 	}
 
-	apiKey := os.Getenv("WEATHER_API_KEY")
-	if apiKey == "" {
-		return "Weather service unavailable: API key not configured", nil
+	query := WeatherQuery{Location: req.Location, Days: req.Days, Hours: req.Hours}
+	if req.Forecast && query.Days == 0 {
+		query.Days = 3
+	}
+	if query.Days < 0 || query.Days > maxForecastDays {
+		return "", fmt.Errorf("days must be between 0 and %d", maxForecastDays)
+	}
+	if query.Hours < 0 || query.Hours > maxForecastHours {
+		return "", fmt.Errorf("hours must be between 0 and %d", maxForecastHours)
 	}
 
-	// Build API URL
-	baseURL := "http://api.weatherapi.com/v1/current.json"
-	if req.Forecast {
-		baseURL = "http://api.weatherapi.com/v1/forecast.json"
+	provider := w.Provider
+	if provider == nil {
+		if provider, err = defaultWeather(); err != nil {
+			return "", err
+		}
+	}
+
+	weather, err := provider.Weather(ctx, query)
+	if err != nil {
+		return "", fmt.Errorf("weather unavailable for %q: %w", req.Location, err)
 	}
 
-	u, _ := url.Parse(baseURL)
-	q := u.Query()
-	q.Set("key", apiKey)
-	q.Set("q", req.Location)
-	q.Set("aqi", "no")
-	if req.Forecast {
-		q.Set("days", "3")
+	if req.Units == "imperial" {
+		weather = weather.imperial()
+	} else {
+		weather.Units = WeatherUnits{Temperature: "°C", WindSpeed: "km/h", Precipitation: "mm"}
 	}
-	u.RawQuery = q.Encode()
 
-	// Make HTTP request
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get(u.String())
+	data, err := json.Marshal(weather.rounded())
 	if err != nil {
-		return "", fmt.Errorf("weather API request failed: %w", err)
+		return "", err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Sprintf("Weather information unavailable for '%s'", req.Location), nil
+	return string(data), nil
+}
+
+// imperial returns a copy of w, in imperial units.
+func (w *Weather) imperial() *Weather {
+	fahrenheit := func(c float64) float64 { return c*9/5 + 32 }
+	mph := func(kph float64) float64 { return kph / 1.609344 }
+	inches := func(mm float64) float64 { return mm / 25.4 }
+
+	out := *w
+	out.Units = WeatherUnits{Temperature: "°F", WindSpeed: "mph", Precipitation: "in"}
+
+	out.Current.Temperature = fahrenheit(w.Current.Temperature)
+	out.Current.FeelsLike = fahrenheit(w.Current.FeelsLike)
+	out.Current.WindSpeed = mph(w.Current.WindSpeed)
+
+	out.Daily = make([]DailyForecast, len(w.Daily))
+	for i, day := range w.Daily {
+		day.TemperatureMin, day.TemperatureMax = fahrenheit(day.TemperatureMin), fahrenheit(day.TemperatureMax)
+		day.Precipitation = inches(day.Precipitation)
+		out.Daily[i] = day
 	}
 
-	var weather weatherResponse
-	if err := json.NewDecoder(resp.Body).Decode(&weather); err != nil {
-		return "", fmt.Errorf("failed to parse weather response: %w", err)
+	out.Hourly = make([]HourlyForecast, len(w.Hourly))
+	for i, hour := range w.Hourly {
+		hour.Temperature, hour.WindSpeed = fahrenheit(hour.Temperature), mph(hour.WindSpeed)
+		hour.Precipitation = inches(hour.Precipitation)
+		out.Hourly[i] = hour
 	}
 
-	// Format response
-	var result strings.Builder
-	fmt.Fprintf(&result, "Weather in %s, %s:\n", weather.Location.Name, weather.Location.Country)
-	fmt.Fprintf(&result, "Temperature: %.1f°C (feels like %.1f°C)\n", weather.Current.TempC, weather.Current.FeelsC)
-	fmt.Fprintf(&result, "Condition: %s\n", weather.Current.Condition.Text)
-	fmt.Fprintf(&result, "Wind: %.1f km/h\n", weather.Current.WindKph)
-	fmt.Fprintf(&result, "Humidity: %d%%", weather.Current.Humidity)
-
-	if req.Forecast && len(weather.Forecast.ForecastDay) > 0 {
-		result.WriteString("\n\nForecast:\n")
-		for _, day := range weather.Forecast.ForecastDay {
-			fmt.Fprintf(&result, "%s: %s, %.1f°C - %.1f°C\n",
-				day.Date, day.Day.Condition.Text, day.Day.MinTempC, day.Day.MaxTempC)
-		}
+	return &out
+}
+
+// rounded returns a copy of w with measures rounded to a tenth, or a hundredth for inches of precipitation.
+func (w *Weather) rounded() *Weather {
+	tenth := func(v float64) float64 { return math.Round(v*10) / 10 }
+	precipitation := tenth
+	if w.Units.Precipitation == "in" {
+		precipitation = func(v float64) float64 { return math.Round(v*100) / 100 }
 	}
 
-	return result.String(), nil
-}
\ No newline at end of file
+	out := *w
+	out.Current.Temperature, out.Current.FeelsLike = tenth(w.Current.Temperature), tenth(w.Current.FeelsLike)
+	out.Current.WindSpeed = tenth(w.Current.WindSpeed)
+
+	out.Daily = make([]DailyForecast, len(w.Daily))
+	for i, day := range w.Daily {
+		day.TemperatureMin, day.TemperatureMax = tenth(day.TemperatureMin), tenth(day.TemperatureMax)
+		day.Precipitation = precipitation(day.Precipitation)
+		out.Daily[i] = day
+	}
+
+	out.Hourly = make([]HourlyForecast, len(w.Hourly))
+	for i, hour := range w.Hourly {
+		hour.Temperature, hour.WindSpeed = tenth(hour.Temperature), tenth(hour.WindSpeed)
+		hour.Precipitation = precipitation(hour.Precipitation)
+		out.Hourly[i] = hour
+	}
+
+	return &out
+}
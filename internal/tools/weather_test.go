@@ -0,0 +1,207 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// replay serves recorded responses from testdata/weather by path, and records the queries it was sent.
+type replay struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	queries []string
+}
+
+func newReplay(t *testing.T, routes map[string]string) *replay {
+	t.Helper()
+
+	r := &replay{}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		r.queries = append(r.queries, req.URL.Path+"?"+req.URL.RawQuery)
+		r.mu.Unlock()
+
+		file, ok := routes[req.URL.Path]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+
+		status := http.StatusOK
+		if strings.Contains(file, "not-found") {
+			status = http.StatusBadRequest
+		}
+
+		data, err := os.ReadFile("testdata/weather/" + file)
+		if err != nil {
+			t.Errorf("missing recording: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(r.Close)
+
+	return r
+}
+
+// query returns the parameters of the i-th request.
+func (r *replay) query(i int) url.Values {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, raw, _ := strings.Cut(r.queries[i], "?")
+	values, _ := url.ParseQuery(raw)
+	return values
+}
+
+func TestWeatherAPI(t *testing.T) {
+	srv := newReplay(t, map[string]string{"/v1/forecast.json": "weatherapi-forecast.json", "/v1/current.json": "weatherapi-not-found.json"})
+	provider := &WeatherAPI{Key: "secret", URL: srv.URL + "/v1"}
+
+	got, err := provider.Weather(context.Background(), WeatherQuery{Location: "Barcelona", Days: 1, Hours: 3})
+	if err != nil {
+		t.Fatalf("Weather() error = %v", err)
+	}
+
+	want := &Weather{
+		Location: WeatherLocation{Name: "Barcelona", Region: "Catalonia", Country: "Spain", Latitude: 41.38, Longitude: 2.18, Timezone: "Europe/Madrid"},
+		Current:  WeatherConditions{Time: "2026-10-16T22:05", Condition: "Partly cloudy", Temperature: 19.2, FeelsLike: 19.2, WindSpeed: 11.2, Humidity: 73},
+		Daily: []DailyForecast{
+			{Date: "2026-10-16", Condition: "Patchy rain nearby", TemperatureMin: 16.1, TemperatureMax: 23.4, Precipitation: 0.4, PrecipitationProbability: 60},
+		},
+		Hourly: []HourlyForecast{
+			{Time: "2026-10-16T22:00", Condition: "Partly cloudy", Temperature: 16.2, WindSpeed: 14.6},
+			{Time: "2026-10-16T23:00", Condition: "Partly cloudy", Temperature: 15.6, WindSpeed: 14.9},
+			{Time: "2026-10-17T00:00", Condition: "Sunny", Temperature: 11.4, WindSpeed: 8},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Weather() mismatch (-want +got):\n%s", diff)
+	}
+
+	// Hourly forecasts ask for the days covering them.
+	query := srv.query(0)
+	if query.Get("key") != "secret" || query.Get("q") != "Barcelona" || query.Get("days") != "2" {
+		t.Errorf("unexpected query %v", query)
+	}
+
+	if _, err := provider.Weather(context.Background(), WeatherQuery{Location: "Nowhere"}); !errors.Is(err, ErrUnknownLocation) {
+		t.Errorf("Weather() error = %v, want ErrUnknownLocation", err)
+	}
+}
+
+func TestOpenMeteo(t *testing.T) {
+	srv := newReplay(t, map[string]string{"/v1/search": "openmeteo-geocoding.json", "/v1/forecast": "openmeteo-forecast.json"})
+	provider := &OpenMeteo{GeocodingURL: srv.URL + "/v1/search", ForecastURL: srv.URL + "/v1/forecast"}
+
+	got, err := provider.Weather(context.Background(), WeatherQuery{Location: "London, Canada", Days: 2, Hours: 3})
+	if err != nil {
+		t.Fatalf("Weather() error = %v", err)
+	}
+
+	want := &Weather{
+		Location: WeatherLocation{Name: "London", Region: "Ontario", Country: "Canada", Latitude: 42.98339, Longitude: -81.23304, Timezone: "America/Toronto"},
+		Current:  WeatherConditions{Time: "2026-10-16T18:15", Condition: "Slight rain", Temperature: 9.6, FeelsLike: 6.4, WindSpeed: 17.3, Humidity: 81},
+		Daily: []DailyForecast{
+			{Date: "2026-10-16", Condition: "Moderate rain", TemperatureMin: 7.5, TemperatureMax: 13.2, Precipitation: 6.3, PrecipitationProbability: 90},
+			{Date: "2026-10-17", Condition: "Mainly clear", TemperatureMin: 4.9, TemperatureMax: 15.4, PrecipitationProbability: 5},
+		},
+		Hourly: []HourlyForecast{
+			{Time: "2026-10-16T18:00", Condition: "Slight rain", Temperature: 9.6, Precipitation: 0.6, PrecipitationProbability: 70, WindSpeed: 17.3},
+			{Time: "2026-10-16T19:00", Condition: "Overcast", Temperature: 8.9, PrecipitationProbability: 35, WindSpeed: 15.8},
+			{Time: "2026-10-16T20:00", Condition: "Partly cloudy", Temperature: 8.1, PrecipitationProbability: 10, WindSpeed: 12.4},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Weather() mismatch (-want +got):\n%s", diff)
+	}
+
+	if query := srv.query(0); query.Get("name") != "London" {
+		t.Errorf("unexpected geocoding query %v", query)
+	}
+	if query := srv.query(1); query.Get("latitude") != "42.98339" || query.Get("forecast_days") != "2" || query.Get("forecast_hours") != "3" {
+		t.Errorf("unexpected forecast query %v", query)
+	}
+
+	// Coordinates are not looked up.
+	if _, err := provider.Weather(context.Background(), WeatherQuery{Location: "41.39, 2.17"}); err != nil {
+		t.Fatalf("Weather() error = %v", err)
+	}
+	if query := srv.query(2); query.Get("latitude") != "41.39" || query.Has("daily") || query.Has("hourly") {
+		t.Errorf("unexpected forecast query %v", query)
+	}
+
+	if _, err := provider.Weather(context.Background(), WeatherQuery{Location: "London, Atlantis"}); !errors.Is(err, ErrUnknownLocation) {
+		t.Errorf("Weather() error = %v, want ErrUnknownLocation", err)
+	}
+}
+
+func TestWeatherTool(t *testing.T) {
+	srv := newReplay(t, map[string]string{"/v1/search": "openmeteo-geocoding.json", "/v1/forecast": "openmeteo-forecast.json"})
+	tool := &WeatherTool{Provider: &OpenMeteo{GeocodingURL: srv.URL + "/v1/search", ForecastURL: srv.URL + "/v1/forecast"}}
+
+	tests := []struct {
+		name    string
+		args    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "metric",
+			args: `{"location": "London, Ontario"}`,
+			want: `{"location":{"name":"London","region":"Ontario","country":"Canada","latitude":42.98339,"longitude":-81.23304,"timezone":"America/Toronto"},` +
+				`"units":{"temperature":"°C","wind_speed":"km/h","precipitation":"mm"},` +
+				`"current":{"time":"2026-10-16T18:15","condition":"Slight rain","temperature":9.6,"feels_like":6.4,"wind_speed":17.3,"humidity":81},` +
+				`"daily":[{"date":"2026-10-16","condition":"Moderate rain","temperature_min":7.5,"temperature_max":13.2,"precipitation":6.3,"precipitation_probability":90},` +
+				`{"date":"2026-10-17","condition":"Mainly clear","temperature_min":4.9,"temperature_max":15.4,"precipitation":0,"precipitation_probability":5}],` +
+				`"hourly":[{"time":"2026-10-16T18:00","condition":"Slight rain","temperature":9.6,"precipitation":0.6,"precipitation_probability":70,"wind_speed":17.3},` +
+				`{"time":"2026-10-16T19:00","condition":"Overcast","temperature":8.9,"precipitation":0,"precipitation_probability":35,"wind_speed":15.8},` +
+				`{"time":"2026-10-16T20:00","condition":"Partly cloudy","temperature":8.1,"precipitation":0,"precipitation_probability":10,"wind_speed":12.4}]}`,
+		},
+		{
+			name: "imperial",
+			args: `{"location": "London, Ontario", "forecast": true, "units": "imperial"}`,
+			want: `{"location":{"name":"London","region":"Ontario","country":"Canada","latitude":42.98339,"longitude":-81.23304,"timezone":"America/Toronto"},` +
+				`"units":{"temperature":"°F","wind_speed":"mph","precipitation":"in"},` +
+				`"current":{"time":"2026-10-16T18:15","condition":"Slight rain","temperature":49.3,"feels_like":43.5,"wind_speed":10.7,"humidity":81},` +
+				`"daily":[{"date":"2026-10-16","condition":"Moderate rain","temperature_min":45.5,"temperature_max":55.8,"precipitation":0.25,"precipitation_probability":90},` +
+				`{"date":"2026-10-17","condition":"Mainly clear","temperature_min":40.8,"temperature_max":59.7,"precipitation":0,"precipitation_probability":5}],` +
+				`"hourly":[{"time":"2026-10-16T18:00","condition":"Slight rain","temperature":49.3,"precipitation":0.02,"precipitation_probability":70,"wind_speed":10.7},` +
+				`{"time":"2026-10-16T19:00","condition":"Overcast","temperature":48,"precipitation":0,"precipitation_probability":35,"wind_speed":9.8},` +
+				`{"time":"2026-10-16T20:00","condition":"Partly cloudy","temperature":46.6,"precipitation":0,"precipitation_probability":10,"wind_speed":7.7}]}`,
+		},
+		{name: "too many days", args: `{"location": "London", "days": 30}`, wantErr: "days must be between 0 and 14"},
+		{name: "too many hours", args: `{"location": "London", "hours": 100}`, wantErr: "hours must be between 0 and 72"},
+		{name: "unknown location", args: `{"location": "London, Atlantis"}`, wantErr: `weather unavailable for "London, Atlantis": unknown location`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tool.Execute(context.Background(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Execute() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Execute() mismatch (-want +got):\n%s", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WeatherAPI provides the weather of weatherapi.com, which needs an API key.
+type WeatherAPI struct {
+	Key string
+	// Client defaults to a client shared by weather providers.
+	Client *http.Client
+	// URL defaults to http://api.weatherapi.com/v1.
+	URL string
+}
+
+type weatherAPIResponse struct {
+	Location struct {
+		Name      string  `json:"name"`
+		Region    string  `json:"region"`
+		Country   string  `json:"country"`
+		Lat       float64 `json:"lat"`
+		Lon       float64 `json:"lon"`
+		TzID      string  `json:"tz_id"`
+		Localtime string  `json:"localtime"`
+	} `json:"location"`
+	Current struct {
+		LastUpdated string  `json:"last_updated"`
+		TempC       float64 `json:"temp_c"`
+		Condition   struct {
+			Text string `json:"text"`
+		} `json:"condition"`
+		WindKph  float64 `json:"wind_kph"`
+		Humidity int     `json:"humidity"`
+		FeelsC   float64 `json:"feelslike_c"`
+	} `json:"current"`
+	Forecast struct {
+		ForecastDay []struct {
+			Date string `json:"date"`
+			Day  struct {
+				MaxTempC          float64 `json:"maxtemp_c"`
+				MinTempC          float64 `json:"mintemp_c"`
+				TotalPrecipMm     float64 `json:"totalprecip_mm"`
+				DailyChanceOfRain int     `json:"daily_chance_of_rain"`
+				Condition         struct {
+					Text string `json:"text"`
+				} `json:"condition"`
+			} `json:"day"`
+			Hour []struct {
+				Time         string  `json:"time"`
+				TempC        float64 `json:"temp_c"`
+				PrecipMm     float64 `json:"precip_mm"`
+				ChanceOfRain int     `json:"chance_of_rain"`
+				WindKph      float64 `json:"wind_kph"`
+				Condition    struct {
+					Text string `json:"text"`
+				} `json:"condition"`
+			} `json:"hour"`
+		} `json:"forecastday"`
+	} `json:"forecast"`
+}
+
+// Weather fetches the current weather, and the forecast when asked for. Hourly forecasts come from the days
+// of the forecast, so enough days are fetched to cover the hours.
+func (p *WeatherAPI) Weather(ctx context.Context, query WeatherQuery) (*Weather, error) {
+	base := "http://api.weatherapi.com/v1"
+	if p.URL != "" {
+		base = strings.TrimSuffix(p.URL, "/")
+	}
+
+	days := max(query.Days, (query.Hours+23)/24+1)
+	if query.Hours == 0 {
+		days = query.Days
+	}
+
+	endpoint := base + "/current.json"
+	if days > 0 {
+		endpoint = base + "/forecast.json"
+	}
+
+	q := url.Values{}
+	q.Set("key", p.Key)
+	q.Set("q", query.Location)
+	q.Set("aqi", "no")
+	if days > 0 {
+		q.Set("days", strconv.Itoa(days))
+		q.Set("alerts", "no")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := p.Client
+	if client == nil {
+		client = weatherClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("weather API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var failure struct {
+			Error struct {
+				Code    int    `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&failure)
+
+		// 1006 is the code of locations that are not found.
+		if failure.Error.Code == 1006 {
+			return nil, ErrUnknownLocation
+		}
+		return nil, fmt.Errorf("weather API request failed: %s %s", resp.Status, failure.Error.Message)
+	}
+
+	var data weatherAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to parse weather response: %w", err)
+	}
+
+	weather := &Weather{
+		Location: WeatherLocation{
+			Name:      data.Location.Name,
+			Region:    data.Location.Region,
+			Country:   data.Location.Country,
+			Latitude:  data.Location.Lat,
+			Longitude: data.Location.Lon,
+			Timezone:  data.Location.TzID,
+		},
+		Current: WeatherConditions{
+			Time:        localTime(data.Location.Localtime),
+			Condition:   data.Current.Condition.Text,
+			Temperature: data.Current.TempC,
+			FeelsLike:   data.Current.FeelsC,
+			WindSpeed:   data.Current.WindKph,
+			Humidity:    data.Current.Humidity,
+		},
+	}
+
+	// Hours are counted from the current one.
+	from := weather.Current.Time[:min(len(weather.Current.Time), len("2006-01-02T15"))]
+	for i, day := range data.Forecast.ForecastDay {
+		if i < query.Days {
+			weather.Daily = append(weather.Daily, DailyForecast{
+				Date:                     day.Date,
+				Condition:                day.Day.Condition.Text,
+				TemperatureMin:           day.Day.MinTempC,
+				TemperatureMax:           day.Day.MaxTempC,
+				Precipitation:            day.Day.TotalPrecipMm,
+				PrecipitationProbability: day.Day.DailyChanceOfRain,
+			})
+		}
+
+		for _, hour := range day.Hour {
+			t := localTime(hour.Time)
+			if len(weather.Hourly) >= query.Hours || t < from {
+				continue
+			}
+
+			weather.Hourly = append(weather.Hourly, HourlyForecast{
+				Time:                     t,
+				Condition:                hour.Condition.Text,
+				Temperature:              hour.TempC,
+				Precipitation:            hour.PrecipMm,
+				PrecipitationProbability: hour.ChanceOfRain,
+				WindSpeed:                hour.WindKph,
+			})
+		}
+	}
+
+	return weather, nil
+}
+
+// localTime formats the local times of weatherapi.com, e.g. "2026-10-16 14:05", like the ones of Open-Meteo,
+// "2026-10-16T14:05".
+func localTime(s string) string {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		return s
+	}
+	return t.Format("2006-01-02T15:04")
+}